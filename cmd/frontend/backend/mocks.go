@@ -13,7 +13,8 @@ import (
 var Mocks MockServices
 
 type MockServices struct {
-	Repos MockRepos
+	Repos   MockRepos
+	Symbols MockSymbols
 }
 
 // testContext creates a new context.Context for use by tests
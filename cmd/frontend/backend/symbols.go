@@ -15,9 +15,17 @@ type symbols struct{}
 
 // ListTags returns symbols in a repository from ctags.
 func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+	if Mocks.Symbols.ListTags != nil {
+		return Mocks.Symbols.ListTags(ctx, args)
+	}
+
 	result, err := symbolsclient.DefaultClient.Search(ctx, args)
 	if result == nil {
 		return nil, err
 	}
 	return result.Symbols, err
 }
+
+type MockSymbols struct {
+	ListTags func(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error)
+}
@@ -21,12 +21,13 @@ type AddChangesetsToCampaignArgs struct {
 
 type CreateCampaignArgs struct {
 	Input struct {
-		Namespace   graphql.ID
-		Name        string
-		Description string
-		Branch      *string
-		Plan        *graphql.ID
-		Draft       *bool
+		Namespace      graphql.ID
+		Name           string
+		Description    string
+		Branch         *string
+		Plan           *graphql.ID
+		Draft          *bool
+		IdempotencyKey *string
 	}
 }
 
@@ -52,12 +53,15 @@ type CampaignPlanPatch struct {
 
 type ListCampaignArgs struct {
 	First *int32
+	After *string
 	State *string
+	Query *string
 }
 
 type DeleteCampaignArgs struct {
 	Campaign        graphql.ID
 	CloseChangesets bool
+	Reason          *string
 }
 
 type RetryCampaignArgs struct {
@@ -173,7 +173,7 @@ func (d *discussionThreadTargetRepoInput) populateLinesFromRepository(ctx contex
 	if err != nil {
 		return err
 	}
-	fileContent, err := blob.Content(ctx)
+	fileContent, err := blob.content(ctx)
 	if err != nil {
 		return err
 	}
@@ -473,7 +473,11 @@ func (r *discussionThreadTargetRepoResolver) RelativePath(ctx context.Context, a
 		return nil, err
 	}
 	currentPath := *r.t.Path
-	fileDiffs, err := comparison.FileDiffs(&graphqlutil.ConnectionArgs{}).Nodes(ctx)
+	fileDiffConnection, err := comparison.FileDiffs(&fileDiffsConnectionArgs{})
+	if err != nil {
+		return nil, err
+	}
+	fileDiffs, err := fileDiffConnection.Nodes(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -609,7 +613,7 @@ func (r *discussionThreadTargetRepoResolver) RelativeSelection(ctx context.Conte
 	if err != nil {
 		return nil, err
 	}
-	newContent, err := file.Content(ctx)
+	newContent, err := file.content(ctx)
 	if err != nil {
 		return nil, err
 	}
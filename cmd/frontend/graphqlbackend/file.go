@@ -1,11 +1,19 @@
 package graphqlbackend
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"fmt"
 	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
 	"path"
 	"strings"
 	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -14,7 +22,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
-func (r *GitTreeEntryResolver) Content(ctx context.Context) (string, error) {
+func (r *GitTreeEntryResolver) Content(ctx context.Context, args *struct {
+	TranscodeToUTF8 bool
+}) (string, error) {
+	content, err := r.content(ctx)
+	if err != nil {
+		return "", err
+	}
+	if args.TranscodeToUTF8 {
+		return transcodeToUTF8([]byte(content), detectEncoding([]byte(content))), nil
+	}
+	return content, nil
+}
+
+// content returns the raw content of the file, without any transcoding. For a Git LFS pointer
+// file, it is the content of the LFS object if it is available and at most lfsObjectMaxBytes
+// large, and the pointer otherwise.
+func (r *GitTreeEntryResolver) content(ctx context.Context) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -28,9 +52,63 @@ func (r *GitTreeEntryResolver) Content(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	if p := parseLFSPointer(contents); p != nil {
+		object, err := readLFSObject(ctx, r.commit.repo.repo, p)
+		if err == nil {
+			return string(object), nil
+		}
+		if !os.IsNotExist(err) && err != errLFSObjectTooLarge {
+			return "", err
+		}
+	}
+
 	return string(contents), nil
 }
 
+// maxContentBytesLength is the maximum number of bytes returned by ContentBytes.
+const maxContentBytesLength = 10 * 1024 * 1024
+
+// ContentBytes returns the base64-encoded byte range [offset, offset+length) of the raw file
+// content. The range is clamped to the end of the file, so the result is empty if offset is at or
+// past the end of the file.
+//
+// Unlike content, it reads the blob stored in Git, so for a Git LFS pointer file it returns a range
+// of the pointer, not of the LFS object.
+func (r *GitTreeEntryResolver) ContentBytes(ctx context.Context, args *struct {
+	Offset int32
+	Length int32
+}) (string, error) {
+	if args.Offset < 0 {
+		return "", fmt.Errorf("offset must not be negative (got %d)", args.Offset)
+	}
+	if args.Length < 0 || args.Length > maxContentBytesLength {
+		return "", fmt.Errorf("length must be between 0 and %d (got %d)", maxContentBytesLength, args.Length)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return "", err
+	}
+
+	rc, err := git.NewFileReader(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path())
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	if _, err := io.CopyN(ioutil.Discard, rc, int64(args.Offset)); err != nil && err != io.EOF {
+		return "", err
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(rc, int64(args.Length)))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
 func (r *GitTreeEntryResolver) RichHTML(ctx context.Context) (string, error) {
 	switch path.Ext(r.Path()) {
 	case ".md", ".mdown", ".markdown", ".markdn":
@@ -38,7 +116,7 @@ func (r *GitTreeEntryResolver) RichHTML(ctx context.Context) (string, error) {
 	default:
 		return "", nil
 	}
-	content, err := r.Content(ctx)
+	content, err := r.content(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -77,13 +155,74 @@ func (*schemaResolver) HighlightCode(ctx context.Context, args *struct {
 }
 
 func (r *GitTreeEntryResolver) Binary(ctx context.Context) (bool, error) {
-	content, err := r.Content(ctx)
+	content, err := r.content(ctx)
 	if err != nil {
 		return false, err
 	}
 	return highlight.IsBinary([]byte(content)), nil
 }
 
+// The encodings reported by (*GitTreeEntryResolver).Encoding.
+const (
+	encodingUTF8    = "utf-8"
+	encodingUTF16LE = "utf-16le"
+	encodingUTF16BE = "utf-16be"
+	encodingLatin1  = "iso-8859-1"
+	encodingBinary  = "binary"
+)
+
+func (r *GitTreeEntryResolver) Encoding(ctx context.Context) (string, error) {
+	content, err := r.content(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detectEncoding([]byte(content)), nil
+}
+
+// detectEncoding returns the character encoding of content. UTF-16 is only
+// detected when the content starts with a byte order mark. Content that is
+// neither valid UTF-8 nor binary is assumed to be ISO-8859-1 (Latin-1).
+func detectEncoding(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xff, 0xfe}):
+		return encodingUTF16LE
+	case bytes.HasPrefix(content, []byte{0xfe, 0xff}):
+		return encodingUTF16BE
+	case utf8.Valid(content):
+		return encodingUTF8
+	case highlight.IsBinary(content):
+		return encodingBinary
+	}
+	return encodingLatin1
+}
+
+// transcodeToUTF8 converts content from the given encoding (as returned by
+// detectEncoding) to UTF-8. UTF-8 and binary content is returned unchanged.
+func transcodeToUTF8(content []byte, encoding string) string {
+	switch encoding {
+	case encodingUTF16LE, encodingUTF16BE:
+		content = content[2:] // strip the byte order mark
+		units := make([]uint16, len(content)/2)
+		for i := range units {
+			lo, hi := content[2*i], content[2*i+1]
+			if encoding == encodingUTF16BE {
+				lo, hi = hi, lo
+			}
+			units[i] = uint16(hi)<<8 | uint16(lo)
+		}
+		return string(utf16.Decode(units))
+	case encodingLatin1:
+		// ISO-8859-1 maps each byte directly to the Unicode code point of the
+		// same value.
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return string(content)
+}
+
 type highlightedFileResolver struct {
 	aborted bool
 	html    string
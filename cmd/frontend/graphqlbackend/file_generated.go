@@ -0,0 +1,97 @@
+package graphqlbackend
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/src-d/enry/v2"
+)
+
+var (
+	// vendoredPathPatterns match the paths of vendored files, in addition to linguist's vendor
+	// patterns.
+	vendoredPathPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(^|/)third[_-]?party/`),
+	}
+
+	// generatedPathPatterns match the paths of generated files.
+	generatedPathPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\.pb\.go$`),
+		regexp.MustCompile(`_pb2(_grpc)?\.py$`),
+		regexp.MustCompile(`(^|/)(bindata|zz_generated[^/]*)\.go$`),
+		regexp.MustCompile(`\.min\.(js|css)$`),
+		regexp.MustCompile(`\.(js|css)\.map$`),
+		regexp.MustCompile(`(^|/)(package-lock\.json|yarn\.lock|Gopkg\.lock|Cargo\.lock|composer\.lock)$`),
+		regexp.MustCompile(`\.designer\.(cs|vb)$`),
+	}
+
+	// generatedContentMarkers match markers near the beginning of generated files.
+	generatedContentMarkers = []*regexp.Regexp{
+		// https://golang.org/s/generatedcode
+		regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`),
+		regexp.MustCompile(`@generated\b`),
+		regexp.MustCompile(`(?i)\b(auto-?generated|generated by|do not edit)\b`),
+	}
+)
+
+// generatedContentMarkerBytes is the number of bytes at the beginning of a file that are searched
+// for generatedContentMarkers.
+const generatedContentMarkerBytes = 1024
+
+// IsVendored reports whether the file is vendored, i.e. copied from a third party, based on its
+// path.
+func (r *GitTreeEntryResolver) IsVendored(ctx context.Context) (bool, error) {
+	if r.IsDirectory() {
+		return false, nil
+	}
+	return isVendoredPath(r.Path()), nil
+}
+
+func isVendoredPath(p string) bool {
+	return enry.IsVendor(p) || matchAny(vendoredPathPatterns, p)
+}
+
+// IsGenerated reports whether the file was generated by a tool, based on its path and on markers
+// (such as "Code generated ... DO NOT EDIT.") at the beginning of its content.
+func (r *GitTreeEntryResolver) IsGenerated(ctx context.Context) (bool, error) {
+	if r.IsDirectory() {
+		return false, nil
+	}
+	if matchAny(generatedPathPatterns, r.Path()) {
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return false, err
+	}
+	rc, err := git.NewFileReader(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path())
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	head, err := ioutil.ReadAll(io.LimitReader(rc, generatedContentMarkerBytes))
+	if err != nil {
+		return false, err
+	}
+	return matchAny(generatedContentMarkers, string(head)), nil
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, p := range patterns {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
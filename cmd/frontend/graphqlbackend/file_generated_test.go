@@ -0,0 +1,88 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+func TestGitBlobIsGeneratedAndVendored(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		return &util.FileInfo{Name_: path, Mode_: 0}, nil
+	}
+	contents := map[string]string{
+		"vendor/github.com/pkg/errors/errors.go": "package errors\n",
+		"internal/api/types.go":                  "// Code generated by go-enum. DO NOT EDIT.\n\npackage api\n",
+		"internal/api/api.go":                    "// Package api is not generated.\npackage api\n",
+		"internal/api/api.pb.go":                 "package api\n",
+	}
+	git.Mocks.NewFileReader = func(commit api.CommitID, name string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(contents[name])), nil
+	}
+	defer git.ResetMocks()
+
+	tests := []struct {
+		path                    string
+		isGenerated, isVendored bool
+	}{
+		{path: "vendor/github.com/pkg/errors/errors.go", isVendored: true},
+		{path: "internal/api/types.go", isGenerated: true},  // content marker
+		{path: "internal/api/api.pb.go", isGenerated: true}, // path pattern
+		{path: "internal/api/api.go"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			gqltesting.RunTests(t, []*gqltesting.Test{
+				{
+					Schema: mustParseGraphQLSchema(t),
+					Query: fmt.Sprintf(`
+						{
+							repository(name: "github.com/gorilla/mux") {
+								commit(rev: "%s") {
+									blob(path: %q) {
+										isGenerated
+										isVendored
+									}
+								}
+							}
+						}
+					`, exampleCommitSHA1, test.path),
+					ExpectedResult: fmt.Sprintf(`
+						{
+							"repository": {
+								"commit": {
+									"blob": {
+										"isGenerated": %t,
+										"isVendored": %t
+									}
+								}
+							}
+						}
+					`, test.isGenerated, test.isVendored),
+				},
+			})
+		})
+	}
+}
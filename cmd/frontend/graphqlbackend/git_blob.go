@@ -2,9 +2,14 @@ package graphqlbackend
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/highlight"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -32,3 +37,44 @@ func (r *GitTreeEntryResolver) Blame(ctx context.Context,
 
 	return hunksResolver, nil
 }
+
+// BlobByOID returns the blob with the given object ID in the commit's repository, regardless of
+// the paths it is stored at, or nil if there is no such blob. Access is checked like for any other
+// data of the repository.
+func (r *GitCommitResolver) BlobByOID(ctx context.Context, args *struct {
+	OID string
+}) (*gitBlobObjectResolver, error) {
+	if !git.IsAbsoluteRevision(args.OID) {
+		return nil, fmt.Errorf("invalid blob OID %q: must be a 40-character hexadecimal SHA", args.OID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	content, err := git.ReadBlob(ctx, *cachedRepo, args.OID, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &gitBlobObjectResolver{oid: GitObjectID(args.OID), content: content}, nil
+}
+
+// gitBlobObjectResolver resolves a blob by its object ID, without a path.
+type gitBlobObjectResolver struct {
+	oid     GitObjectID
+	content []byte
+}
+
+func (r *gitBlobObjectResolver) OID() GitObjectID { return r.oid }
+
+func (r *gitBlobObjectResolver) ByteSize() int32 { return int32(len(r.content)) }
+
+func (r *gitBlobObjectResolver) Content() string { return string(r.content) }
+
+func (r *gitBlobObjectResolver) Binary() bool { return highlight.IsBinary(r.content) }
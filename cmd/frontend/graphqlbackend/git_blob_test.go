@@ -1,9 +1,24 @@
 package graphqlbackend
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/graph-gophers/graphql-go/gqltesting"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
 )
 
 func TestIsBinary(t *testing.T) {
@@ -73,3 +88,169 @@ func TestIsBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []byte
+		wantEncoding string
+		wantUTF8     string
+	}{
+		{
+			name:         "UTF8",
+			input:        []byte("hellö world\n"),
+			wantEncoding: encodingUTF8,
+			wantUTF8:     "hellö world\n",
+		},
+		{
+			name: "UTF16 LE with BOM",
+			// "hellö world"
+			input:        []byte{0xff, 0xfe, 0x68, 0x00, 0x65, 0x00, 0x6c, 0x00, 0x6c, 0x00, 0xf6, 0x00, 0x20, 0x00, 0x77, 0x00, 0x6f, 0x00, 0x72, 0x00, 0x6c, 0x00, 0x64, 0x00, 0x0a, 0x00},
+			wantEncoding: encodingUTF16LE,
+			wantUTF8:     "hellö world\n",
+		},
+		{
+			name: "UTF16 BE with BOM",
+			// "hellö"
+			input:        []byte{0xfe, 0xff, 0x00, 0x68, 0x00, 0x65, 0x00, 0x6c, 0x00, 0x6c, 0x00, 0xf6},
+			wantEncoding: encodingUTF16BE,
+			wantUTF8:     "hellö",
+		},
+		{
+			name: "ISO-8859-1",
+			// "hellö world"
+			input:        []byte{0x68, 0x65, 0x6c, 0x6c, 0xf6, 0x20, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x0a},
+			wantEncoding: encodingLatin1,
+			wantUTF8:     "hellö world\n",
+		},
+		{
+			name:         "binary PNG image",
+			input:        []byte{0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52},
+			wantEncoding: encodingBinary,
+			wantUTF8:     string([]byte{0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52}),
+		},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			encoding := detectEncoding(tst.input)
+			if encoding != tst.wantEncoding {
+				t.Fatalf("got encoding %q want %q", encoding, tst.wantEncoding)
+			}
+			if got := transcodeToUTF8(tst.input, encoding); got != tst.wantUTF8 {
+				t.Fatalf("got content %q want %q", got, tst.wantUTF8)
+			}
+		})
+	}
+}
+
+func TestGitBlobContentBytes(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		return &util.FileInfo{Name_: path, Mode_: 0}, nil
+	}
+	git.Mocks.NewFileReader = func(commit api.CommitID, name string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("0123456789")), nil
+	}
+	defer git.ResetMocks()
+
+	query := func(offset, length int) string {
+		return fmt.Sprintf(`
+			{
+				repository(name: "github.com/gorilla/mux") {
+					commit(rev: "%s") {
+						blob(path: "file.txt") {
+							contentBytes(offset: %d, length: %d)
+						}
+					}
+				}
+			}
+		`, exampleCommitSHA1, offset, length)
+	}
+	result := func(content string) string {
+		return fmt.Sprintf(`{"repository": {"commit": {"blob": {"contentBytes": %q}}}}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			// Mid-file slice.
+			Schema:         mustParseGraphQLSchema(t),
+			Query:          query(3, 4),
+			ExpectedResult: result("3456"),
+		},
+		{
+			// Clamped to the end of the file.
+			Schema:         mustParseGraphQLSchema(t),
+			Query:          query(8, 100),
+			ExpectedResult: result("89"),
+		},
+		{
+			// Past the end of the file.
+			Schema:         mustParseGraphQLSchema(t),
+			Query:          query(20, 5),
+			ExpectedResult: result(""),
+		},
+	})
+
+	r := &GitTreeEntryResolver{}
+	if _, err := r.ContentBytes(context.Background(), &struct {
+		Offset int32
+		Length int32
+	}{Offset: -1, Length: 1}); err == nil {
+		t.Error("got no error for negative offset")
+	}
+}
+
+func TestGitCommitBlobByOID(t *testing.T) {
+	const blobOID = "acbe86c7c89586e0912a0a851bacf309c595c308"
+	git.Mocks.ReadBlob = func(oid string) ([]byte, error) {
+		if oid != blobOID {
+			return nil, &os.PathError{Op: "cat-file", Path: oid, Err: os.ErrNotExist}
+		}
+		return []byte("abcd\n"), nil
+	}
+	defer git.ResetMocks()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	blobByOID := func(oid string) (*gitBlobObjectResolver, error) {
+		return commit.BlobByOID(context.Background(), &struct{ OID string }{OID: oid})
+	}
+
+	t.Run("valid OID", func(t *testing.T) {
+		blob, err := blobByOID(blobOID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blob == nil {
+			t.Fatal("got nil blob")
+		}
+		if blob.OID() != blobOID || blob.Content() != "abcd\n" || blob.ByteSize() != 5 || blob.Binary() {
+			t.Errorf("got blob %+v", blob)
+		}
+	})
+
+	t.Run("unknown OID", func(t *testing.T) {
+		blob, err := blobByOID("0000000000000000000000000000000000000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blob != nil {
+			t.Errorf("got blob %+v, want nil", blob)
+		}
+	})
+
+	t.Run("malformed OID", func(t *testing.T) {
+		for _, oid := range []string{"", "acbe86c", "HEAD:README.md", blobOID + "0"} {
+			if _, err := blobByOID(oid); err == nil {
+				t.Errorf("%q: got nil error, want invalid OID error", oid)
+			}
+		}
+	})
+}
@@ -1,8 +1,17 @@
 package graphqlbackend
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	neturl "net/url"
+	"os"
+	"path"
 	"strings"
 	"sync"
 
@@ -10,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 
@@ -170,6 +180,50 @@ func (r *GitCommitResolver) Parents(ctx context.Context) ([]*GitCommitResolver,
 	return resolvers, nil
 }
 
+func (r *GitCommitResolver) ParentCount(ctx context.Context) (int32, error) {
+	r.resolveCommit(ctx)
+	return int32(len(r.parents)), r.err
+}
+
+func (r *GitCommitResolver) IsMerge(ctx context.Context) (bool, error) {
+	r.resolveCommit(ctx)
+	return len(r.parents) > 1, r.err
+}
+
+// Stat returns the number of files changed and lines inserted and deleted by the commit, compared
+// to its first parent. Merge commits are also compared to their first parent (the branch that was
+// merged into), so the stat covers all changes brought in by the merge. Root commits are compared to
+// the empty tree.
+func (r *GitCommitResolver) Stat(ctx context.Context) (*gitCommitStatResolver, error) {
+	r.resolveCommit(ctx)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	base := devNullSHA
+	if len(r.parents) > 0 {
+		base = string(r.parents[0])
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := git.GetDiffStat(ctx, *cachedRepo, base, string(r.oid))
+	if err != nil {
+		return nil, err
+	}
+	return &gitCommitStatResolver{stat: *stat}, nil
+}
+
+type gitCommitStatResolver struct {
+	stat git.DiffStat
+}
+
+func (r *gitCommitStatResolver) FilesChanged() int32 { return r.stat.FilesChanged }
+func (r *gitCommitStatResolver) Insertions() int32   { return r.stat.Insertions }
+func (r *gitCommitStatResolver) Deletions() int32    { return r.stat.Deletions }
+
 func (r *GitCommitResolver) URL() (string, error) {
 	return r.repo.URL() + "/-/commit/" + string(r.inputRevOrImmutableRev()), nil
 }
@@ -178,6 +232,19 @@ func (r *GitCommitResolver) CanonicalURL() (string, error) {
 	return r.repo.URL() + "/-/commit/" + string(r.oid), nil
 }
 
+// Permalink returns this commit resolved with its immutable commit ID as the input revision, so
+// that URLs built from it don't change when the input revision (such as a branch) moves.
+func (r *GitCommitResolver) Permalink() *GitCommitResolver {
+	if r.inputRev == nil || *r.inputRev == string(r.oid) {
+		return r
+	}
+	return &GitCommitResolver{
+		repo:            r.repo,
+		includeUserInfo: r.includeUserInfo,
+		oid:             r.oid,
+	}
+}
+
 func (r *GitCommitResolver) ExternalURLs(ctx context.Context) ([]*externallink.Resolver, error) {
 	return externallink.Commit(ctx, r.repo.repo, api.CommitID(r.oid))
 }
@@ -185,12 +252,23 @@ func (r *GitCommitResolver) ExternalURLs(ctx context.Context) ([]*externallink.R
 func (r *GitCommitResolver) Tree(ctx context.Context, args *struct {
 	Path      string
 	Recursive bool
+	Pattern   *string
 }) (*GitTreeEntryResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	var pattern *treeEntryPattern
+	if args.Pattern != nil && *args.Pattern != "" {
+		if pattern, err = compileTreeEntryPattern(*args.Pattern); err != nil {
+			return nil, err
+		}
+	}
 	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
 	if err != nil {
 		return nil, err
 	}
-	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), args.Path)
+	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), p)
 	if err != nil {
 		return nil, err
 	}
@@ -201,17 +279,63 @@ func (r *GitCommitResolver) Tree(ctx context.Context, args *struct {
 		commit:      r,
 		stat:        stat,
 		isRecursive: args.Recursive,
+		pattern:     pattern,
+	}, nil
+}
+
+// TreeAtParent returns the tree or blob at the given path in the parent with the given index (0
+// for the first parent) of this commit, e.g. for the "before" side of the commit's diff. It
+// returns nil if the path doesn't exist in the parent.
+func (r *GitCommitResolver) TreeAtParent(ctx context.Context, args *struct {
+	Path        string
+	ParentIndex int32
+}) (*GitTreeEntryResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	r.resolveCommit(ctx)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if args.ParentIndex < 0 || int(args.ParentIndex) >= len(r.parents) {
+		return nil, fmt.Errorf("invalid parent index %d: commit %s has %d parents", args.ParentIndex, r.oid, len(r.parents))
+	}
+
+	parent := &GitCommitResolver{
+		repo:            r.repo,
+		includeUserInfo: r.includeUserInfo,
+		oid:             GitObjectID(r.parents[args.ParentIndex]),
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(parent.oid), p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &GitTreeEntryResolver{
+		commit: parent,
+		stat:   stat,
 	}, nil
 }
 
 func (r *GitCommitResolver) Blob(ctx context.Context, args *struct {
 	Path string
 }) (*GitTreeEntryResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
 	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
 	if err != nil {
 		return nil, err
 	}
-	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), args.Path)
+	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), p)
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +354,180 @@ func (r *GitCommitResolver) File(ctx context.Context, args *struct {
 	return r.Blob(ctx, args)
 }
 
+// BlobSize returns the size in bytes of the file at the given path in this commit. The size is
+// read from the tree, so the file's contents aren't transferred.
+func (r *GitCommitResolver) BlobSize(ctx context.Context, args *struct {
+	Path string
+}) (int32, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return 0, err
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return 0, err
+	}
+	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), p)
+	if err != nil {
+		return 0, err
+	}
+	if !stat.Mode().IsRegular() {
+		return 0, fmt.Errorf("not a blob: %q", args.Path)
+	}
+	if stat.Size() > math.MaxInt32 {
+		return 0, fmt.Errorf("blob %q is too large: %d bytes", args.Path, stat.Size())
+	}
+	return int32(stat.Size()), nil
+}
+
+// FilePermalink returns the URL to the file at the given path in this commit, pinned to the
+// commit ID. If StartLine is given, the URL has a fragment that highlights the line (or the lines
+// up to EndLine). Lines are 1-based. The path is not checked for existence.
+func (r *GitCommitResolver) FilePermalink(ctx context.Context, args *struct {
+	Path      string
+	StartLine *int32
+	EndLine   *int32
+}) (string, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("path must refer to a file, not the repository root")
+	}
+	fragment, err := lineRangeURLFragment(args.StartLine, args.EndLine)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := r.canonicalRepoRevURL()
+	if err != nil {
+		return "", err
+	}
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(u.Path, "-", "blob", p)
+	return u.String() + fragment, nil
+}
+
+// lineRangeURLFragment returns the URL fragment (such as "#L3" or "#L3-L7") that highlights the
+// given 1-based line range, or "" if no start line is given.
+func lineRangeURLFragment(start, end *int32) (string, error) {
+	if start == nil {
+		if end != nil {
+			return "", errors.New("endLine requires startLine")
+		}
+		return "", nil
+	}
+	if *start < 1 {
+		return "", fmt.Errorf("invalid startLine %d: lines start at 1", *start)
+	}
+	if end == nil || *end == *start {
+		return fmt.Sprintf("#L%d", *start), nil
+	}
+	if *end < *start {
+		return "", fmt.Errorf("invalid line range: endLine %d is before startLine %d", *end, *start)
+	}
+	return fmt.Sprintf("#L%d-L%d", *start, *end), nil
+}
+
+// cleanTreePath returns the shortest path relative to the repository root that
+// is equivalent to p, resolving "." and ".." elements. The root itself is
+// returned as "". An error is returned if p refers to a location above the
+// repository root.
+func cleanTreePath(p string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(p, "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid path %q: outside of the repository root", p)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// Files returns the files at the given paths in this commit. Duplicate paths
+// are only resolved once. A path that can't be resolved (e.g. because it does
+// not exist) is reported in its result instead of failing the whole batch.
+func (r *GitCommitResolver) Files(ctx context.Context, args *struct {
+	Paths []string
+}) ([]*gitCommitFileResultResolver, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(args.Paths))
+	results := make([]*gitCommitFileResultResolver, 0, len(args.Paths))
+	for _, p := range args.Paths {
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+
+		result := &gitCommitFileResultResolver{path: p}
+		results = append(results, result)
+
+		if p == "" {
+			result.err = errors.New("path must not be empty")
+			continue
+		}
+		cleaned, err := cleanTreePath(p)
+		if err != nil {
+			result.err = err
+			continue
+		}
+		stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), cleaned)
+		if err != nil {
+			result.err = err
+			continue
+		}
+		if !stat.Mode().IsRegular() {
+			result.err = fmt.Errorf("not a blob: %q", p)
+			continue
+		}
+		result.file = &GitTreeEntryResolver{
+			commit: r,
+			stat:   stat,
+		}
+	}
+	return results, nil
+}
+
+type gitCommitFileResultResolver struct {
+	path string
+	file *GitTreeEntryResolver
+	err  error
+}
+
+func (r *gitCommitFileResultResolver) Path() string { return r.path }
+
+func (r *gitCommitFileResultResolver) File() *GitTreeEntryResolver { return r.file }
+
+func (r *gitCommitFileResultResolver) Error() *string {
+	if r.err == nil {
+		return nil
+	}
+	msg := r.err.Error()
+	return &msg
+}
+
+func (r *GitCommitResolver) IsIgnored(ctx context.Context, args *struct {
+	Path string
+}) (bool, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return false, err
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return false, err
+	}
+	return git.IsIgnored(ctx, *cachedRepo, api.CommitID(r.oid), p)
+}
+
 func (r *GitCommitResolver) Languages(ctx context.Context) ([]string, error) {
 	inventory, err := backend.Repos.GetInventory(ctx, r.repo.repo, api.CommitID(r.oid), false)
 	if err != nil {
@@ -273,6 +571,420 @@ func (r *GitCommitResolver) Ancestors(ctx context.Context, args *struct {
 	}, nil
 }
 
+func (r *GitCommitResolver) History(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	Path  string
+	After *string
+}) (*gitCommitConnectionResolver, error) {
+	return &gitCommitConnectionResolver{
+		revisionRange: string(r.oid),
+		first:         args.ConnectionArgs.First,
+		path:          &args.Path,
+		follow:        true,
+		commitCursors: true,
+		afterCommit:   args.After,
+		repo:          r.repo,
+	}, nil
+}
+
+// Exists reports whether this commit exists in the repository. An ID that doesn't resolve to a
+// commit (or resolves to a commit with a different ID, e.g. because it is a ref name) is reported
+// as not existing instead of as an error.
+func (r *GitCommitResolver) Exists(ctx context.Context) (bool, error) {
+	commitID, err := backend.Repos.ResolveRev(ctx, r.repo.repo, string(r.oid))
+	if err != nil {
+		if gitserver.IsRevisionNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return r.oid != "" && strings.HasPrefix(string(commitID), string(r.oid)), nil
+}
+
+// ChangedInCommit reports whether the file or directory at path was added, modified, deleted or
+// had its mode changed by this commit, compared to its first parent. For a root commit, every
+// path that exists is reported as changed.
+func (r *GitCommitResolver) ChangedInCommit(ctx context.Context, args *struct {
+	Path string
+}) (bool, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return false, err
+	}
+	r.resolveCommit(ctx)
+	if r.err != nil {
+		return false, r.err
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return false, err
+	}
+	lstat := func(commit api.CommitID) (os.FileInfo, error) {
+		fi, err := git.Lstat(ctx, *cachedRepo, commit, p)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return fi, err
+	}
+
+	fi, err := lstat(api.CommitID(r.oid))
+	if err != nil {
+		return false, err
+	}
+	if len(r.parents) == 0 {
+		return fi != nil, nil
+	}
+	parentFI, err := lstat(r.parents[0])
+	if err != nil {
+		return false, err
+	}
+
+	if fi == nil || parentFI == nil {
+		return fi != parentFI, nil
+	}
+	return fi.Mode() != parentFI.Mode() || treeEntryObjectID(fi) != treeEntryObjectID(parentFI), nil
+}
+
+// treeEntryObjectID returns the ID of the Git object (or, for submodules, the commit) that the
+// tree entry described by fi points to.
+func treeEntryObjectID(fi os.FileInfo) string {
+	switch sys := fi.Sys().(type) {
+	case git.ObjectInfo:
+		return sys.OID().String()
+	case git.Submodule:
+		return string(sys.CommitID)
+	}
+	return ""
+}
+
+// PathLifecycle returns the commits that added or removed the file at path, oldest first. Only
+// ancestors of this commit (including itself) that are not ancestors of since are considered, or
+// all of them if since is not set.
+func (r *GitCommitResolver) PathLifecycle(ctx context.Context, args *struct {
+	Path  string
+	Since *string
+}) ([]*pathLifecycleEventResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	var since string
+	if args.Since != nil {
+		commitID, err := backend.Repos.ResolveRev(ctx, r.repo.repo, *args.Since)
+		if err != nil {
+			return nil, err
+		}
+		since = string(commitID)
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	events, err := git.GetPathLifecycle(ctx, *cachedRepo, since, string(r.oid), p)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*pathLifecycleEventResolver, len(events))
+	for i, event := range events {
+		resolvers[i] = &pathLifecycleEventResolver{
+			commit: &GitCommitResolver{
+				repo:            r.repo,
+				includeUserInfo: r.includeUserInfo,
+				oid:             GitObjectID(event.Commit),
+			},
+			added: event.Added,
+		}
+	}
+	return resolvers, nil
+}
+
+type pathLifecycleEventResolver struct {
+	commit *GitCommitResolver
+	added  bool
+}
+
+func (r *pathLifecycleEventResolver) Commit() *GitCommitResolver { return r.commit }
+
+func (r *pathLifecycleEventResolver) Type() string /* enum PathLifecycleEventType */ {
+	if r.added {
+		return "ADDED"
+	}
+	return "REMOVED"
+}
+
+// Describe returns the name of the commit relative to the nearest annotated tag it is reachable
+// from (as with `git describe`), or the abbreviated commit ID if there is no such tag.
+func (r *GitCommitResolver) Describe(ctx context.Context) (string, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return "", err
+	}
+	return git.Describe(ctx, *cachedRepo, api.CommitID(r.oid))
+}
+
+// Notes returns the text of the Git note attached to the commit in the given notes ref (by default
+// refs/notes/commits), or "" if there is none.
+func (r *GitCommitResolver) Notes(ctx context.Context, args *struct {
+	Ref *string
+}) (string, error) {
+	ref := git.DefaultNotesRef
+	if args.Ref != nil {
+		ref = *args.Ref
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return "", err
+	}
+	return git.Notes(ctx, *cachedRepo, ref, api.CommitID(r.oid))
+}
+
+// FileDiff returns the diff of the file at path between base (a Git revspec) and this commit, or
+// nil if the file was not changed. As in repository comparisons, the diff is computed against
+// the merge base of base and this commit. A path matches the file's old or new name, so for a
+// renamed or copied file the diff of the rename is returned for either name.
+func (r *GitCommitResolver) FileDiff(ctx context.Context, args *struct {
+	Base string
+	Path string
+}) (*fileDiffResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	head := string(r.oid)
+	cmp, err := NewRepositoryComparison(ctx, r.repo, &RepositoryComparisonInput{Base: &args.Base, Head: &head})
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := cmp.execDiff(ctx, defaultDiffSimilarityThreshold)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	fileDiff, err := findFileDiff(rdr, p)
+	if err != nil || fileDiff == nil {
+		return nil, err
+	}
+	return &fileDiffResolver{fileDiff: fileDiff, cmp: cmp}, nil
+}
+
+// RawDiff returns the unified diff text between base (a Git revspec, defaulting to the first parent
+// or, for a root commit, the empty tree) and this commit. Diffs larger than the gitMaxRawDiffBytes
+// site configuration value are truncated, which is indicated by a marker at the end of the text.
+func (r *GitCommitResolver) RawDiff(ctx context.Context, args *struct {
+	Base *string
+}) (string, error) {
+	base, err := r.diffBase(ctx, args.Base)
+	if err != nil {
+		return "", err
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return "", err
+	}
+	rdr, err := git.ExecReader(ctx, *cachedRepo, []string{
+		"diff",
+		"--find-renames",
+		"--full-index",
+		base,
+		string(r.oid),
+		"--",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer rdr.Close()
+
+	return readRawDiff(rdr, conf.GitMaxRawDiffBytes())
+}
+
+// diffBase returns the commit ID to diff this commit against: the given Git revspec, if any, and
+// otherwise the first parent or, for a root commit, the empty tree.
+func (r *GitCommitResolver) diffBase(ctx context.Context, base *string) (string, error) {
+	if base != nil {
+		// Resolve the base to a commit ID, so that user input can't add `git diff` flags.
+		commitID, err := backend.Repos.ResolveRev(ctx, r.repo.repo, *base)
+		if err != nil {
+			return "", err
+		}
+		return string(commitID), nil
+	}
+	r.resolveCommit(ctx)
+	if r.err != nil {
+		return "", r.err
+	}
+	if len(r.parents) > 0 {
+		return string(r.parents[0]), nil
+	}
+	return devNullSHA, nil
+}
+
+// ChangedPaths returns the paths of the files that were added, deleted or modified between base
+// (a Git revspec, defaulting to the first parent or, for a root commit, the empty tree) and this
+// commit, in the order Git reports them. Both paths of a renamed file are included. Unlike
+// fileDiffs and rawDiff, it doesn't compute the hunks of the diff.
+func (r *GitCommitResolver) ChangedPaths(ctx context.Context, args *struct {
+	Base *string
+}) ([]string, error) {
+	base, err := r.diffBase(ctx, args.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	rdr, err := git.ExecReader(ctx, *cachedRepo, []string{
+		"diff",
+		"--name-status",
+		"-z",
+		base,
+		string(r.oid),
+		"--",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	out, err := ioutil.ReadAll(rdr)
+	if err != nil {
+		return nil, err
+	}
+	return parseChangedPaths(out)
+}
+
+// parseChangedPaths returns the paths listed in the output of `git diff --name-status -z`. Each
+// entry is a status letter (with a similarity score for renames and copies) followed by the path,
+// or by the source and destination paths for renames and copies.
+func parseChangedPaths(out []byte) ([]string, error) {
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	if len(fields) == 1 && fields[0] == "" {
+		return []string{}, nil
+	}
+
+	paths := make([]string, 0, len(fields)/2)
+	for i := 0; i < len(fields); {
+		status := fields[i]
+		n := 1
+		if status != "" && (status[0] == 'R' || status[0] == 'C') {
+			n = 2
+		}
+		if i+n >= len(fields) || status == "" {
+			return nil, fmt.Errorf("invalid `git diff --name-status` output: %q", out)
+		}
+		paths = append(paths, fields[i+1:i+1+n]...)
+		i += 1 + n
+	}
+	return paths, nil
+}
+
+// readRawDiff reads at most maxBytes of the diff text from r. If the diff is longer, it is
+// truncated after its last complete line within maxBytes and a marker is appended.
+func readRawDiff(r io.Reader, maxBytes int) (string, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, int64(maxBytes)+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) <= maxBytes {
+		return string(b), nil
+	}
+
+	b = b[:maxBytes]
+	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+		b = b[:i+1]
+	} else {
+		b = b[:0]
+	}
+	return string(b) + fmt.Sprintf("[diff truncated: larger than %d bytes]\n", maxBytes), nil
+}
+
+// HasConflictMarkers reports whether this commit adds lines with merge conflict markers (such as
+// "<<<<<<< HEAD") to any file, compared to its first parent. Only the lines changed in text files
+// are scanned: Git doesn't produce line diffs for binary files.
+func (r *GitCommitResolver) HasConflictMarkers(ctx context.Context) (bool, error) {
+	base, err := r.diffBase(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return false, err
+	}
+	rdr, err := git.ExecReader(ctx, *cachedRepo, []string{
+		"diff",
+		"--no-color",
+		"--unified=0",
+		base,
+		string(r.oid),
+		"--",
+	})
+	if err != nil {
+		return false, err
+	}
+	defer rdr.Close()
+
+	return diffAddsConflictMarkers(rdr)
+}
+
+// diffAddsConflictMarkers reports whether the unified diff read from r adds a line that is a merge
+// conflict marker. Like `git diff --check`, it treats lines starting with 7 "<", "=", ">" or "|"
+// characters followed by a space or the end of the line as markers.
+func diffAddsConflictMarkers(r io.Reader) (bool, error) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 && line[0] == '+' && !bytes.HasPrefix(line, []byte("+++ ")) {
+			if isConflictMarker(bytes.TrimRight(line[1:], "\r\n")) {
+				return true, nil
+			}
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+func isConflictMarker(line []byte) bool {
+	const markerLen = 7
+	if len(line) < markerLen || (len(line) > markerLen && line[markerLen] != ' ') {
+		return false
+	}
+	switch c := line[0]; c {
+	case '<', '=', '>', '|':
+		return bytes.Count(line[:markerLen], []byte{c}) == markerLen
+	}
+	return false
+}
+
+// Contributors returns the authors of the commits reachable from this commit, sorted by their
+// number of commits in descending order. If a path is given, only commits touching it are counted.
+func (r *GitCommitResolver) Contributors(args *struct {
+	Path  *string
+	First *int32
+}) *repositoryContributorConnectionResolver {
+	rev := string(r.oid)
+	return &repositoryContributorConnectionResolver{
+		args: repositoryContributorsArgs{
+			RevisionRange: &rev,
+			Path:          args.Path,
+		},
+		first: args.First,
+		repo:  r.repo,
+	}
+}
+
 func (r *GitCommitResolver) BehindAhead(ctx context.Context, args *struct {
 	Revspec string
 }) (*behindAheadCountsResolver, error) {
@@ -290,11 +1002,88 @@ func (r *GitCommitResolver) BehindAhead(ctx context.Context, args *struct {
 	}, nil
 }
 
+func (r *GitCommitResolver) OnDefaultBranch(ctx context.Context) (bool, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return false, err
+	}
+	head, err := git.ResolveRevision(ctx, *cachedRepo, nil, "HEAD", &git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return false, err
+	}
+	return git.IsAncestor(ctx, *cachedRepo, api.CommitID(r.oid), head)
+}
+
+func (r *GitCommitResolver) LastCommit(ctx context.Context, args *struct {
+	Path string
+}) (*GitCommitResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := git.LastCommitForPath(ctx, *cachedRepo, api.CommitID(r.oid), p)
+	if err != nil || commit == nil {
+		return nil, err
+	}
+	return toGitCommitResolver(r.repo, commit), nil
+}
+
+func (r *GitCommitResolver) Signature(ctx context.Context) (*gitCommitSignatureResolver, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := git.GetCommitSignature(ctx, *cachedRepo, api.CommitID(r.oid))
+	if err != nil {
+		return nil, err
+	}
+	return &gitCommitSignatureResolver{sig: sig}, nil
+}
+
+func (r *GitCommitResolver) Refs(ctx context.Context) ([]*GitRefResolver, error) {
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := git.ListRefsPointingAt(ctx, *cachedRepo, api.CommitID(r.oid))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*GitRefResolver, len(refs))
+	for i, ref := range refs {
+		resolvers[i] = NewGitRefResolver(r.repo, ref, r.oid)
+	}
+	return resolvers, nil
+}
+
 type behindAheadCountsResolver struct{ behind, ahead int32 }
 
 func (r *behindAheadCountsResolver) Behind() int32 { return r.behind }
 func (r *behindAheadCountsResolver) Ahead() int32  { return r.ahead }
 
+type gitCommitSignatureResolver struct{ sig *git.CommitSignature }
+
+func (r *gitCommitSignatureResolver) Signed() bool   { return r.sig.Signed }
+func (r *gitCommitSignatureResolver) Verified() bool { return r.sig.Verified }
+
+func (r *gitCommitSignatureResolver) Signer() *string {
+	if r.sig.Signer == "" {
+		return nil
+	}
+	return &r.sig.Signer
+}
+
+func (r *gitCommitSignatureResolver) Key() *string {
+	if r.sig.Key == "" {
+		return nil
+	}
+	return &r.sig.Key
+}
+
 // inputRevOrImmutableRev returns the input revspec, if it is provided and nonempty. Otherwise it returns the
 // canonical OID for the revision.
 func (r *GitCommitResolver) inputRevOrImmutableRev() string {
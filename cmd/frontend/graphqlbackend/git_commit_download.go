@@ -0,0 +1,90 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// sniffLen is the number of bytes that http.DetectContentType considers.
+const sniffLen = 512
+
+// DownloadInfo returns the information needed to download the raw contents of the file at the
+// given path in this commit. It returns nil if there is no such path.
+func (r *GitCommitResolver) DownloadInfo(ctx context.Context, args *struct {
+	Path string
+}) (*downloadInfoResolver, error) {
+	p, err := cleanTreePath(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := git.Stat(ctx, *cachedRepo, api.CommitID(r.oid), p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, fmt.Errorf("not a blob: %q", args.Path)
+	}
+	if stat.Size() > math.MaxInt32 {
+		return nil, fmt.Errorf("blob %q is too large: %d bytes", args.Path, stat.Size())
+	}
+
+	head, err := git.ReadFile(ctx, *cachedRepo, api.CommitID(r.oid), p, sniffLen)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := r.canonicalRepoRevURL()
+	if err != nil {
+		return nil, err
+	}
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "-", "raw", p)
+
+	return &downloadInfoResolver{
+		contentType: detectContentType(p, head),
+		byteSize:    stat.Size(),
+		url:         u.String(),
+	}, nil
+}
+
+// detectContentType returns the MIME type of the file at name whose contents start with head.
+// The file extension takes precedence; the contents are only sniffed if the extension is unknown.
+func detectContentType(name string, head []byte) string {
+	if typ := mime.TypeByExtension(path.Ext(name)); typ != "" {
+		return typ
+	}
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	return http.DetectContentType(head)
+}
+
+type downloadInfoResolver struct {
+	contentType string
+	byteSize    int64
+	url         string
+}
+
+func (r *downloadInfoResolver) ContentType() string { return r.contentType }
+func (r *downloadInfoResolver) ByteSize() int32     { return int32(r.byteSize) }
+func (r *downloadInfoResolver) URL() string         { return r.url }
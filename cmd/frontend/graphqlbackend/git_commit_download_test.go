@@ -0,0 +1,82 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+func TestGitCommitDownloadInfo(t *testing.T) {
+	files := map[string][]byte{
+		"img/logo.png":  append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 1000)...),
+		"docs/spec.pdf": []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"),
+		"data.unknown1": {0x00, 0x01, 0x02, 0xff},
+		"notes.unknown": []byte("just some text\n"),
+	}
+	git.Mocks.Stat = func(commit api.CommitID, name string) (os.FileInfo, error) {
+		if name == "huge.iso" {
+			return &util.FileInfo{Name_: name, Size_: 1 << 31}, nil
+		}
+		data, ok := files[name]
+		if !ok {
+			return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+		}
+		return &util.FileInfo{Name_: name, Size_: int64(len(data))}, nil
+	}
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		return files[name], nil
+	}
+	defer git.ResetMocks()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	tests := map[string]struct {
+		path        string
+		contentType string
+		byteSize    int32
+	}{
+		"image":                    {path: "img/logo.png", contentType: "image/png", byteSize: 1008},
+		"pdf":                      {path: "/docs/spec.pdf", contentType: "application/pdf", byteSize: 15},
+		"unknown extension binary": {path: "data.unknown1", contentType: "application/octet-stream", byteSize: 4},
+		"unknown extension text":   {path: "notes.unknown", contentType: "text/plain; charset=utf-8", byteSize: 15},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			info, err := commit.DownloadInfo(context.Background(), &struct{ Path string }{Path: test.path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.ContentType() != test.contentType {
+				t.Errorf("got content type %q, want %q", info.ContentType(), test.contentType)
+			}
+			if info.ByteSize() != test.byteSize {
+				t.Errorf("got byte size %d, want %d", info.ByteSize(), test.byteSize)
+			}
+			if want := "/github.com/gorilla/mux@" + exampleCommitSHA1 + "/-/raw/" + strings.TrimPrefix(test.path, "/"); info.URL() != want {
+				t.Errorf("got URL %q, want %q", info.URL(), want)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		info, err := commit.DownloadInfo(context.Background(), &struct{ Path string }{Path: "missing.txt"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info != nil {
+			t.Errorf("got %+v, want nil", info)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		// The size doesn't fit in a GraphQL Int.
+		if _, err := commit.DownloadInfo(context.Background(), &struct{ Path string }{Path: "huge.iso"}); err == nil {
+			t.Fatal("got no error, want error")
+		}
+	})
+}
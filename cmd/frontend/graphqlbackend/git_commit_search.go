@@ -0,0 +1,85 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+// maxCommitContentSearchResults is the maximum number of file matches that
+// GitCommitResolver.SearchContent returns, regardless of the requested
+// maximum.
+const maxCommitContentSearchResults = 500
+
+type commitContentSearchArgs struct {
+	Pattern    string
+	IsRegExp   bool
+	MaxResults int32
+}
+
+func (r *GitCommitResolver) SearchContent(ctx context.Context, args *commitContentSearchArgs) (*commitContentSearchResultsResolver, error) {
+	if args.Pattern == "" {
+		return nil, errors.New("empty search pattern")
+	}
+	if args.IsRegExp {
+		if _, err := regexp.Compile(args.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %s", err)
+		}
+	}
+	if args.MaxResults <= 0 {
+		return nil, errors.New("maxResults must be positive")
+	}
+	limit := args.MaxResults
+	if limit > maxCommitContentSearchResults {
+		limit = maxCommitContentSearchResults
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	info := &search.TextPatternInfo{
+		Pattern:               args.Pattern,
+		IsRegExp:              args.IsRegExp,
+		FileMatchLimit:        limit,
+		PatternMatchesContent: true,
+	}
+	matches, limitHit, err := textSearch(ctx, search.SearcherURLs(), *cachedRepo, api.CommitID(r.oid), info, defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > int(limit) {
+		matches, limitHit = matches[:limit], true
+	}
+
+	rev := string(r.oid)
+	if r.inputRev != nil && *r.inputRev != "" {
+		rev = *r.inputRev
+	}
+	workspace := fileMatchURI(r.repo.repo.Name, rev, "")
+	for _, fm := range matches {
+		fm.uri = workspace + fm.JPath
+		fm.Repo = r.repo.repo
+		fm.CommitID = api.CommitID(r.oid)
+		fm.InputRev = &rev
+	}
+	return &commitContentSearchResultsResolver{matches: matches, limitHit: limitHit}, nil
+}
+
+type commitContentSearchResultsResolver struct {
+	matches  []*FileMatchResolver
+	limitHit bool
+}
+
+func (r *commitContentSearchResultsResolver) Results() []*FileMatchResolver { return r.matches }
+
+func (r *commitContentSearchResultsResolver) LimitHit() bool { return r.limitHit }
@@ -0,0 +1,118 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+func TestGitCommitSearchContent(t *testing.T) {
+	files := map[string]string{
+		"a.go":  "package a\n\nfunc Foo() {}\n",
+		"b.go":  "package b\n\nvar foo_bar = 1\n",
+		"c.txt": "Foo.Bar\nFooxBar\n",
+	}
+	var gotInfo *search.TextPatternInfo
+	// mockTextSearch behaves like searcher for case-insensitive searches of the files above.
+	mockTextSearch = func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration) ([]*FileMatchResolver, bool, error) {
+		gotInfo = p
+		if commit != exampleCommitSHA1 {
+			t.Errorf("got commit %q, want %q", commit, exampleCommitSHA1)
+		}
+		pattern := p.Pattern
+		if !p.IsRegExp {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		re := regexp.MustCompile("(?i)" + pattern)
+
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var matches []*FileMatchResolver
+		for _, name := range names {
+			fm := &FileMatchResolver{JPath: name}
+			for i, line := range strings.Split(files[name], "\n") {
+				if loc := re.FindStringIndex(line); loc != nil {
+					fm.JLineMatches = append(fm.JLineMatches, &lineMatch{JPreview: line, JLineNumber: int32(i), JOffsetAndLengths: [][2]int32{{int32(loc[0]), int32(loc[1] - loc[0])}}})
+				}
+			}
+			if len(fm.JLineMatches) > 0 {
+				if len(matches) == int(p.FileMatchLimit) {
+					return matches, true, nil
+				}
+				matches = append(matches, fm)
+			}
+		}
+		return matches, false, nil
+	}
+	defer func() { mockTextSearch = nil }()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	searchContent := func(t *testing.T, args commitContentSearchArgs) (lines []string, limitHit bool) {
+		t.Helper()
+		res, err := commit.SearchContent(context.Background(), &args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fm := range res.Results() {
+			if want := "git://github.com/gorilla/mux?" + exampleCommitSHA1 + "#" + fm.JPath; fm.Resource() != want {
+				t.Errorf("got resource %q, want %q", fm.Resource(), want)
+			}
+			for _, lm := range fm.LineMatches() {
+				lines = append(lines, fmt.Sprintf("%s:%d:%s", fm.JPath, lm.LineNumber(), lm.Preview()))
+			}
+		}
+		return lines, res.LimitHit()
+	}
+
+	t.Run("literal", func(t *testing.T) {
+		lines, limitHit := searchContent(t, commitContentSearchArgs{Pattern: "foo.bar", MaxResults: 30})
+		if want := []string{"c.txt:0:Foo.Bar"}; !reflect.DeepEqual(lines, want) || limitHit {
+			t.Errorf("got %q (limitHit %v), want %q", lines, limitHit, want)
+		}
+	})
+
+	t.Run("regexp", func(t *testing.T) {
+		lines, limitHit := searchContent(t, commitContentSearchArgs{Pattern: "foo.bar", IsRegExp: true, MaxResults: 30})
+		want := []string{"b.go:2:var foo_bar = 1", "c.txt:0:Foo.Bar", "c.txt:1:FooxBar"}
+		if !reflect.DeepEqual(lines, want) || limitHit {
+			t.Errorf("got %q (limitHit %v), want %q", lines, limitHit, want)
+		}
+	})
+
+	t.Run("result cap", func(t *testing.T) {
+		lines, limitHit := searchContent(t, commitContentSearchArgs{Pattern: "package", MaxResults: 1})
+		if want := []string{"a.go:0:package a"}; !reflect.DeepEqual(lines, want) || !limitHit {
+			t.Errorf("got %q (limitHit %v), want %q with limitHit", lines, limitHit, want)
+		}
+
+		searchContent(t, commitContentSearchArgs{Pattern: "package", MaxResults: 100000})
+		if gotInfo.FileMatchLimit != maxCommitContentSearchResults {
+			t.Errorf("got FileMatchLimit %d, want %d", gotInfo.FileMatchLimit, maxCommitContentSearchResults)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		for _, args := range []commitContentSearchArgs{
+			{Pattern: "", MaxResults: 30},
+			{Pattern: "(", IsRegExp: true, MaxResults: 30},
+			{Pattern: "foo", MaxResults: 0},
+		} {
+			if _, err := commit.SearchContent(context.Background(), &args); err == nil {
+				t.Errorf("%+v: got no error, want an error", args)
+			}
+		}
+	})
+}
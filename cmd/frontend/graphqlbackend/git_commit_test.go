@@ -1,6 +1,23 @@
 package graphqlbackend
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
 
 func TestGitCommitBody(t *testing.T) {
 	tests := map[string]string{
@@ -18,3 +35,700 @@ func TestGitCommitBody(t *testing.T) {
 		}
 	}
 }
+
+func TestGitCommitFiles(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+
+	var statCalls int
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		statCalls++
+		switch path {
+		case "README.md":
+			return &util.FileInfo{Name_: path, Mode_: 0}, nil
+		case "docs":
+			return &util.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+		}
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						commit(rev: "` + exampleCommitSHA1 + `") {
+							files(paths: ["README.md", "missing.go", "README.md", "docs", ""]) {
+								path
+								file {
+									name
+								}
+								error
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+{
+  "repository": {
+    "commit": {
+      "files": [
+        {
+          "path": "README.md",
+          "file": {
+            "name": "README.md"
+          },
+          "error": null
+        },
+        {
+          "path": "missing.go",
+          "file": null,
+          "error": "open missing.go: file does not exist"
+        },
+        {
+          "path": "docs",
+          "file": null,
+          "error": "not a blob: \"docs\""
+        },
+        {
+          "path": "",
+          "file": null,
+          "error": "path must not be empty"
+        }
+      ]
+    }
+  }
+}
+			`,
+		},
+	})
+
+	if want := 3; statCalls != want {
+		t.Errorf("got %d calls to git.Stat, want %d", statCalls, want)
+	}
+}
+
+func TestGitCommitParentCount(t *testing.T) {
+	tests := map[string]struct {
+		parents     []api.CommitID
+		parentCount int
+		isMerge     bool
+	}{
+		"merge commit":  {parents: []api.CommitID{"a", "b"}, parentCount: 2, isMerge: true},
+		"normal commit": {parents: []api.CommitID{"a"}, parentCount: 1},
+		"root commit":   {parents: nil, parentCount: 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resetMocks()
+			db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+				return nil, nil
+			}
+			db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+			backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+				return exampleCommitSHA1, nil
+			}
+			var getCommitCalls int
+			backend.Mocks.Repos.GetCommit = func(ctx context.Context, repo *types.Repo, commitID api.CommitID) (*git.Commit, error) {
+				getCommitCalls++
+				return &git.Commit{ID: exampleCommitSHA1, Parents: test.parents}, nil
+			}
+
+			gqltesting.RunTests(t, []*gqltesting.Test{
+				{
+					Schema: mustParseGraphQLSchema(t),
+					Query: `
+						{
+							repository(name: "github.com/gorilla/mux") {
+								commit(rev: "` + exampleCommitSHA1 + `") {
+									parentCount
+									isMerge
+								}
+							}
+						}
+					`,
+					ExpectedResult: fmt.Sprintf(`
+						{
+							"repository": {
+								"commit": {
+									"parentCount": %d,
+									"isMerge": %t
+								}
+							}
+						}
+					`, test.parentCount, test.isMerge),
+				},
+			})
+
+			if getCommitCalls != 1 {
+				t.Errorf("got %d calls to GetCommit, want 1", getCommitCalls)
+			}
+		})
+	}
+}
+
+func TestCleanTreePath(t *testing.T) {
+	tests := map[string]struct {
+		want    string
+		wantErr bool
+	}{
+		"":                   {want: ""},
+		".":                  {want: ""},
+		"/":                  {want: ""},
+		"foo/bar":            {want: "foo/bar"},
+		"./foo/../bar":       {want: "bar"},
+		"foo/./bar/":         {want: "foo/bar"},
+		"foo/..":             {want: ""},
+		"..":                 {wantErr: true},
+		"../foo":             {wantErr: true},
+		"foo/../../bar":      {wantErr: true},
+		"/../foo":            {wantErr: true},
+		"foo/../bar/../../x": {wantErr: true},
+	}
+	for input, test := range tests {
+		got, err := cleanTreePath(input)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%q: got error %v, want error %v", input, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", input, got, test.want)
+		}
+	}
+}
+
+func TestGitCommitTreeAndFileCleanPaths(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		switch path {
+		case "":
+			return &util.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+		case "bar/README.md":
+			return &util.FileInfo{Name_: path, Mode_: 0}, nil
+		}
+		t.Errorf("unexpected path %q", path)
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						commit(rev: "` + exampleCommitSHA1 + `") {
+							tree(path: ".") {
+								isRoot
+							}
+							file(path: "./foo/../bar/README.md") {
+								path
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+{
+  "repository": {
+    "commit": {
+      "tree": {
+        "isRoot": true
+      },
+      "file": {
+        "path": "bar/README.md"
+      }
+    }
+  }
+}
+			`,
+		},
+	})
+}
+
+func TestGitCommitLastCommitInvalidPath(t *testing.T) {
+	r := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	for _, path := range []string{"..", "../foo", "foo/../../bar"} {
+		if _, err := r.LastCommit(context.Background(), &struct{ Path string }{Path: path}); err == nil {
+			t.Errorf("%q: got no error, want error", path)
+		}
+	}
+}
+
+func TestGitCommitIsIgnoredCleanPath(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		if path != "foo.log" {
+			t.Errorf("unexpected path %q", path)
+		}
+		return &util.FileInfo{Name_: path, Mode_: 0}, nil
+	}
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		if name == ".gitignore" {
+			return []byte("*.log\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	r := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+
+	ignored, err := r.IsIgnored(context.Background(), &struct{ Path string }{Path: "./bar/../foo.log"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ignored {
+		t.Error("got not ignored, want ignored")
+	}
+
+	if _, err := r.IsIgnored(context.Background(), &struct{ Path string }{Path: "../foo.log"}); err == nil {
+		t.Error("got no error for path outside of the repository root, want error")
+	}
+}
+
+func TestGitCommitExists(t *testing.T) {
+	const fullSHA = "0123456789abcdef0123456789abcdef01234567"
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		switch rev {
+		case fullSHA, fullSHA[:7]:
+			return fullSHA, nil
+		case "master":
+			return fullSHA, nil
+		}
+		return "", &gitserver.RevisionNotFoundError{Repo: repo.Name, Spec: rev}
+	}
+	defer func() { backend.Mocks.Repos.ResolveRev = nil }()
+
+	tests := map[string]bool{
+		fullSHA:      true,
+		fullSHA[:7]:  true,
+		"master":     false,
+		"deadbeef01": false,
+	}
+	for oid, want := range tests {
+		r := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{Name: "r"}}, oid: GitObjectID(oid)}
+		got, err := r.Exists(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %s", oid, err)
+		}
+		if got != want {
+			t.Errorf("%s: got exists %v, want %v", oid, got, want)
+		}
+	}
+}
+
+func TestGitCommitPermalink(t *testing.T) {
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}
+	strptr := func(s string) *string { return &s }
+
+	t.Run("branch", func(t *testing.T) {
+		r := &GitCommitResolver{repo: repo, inputRev: strptr("master"), oid: exampleCommitSHA1}
+		permalink := r.Permalink()
+		if permalink == r {
+			t.Fatal("got the same commit, want a commit pinned to its SHA")
+		}
+		if permalink.OID() != exampleCommitSHA1 {
+			t.Errorf("got OID %q, want %q", permalink.OID(), exampleCommitSHA1)
+		}
+		url, err := permalink.URL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "/github.com/gorilla/mux/-/commit/" + exampleCommitSHA1; url != want {
+			t.Errorf("got URL %q, want %q", url, want)
+		}
+		if url, _ := permalink.repoRevURL(); url != "/github.com/gorilla/mux@"+exampleCommitSHA1 {
+			t.Errorf("got repo rev URL %q, want it to be pinned to the SHA", url)
+		}
+	})
+
+	t.Run("SHA", func(t *testing.T) {
+		for _, r := range []*GitCommitResolver{
+			{repo: repo, inputRev: strptr(exampleCommitSHA1), oid: exampleCommitSHA1},
+			{repo: repo, oid: exampleCommitSHA1},
+		} {
+			if permalink := r.Permalink(); permalink != r {
+				t.Errorf("got %+v, want the same commit", permalink)
+			}
+		}
+	})
+}
+
+func TestGitCommitFilePermalink(t *testing.T) {
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}
+	master := "master"
+	r := &GitCommitResolver{repo: repo, inputRev: &master, oid: exampleCommitSHA1}
+	int32ptr := func(n int32) *int32 { return &n }
+	prefix := "/github.com/gorilla/mux@" + exampleCommitSHA1 + "/-/blob/"
+
+	tests := map[string]struct {
+		path       string
+		start, end *int32
+		want       string
+		wantErr    bool
+	}{
+		"no range":           {path: "mux.go", want: prefix + "mux.go"},
+		"single line":        {path: "mux.go", start: int32ptr(12), want: prefix + "mux.go#L12"},
+		"same start and end": {path: "mux.go", start: int32ptr(12), end: int32ptr(12), want: prefix + "mux.go#L12"},
+		"range":              {path: "/a/../doc.go", start: int32ptr(3), end: int32ptr(7), want: prefix + "doc.go#L3-L7"},
+		"end before start":   {path: "mux.go", start: int32ptr(7), end: int32ptr(3), wantErr: true},
+		"end without start":  {path: "mux.go", end: int32ptr(3), wantErr: true},
+		"zero start":         {path: "mux.go", start: int32ptr(0), wantErr: true},
+		"root":               {path: "/", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			url, err := r.FilePermalink(context.Background(), &struct {
+				Path      string
+				StartLine *int32
+				EndLine   *int32
+			}{Path: test.path, StartLine: test.start, EndLine: test.end})
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got URL %q, want error", url)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if url != test.want {
+				t.Errorf("got URL %q, want %q", url, test.want)
+			}
+		})
+	}
+}
+
+func TestGitCommitBlobSize(t *testing.T) {
+	sizes := map[string]int64{
+		"README.md":    12,
+		"data/big.bin": 512 << 20,
+	}
+	git.Mocks.Stat = func(commit api.CommitID, name string) (os.FileInfo, error) {
+		size, ok := sizes[name]
+		if !ok {
+			return nil, &os.PathError{Op: "ls-tree", Path: name, Err: os.ErrNotExist}
+		}
+		return &util.FileInfo{Name_: name, Size_: size}, nil
+	}
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		t.Errorf("got ReadFile(%q), want the size to be read from the tree", name)
+		return nil, nil
+	}
+	defer git.ResetMocks()
+
+	r := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	for path, want := range map[string]int32{
+		"README.md":     12,
+		"/data/big.bin": 512 << 20,
+	} {
+		got, err := r.BlobSize(context.Background(), &struct{ Path string }{Path: path})
+		if err != nil {
+			t.Fatalf("%s: %s", path, err)
+		}
+		if got != want {
+			t.Errorf("%s: got size %d, want %d", path, got, want)
+		}
+	}
+
+	if _, err := r.BlobSize(context.Background(), &struct{ Path string }{Path: "missing.txt"}); !os.IsNotExist(err) {
+		t.Errorf("got err %v, want a not exist error", err)
+	}
+}
+
+func TestGitCommitTreeAtParent(t *testing.T) {
+	const (
+		parent1 = "1111111111111111111111111111111111111111"
+		parent2 = "2222222222222222222222222222222222222222"
+	)
+	git.Mocks.GetCommit = func(commitID api.CommitID) (*git.Commit, error) {
+		return &git.Commit{ID: exampleCommitSHA1, Parents: []api.CommitID{parent1, parent2}}, nil
+	}
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		if commit == parent2 && path == "b.txt" {
+			return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+		}
+		return &util.FileInfo{Name_: path}, nil
+	}
+	defer git.ResetMocks()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	treeAtParent := func(path string, parentIndex int32) (*GitTreeEntryResolver, error) {
+		return commit.TreeAtParent(context.Background(), &struct {
+			Path        string
+			ParentIndex int32
+		}{Path: path, ParentIndex: parentIndex})
+	}
+
+	tests := []struct {
+		path        string
+		parentIndex int32
+		wantCommit  GitObjectID
+	}{
+		{path: "a.txt", parentIndex: 0, wantCommit: parent1},
+		{path: "a.txt", parentIndex: 1, wantCommit: parent2},
+	}
+	for _, test := range tests {
+		entry, err := treeAtParent(test.path, test.parentIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.Path() != test.path {
+			t.Errorf("got path %q, want %q", entry.Path(), test.path)
+		}
+		if entry.Commit().OID() != test.wantCommit {
+			t.Errorf("parent %d: got commit %q, want %q", test.parentIndex, entry.Commit().OID(), test.wantCommit)
+		}
+	}
+
+	t.Run("path missing in parent", func(t *testing.T) {
+		entry, err := treeAtParent("b.txt", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry != nil {
+			t.Errorf("got entry %q, want nil", entry.Path())
+		}
+	})
+
+	t.Run("invalid parent index", func(t *testing.T) {
+		for _, i := range []int32{-1, 2} {
+			if _, err := treeAtParent("a.txt", i); err == nil {
+				t.Errorf("parent %d: got no error, want an error", i)
+			}
+		}
+	})
+}
+
+func TestReadRawDiff(t *testing.T) {
+	const diff = `diff --git a/a.txt b/a.txt
+index 0000000000000000000000000000000000000000..1111111111111111111111111111111111111111 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1 +1 @@
+-a
++b
+`
+	tests := map[string]struct {
+		maxBytes int
+		want     string
+	}{
+		"small diff": {
+			maxBytes: len(diff),
+			want:     diff,
+		},
+		"truncated after the last complete line": {
+			maxBytes: len(diff) - 3,
+			want:     strings.TrimSuffix(diff, "+b\n") + fmt.Sprintf("[diff truncated: larger than %d bytes]\n", len(diff)-3),
+		},
+		"no complete line": {
+			maxBytes: 5,
+			want:     "[diff truncated: larger than 5 bytes]\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := readRawDiff(strings.NewReader(diff), test.maxBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseChangedPaths(t *testing.T) {
+	tests := map[string]struct {
+		out  string
+		want []string
+	}{
+		"empty":    {out: "", want: []string{}},
+		"added":    {out: "A\x00new.go\x00", want: []string{"new.go"}},
+		"deleted":  {out: "D\x00old.go\x00", want: []string{"old.go"}},
+		"modified": {out: "M\x00dir/a b.go\x00", want: []string{"dir/a b.go"}},
+		"mixed": {
+			out:  "A\x00a.go\x00D\x00b.go\x00M\x00c.go\x00R087\x00d.go\x00e.go\x00T\x00f\x00",
+			want: []string{"a.go", "b.go", "c.go", "d.go", "e.go", "f"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseChangedPaths([]byte(test.out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	for _, out := range []string{"M\x00", "R100\x00a.go\x00", "\x00a.go\x00"} {
+		if _, err := parseChangedPaths([]byte(out)); err == nil {
+			t.Errorf("%q: got no error, want an error", out)
+		}
+	}
+}
+
+func TestDiffAddsConflictMarkers(t *testing.T) {
+	tests := map[string]struct {
+		diff string
+		want bool
+	}{
+		"clean": {
+			diff: `diff --git a/a.txt b/a.txt
+index 0000000000000000000000000000000000000000..1111111111111111111111111111111111111111 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1 +1,3 @@
+-a
++Title
++=====
++<<<<<<<< not quite a marker
+`,
+			want: false,
+		},
+		"committed conflict": {
+			diff: `diff --git a/a.txt b/a.txt
+index 0000000000000000000000000000000000000000..1111111111111111111111111111111111111111 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1 +1,5 @@
+-a
++<<<<<<< HEAD
++b
++=======
++c
++>>>>>>> feature
+`,
+			want: true,
+		},
+		"removed conflict": {
+			diff: `diff --git a/a.txt b/a.txt
+index 0000000000000000000000000000000000000000..1111111111111111111111111111111111111111 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1,5 +1 @@
+-<<<<<<< HEAD
+-b
+-=======
+-c
+->>>>>>> feature
++b
+`,
+			want: false,
+		},
+		"binary file": {
+			diff: `diff --git a/a.bin b/a.bin
+index 0000000000000000000000000000000000000000..1111111111111111111111111111111111111111 100644
+Binary files a/a.bin and b/a.bin differ
+`,
+			want: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := diffAddsConflictMarkers(strings.NewReader(test.diff))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+type testObjectInfo git.OID
+
+func (oid testObjectInfo) OID() git.OID { return git.OID(oid) }
+
+func TestGitCommitChangedInCommit(t *testing.T) {
+	const parent = "1111111111111111111111111111111111111111"
+	var (
+		oldBlob = testObjectInfo{1}
+		newBlob = testObjectInfo{2}
+	)
+	trees := map[api.CommitID]map[string]*util.FileInfo{
+		parent: {
+			"unchanged.txt": {Name_: "unchanged.txt", Mode_: 0644, Sys_: oldBlob},
+			"modified.txt":  {Name_: "modified.txt", Mode_: 0644, Sys_: oldBlob},
+			"chmod.sh":      {Name_: "chmod.sh", Mode_: 0644, Sys_: oldBlob},
+			"deleted.txt":   {Name_: "deleted.txt", Mode_: 0644, Sys_: oldBlob},
+		},
+		exampleCommitSHA1: {
+			"unchanged.txt": {Name_: "unchanged.txt", Mode_: 0644, Sys_: oldBlob},
+			"modified.txt":  {Name_: "modified.txt", Mode_: 0644, Sys_: newBlob},
+			"chmod.sh":      {Name_: "chmod.sh", Mode_: 0755, Sys_: oldBlob},
+			"added.txt":     {Name_: "added.txt", Mode_: 0644, Sys_: newBlob},
+		},
+	}
+	git.Mocks.Lstat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		if fi, ok := trees[commit][path]; ok {
+			return fi, nil
+		}
+		return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	changedInCommit := func(t *testing.T, parents []api.CommitID, path string) bool {
+		t.Helper()
+		git.Mocks.GetCommit = func(commitID api.CommitID) (*git.Commit, error) {
+			return &git.Commit{ID: exampleCommitSHA1, Parents: parents}, nil
+		}
+		commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+		changed, err := commit.ChangedInCommit(context.Background(), &struct{ Path string }{Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return changed
+	}
+
+	for path, want := range map[string]bool{
+		"unchanged.txt": false,
+		"modified.txt":  true,
+		"chmod.sh":      true,
+		"added.txt":     true,
+		"deleted.txt":   true,
+		"missing.txt":   false,
+	} {
+		if got := changedInCommit(t, []api.CommitID{parent}, path); got != want {
+			t.Errorf("%s: got changed %v, want %v", path, got, want)
+		}
+	}
+
+	t.Run("root commit", func(t *testing.T) {
+		for path, want := range map[string]bool{
+			"unchanged.txt": true,
+			"deleted.txt":   false,
+		} {
+			if got := changedInCommit(t, nil, path); got != want {
+				t.Errorf("%s: got changed %v, want %v", path, got, want)
+			}
+		}
+	})
+}
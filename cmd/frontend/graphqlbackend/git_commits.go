@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -18,6 +19,15 @@ type gitCommitConnectionResolver struct {
 	author *string
 	after  *string
 
+	// follow continues the history of path beyond renames.
+	follow bool
+
+	// commitCursors makes the connection paginate by commit: its endCursor
+	// is the ID of the last commit of the page, and afterCommit is the
+	// endCursor of the previous page.
+	commitCursors bool
+	afterCommit   *string
+
 	repo *RepositoryResolver
 
 	// cache results because it is used by multiple fields
@@ -53,14 +63,27 @@ func (r *gitCommitConnectionResolver) compute(ctx context.Context) ([]*git.Commi
 		if err != nil {
 			return nil, err
 		}
-		return git.Commits(ctx, *cachedRepo, git.CommitsOptions{
+		opt := git.CommitsOptions{
 			Range:        r.revisionRange,
 			N:            uint(n),
 			MessageQuery: query,
 			Author:       author,
 			After:        after,
 			Path:         path,
-		})
+			Follow:       r.follow,
+		}
+		if r.afterCommit == nil {
+			return git.Commits(ctx, *cachedRepo, opt)
+		}
+
+		// The position of the cursor commit in the log is unknown, so the
+		// log is listed up to the end and paginated here.
+		opt.N = 0
+		commits, err := git.Commits(ctx, *cachedRepo, opt)
+		if err != nil {
+			return nil, err
+		}
+		return commitsAfter(commits, *r.afterCommit, int(n))
 	}
 
 	r.once.Do(func() { r.commits, r.err = do() })
@@ -110,5 +133,26 @@ func (r *gitCommitConnectionResolver) PageInfo(ctx context.Context) (*graphqluti
 
 	// If we have a limit, so we rely on having fetched +1 additional result in our limit to
 	// indicate whether or not a next page exists.
-	return graphqlutil.HasNextPage(r.first != nil && len(commits) > 0 && len(commits) > int(*r.first)), nil
+	hasNextPage := r.first != nil && len(commits) > 0 && len(commits) > int(*r.first)
+	if hasNextPage && r.commitCursors && *r.first > 0 {
+		return graphqlutil.NextPageCursor(string(commits[*r.first-1].ID)), nil
+	}
+	return graphqlutil.HasNextPage(hasNextPage), nil
+}
+
+// commitsAfter returns at most n (or all if n is 0) of the given commits that
+// follow the commit with the ID after. It returns an error if there is no such
+// commit.
+func commitsAfter(commits []*git.Commit, after string, n int) ([]*git.Commit, error) {
+	for i, c := range commits {
+		if string(c.ID) != after {
+			continue
+		}
+		commits = commits[i+1:]
+		if n > 0 && len(commits) > n {
+			commits = commits[:n]
+		}
+		return commits, nil
+	}
+	return nil, fmt.Errorf("invalid cursor %q: not a commit in the list", after)
 }
@@ -0,0 +1,66 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestCommitsAfter(t *testing.T) {
+	commits := []*git.Commit{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+
+	tests := map[string]struct {
+		after   string
+		n       int
+		want    []api.CommitID
+		wantErr bool
+	}{
+		"first":        {after: "a", want: []api.CommitID{"b", "c", "d"}},
+		"limited":      {after: "a", n: 2, want: []api.CommitID{"b", "c"}},
+		"last":         {after: "d", want: []api.CommitID{}},
+		"not in list":  {after: "e", wantErr: true},
+		"date, not ID": {after: "2020-01-01", wantErr: true},
+	}
+	for name, test := range tests {
+		got, err := commitsAfter(commits, test.after, test.n)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v, want error %v", name, err, test.wantErr)
+			continue
+		}
+		if test.wantErr {
+			continue
+		}
+		ids := []api.CommitID{}
+		for _, c := range got {
+			ids = append(ids, c.ID)
+		}
+		if !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("%s: got %v, want %v", name, ids, test.want)
+		}
+	}
+}
+
+func TestGitCommitConnectionEndCursor(t *testing.T) {
+	first := int32(2)
+	r := &gitCommitConnectionResolver{
+		first:         &first,
+		commitCursors: true,
+		// The +1 commit fetched to determine whether a next page exists.
+		commits: []*git.Commit{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+	}
+	r.once.Do(func() {})
+
+	pageInfo, err := r.PageInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pageInfo.HasNextPage() {
+		t.Fatal("got no next page, want next page")
+	}
+	if cursor := pageInfo.EndCursor(); cursor == nil || *cursor != "b" {
+		t.Errorf("got end cursor %v, want %q", cursor, "b")
+	}
+}
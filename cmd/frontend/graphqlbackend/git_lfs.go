@@ -0,0 +1,165 @@
+package graphqlbackend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// LFSObjectReader, if set, returns a reader for the content of the Git LFS object with the given
+// OID (a SHA-256 hex string) in repo. It returns an error that satisfies os.IsNotExist if the
+// object is not available. If LFSObjectReader is nil, LFS objects are never available and LFS
+// pointer files are served as is (gitserver clones repositories without fetching LFS objects).
+var LFSObjectReader func(ctx context.Context, repo *types.Repo, oid string) (io.ReadCloser, error)
+
+// lfsPointerMaxBytes is the maximum size of a Git LFS pointer file, as defined by the spec
+// (https://github.com/git-lfs/git-lfs/blob/master/docs/spec.md).
+const lfsPointerMaxBytes = 1024
+
+// lfsObjectMaxBytes is the maximum size of a Git LFS object whose content is served in place of its
+// pointer file. The content is read fully into memory, so the pointer of larger objects is served.
+const lfsObjectMaxBytes = 50 * 1024 * 1024
+
+// errLFSObjectTooLarge is returned by readLFSObject if the object is larger than lfsObjectMaxBytes.
+var errLFSObjectTooLarge = errors.New("LFS object too large")
+
+var lfsPointerOIDPattern = regexp.MustCompile(`^sha256:([0-9a-f]{64})$`)
+
+// lfsPointer is the content of a Git LFS pointer file.
+type lfsPointer struct {
+	OID  string // the SHA-256 of the object, in hex
+	Size int64  // the size of the object in bytes
+}
+
+// parseLFSPointer parses content as a Git LFS pointer file. It returns nil if content is not a
+// pointer file.
+func parseLFSPointer(content []byte) *lfsPointer {
+	if len(content) > lfsPointerMaxBytes || !bytes.HasPrefix(content, []byte("version https://git-lfs.github.com/spec/")) {
+		return nil
+	}
+
+	var (
+		p       lfsPointer
+		hasSize bool
+	)
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		key, value := s.Text(), ""
+		if i := strings.IndexByte(key, ' '); i >= 0 {
+			key, value = key[:i], key[i+1:]
+		}
+		switch key {
+		case "oid":
+			m := lfsPointerOIDPattern.FindStringSubmatch(value)
+			if m == nil {
+				return nil
+			}
+			p.OID = m[1]
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return nil
+			}
+			p.Size, hasSize = size, true
+		}
+	}
+	if p.OID == "" || !hasSize {
+		return nil
+	}
+	return &p
+}
+
+// lfsPointer returns the Git LFS pointer of the file, or nil if the file is not an LFS pointer
+// file.
+func (r *GitTreeEntryResolver) lfsPointer(ctx context.Context) (*lfsPointer, error) {
+	if r.IsDirectory() {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	// Read one byte more than the maximum pointer size, so that larger files aren't mistaken for
+	// pointers.
+	head, err := git.ReadFile(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path(), lfsPointerMaxBytes+1)
+	if err != nil {
+		return nil, err
+	}
+	return parseLFSPointer(head), nil
+}
+
+// readLFSObject returns the content of the LFS object that p points to. The error satisfies
+// os.IsNotExist if the object is not available, and is errLFSObjectTooLarge if the object is larger
+// than lfsObjectMaxBytes.
+func readLFSObject(ctx context.Context, repo *types.Repo, p *lfsPointer) ([]byte, error) {
+	if p.Size > lfsObjectMaxBytes {
+		return nil, errLFSObjectTooLarge
+	}
+	if LFSObjectReader == nil {
+		return nil, &os.PathError{Op: "read LFS object", Path: p.OID, Err: os.ErrNotExist}
+	}
+	rc, err := LFSObjectReader(ctx, repo, p.OID)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// Don't rely on the size in the pointer: the object may be larger.
+	data, err := ioutil.ReadAll(io.LimitReader(rc, lfsObjectMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > lfsObjectMaxBytes {
+		return nil, errLFSObjectTooLarge
+	}
+	return data, nil
+}
+
+// LFS returns the Git LFS metadata of the file if it is an LFS pointer file, and nil otherwise.
+func (r *GitTreeEntryResolver) LFS(ctx context.Context) (*lfsResolver, error) {
+	p, err := r.lfsPointer(ctx)
+	if p == nil || err != nil {
+		return nil, err
+	}
+	return &lfsResolver{repo: r.commit.repo.repo, pointer: p}, nil
+}
+
+type lfsResolver struct {
+	repo    *types.Repo
+	pointer *lfsPointer
+}
+
+func (r *lfsResolver) OID() string { return r.pointer.OID }
+
+func (r *lfsResolver) ByteSize() float64 { return float64(r.pointer.Size) }
+
+func (r *lfsResolver) Available(ctx context.Context) (bool, error) {
+	if LFSObjectReader == nil {
+		return false, nil
+	}
+	rc, err := LFSObjectReader(ctx, r.repo, r.pointer.OID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, rc.Close()
+}
@@ -0,0 +1,151 @@
+package graphqlbackend
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const (
+	exampleLFSOID     = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	exampleLFSPointer = "version https://git-lfs.github.com/spec/v1\noid sha256:" + exampleLFSOID + "\nsize 12345\n"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    *lfsPointer
+	}{
+		"pointer":       {content: exampleLFSPointer, want: &lfsPointer{OID: exampleLFSOID, Size: 12345}},
+		"regular file":  {content: "package main\n"},
+		"missing size":  {content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + exampleLFSOID + "\n"},
+		"invalid oid":   {content: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 1\n"},
+		"too large":     {content: exampleLFSPointer + strings.Repeat("x", lfsPointerMaxBytes)},
+		"empty":         {content: ""},
+		"version later": {content: "oid sha256:" + exampleLFSOID + "\nsize 1\nversion https://git-lfs.github.com/spec/v1\n"},
+	}
+	for name, test := range tests {
+		if got := parseLFSPointer([]byte(test.content)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", name, got, test.want)
+		}
+	}
+}
+
+func TestGitTreeEntryContentLFS(t *testing.T) {
+	files := map[string]string{
+		"model.bin": exampleLFSPointer,
+		"main.go":   "package main\n",
+	}
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		return []byte(files[name]), nil
+	}
+	defer git.ResetMocks()
+	defer func() { LFSObjectReader = nil }()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	entry := func(path string) *GitTreeEntryResolver {
+		return &GitTreeEntryResolver{commit: commit, stat: CreateFileInfo(path, false)}
+	}
+	contentAndLFS := func(t *testing.T, path string) (string, *lfsResolver) {
+		t.Helper()
+		content, err := entry(path).Content(context.Background(), &struct{ TranscodeToUTF8 bool }{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lfs, err := entry(path).LFS(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return content, lfs
+	}
+
+	t.Run("LFS pointer, resolved", func(t *testing.T) {
+		LFSObjectReader = func(ctx context.Context, repo *types.Repo, oid string) (io.ReadCloser, error) {
+			if oid != exampleLFSOID {
+				t.Fatalf("got oid %q, want %q", oid, exampleLFSOID)
+			}
+			return ioutil.NopCloser(strings.NewReader("real content")), nil
+		}
+
+		content, lfs := contentAndLFS(t, "model.bin")
+		if want := "real content"; content != want {
+			t.Errorf("got content %q, want %q", content, want)
+		}
+		if lfs == nil || lfs.OID() != exampleLFSOID || lfs.ByteSize() != 12345 {
+			t.Fatalf("got LFS metadata %+v", lfs)
+		}
+		if available, err := lfs.Available(context.Background()); err != nil || !available {
+			t.Errorf("got available %v (err %v), want true", available, err)
+		}
+	})
+
+	t.Run("LFS pointer, unavailable", func(t *testing.T) {
+		LFSObjectReader = func(ctx context.Context, repo *types.Repo, oid string) (io.ReadCloser, error) {
+			return nil, &os.PathError{Op: "open", Path: oid, Err: os.ErrNotExist}
+		}
+
+		content, lfs := contentAndLFS(t, "model.bin")
+		if content != exampleLFSPointer {
+			t.Errorf("got content %q, want the pointer", content)
+		}
+		if lfs == nil || lfs.OID() != exampleLFSOID || lfs.ByteSize() != 12345 {
+			t.Fatalf("got LFS metadata %+v", lfs)
+		}
+		if available, err := lfs.Available(context.Background()); err != nil || available {
+			t.Errorf("got available %v (err %v), want false", available, err)
+		}
+	})
+
+	t.Run("LFS pointer, too large", func(t *testing.T) {
+		for name, pointer := range map[string]string{
+			"by pointer": strings.Replace(exampleLFSPointer, "size 12345", "size 10737418240", 1),
+			"by object":  exampleLFSPointer,
+		} {
+			files["huge.bin"] = pointer
+			LFSObjectReader = func(ctx context.Context, repo *types.Repo, oid string) (io.ReadCloser, error) {
+				if name == "by pointer" {
+					t.Fatal("LFSObjectReader called for an object larger than the limit")
+				}
+				// The object is larger than its pointer says.
+				return ioutil.NopCloser(io.LimitReader(zeroReader{}, lfsObjectMaxBytes+1)), nil
+			}
+
+			if content, _ := contentAndLFS(t, "huge.bin"); content != pointer {
+				t.Errorf("%s: got content of length %d, want the pointer", name, len(content))
+			}
+		}
+	})
+
+	t.Run("regular file", func(t *testing.T) {
+		LFSObjectReader = func(ctx context.Context, repo *types.Repo, oid string) (io.ReadCloser, error) {
+			t.Fatal("LFSObjectReader called for a regular file")
+			return nil, nil
+		}
+
+		content, lfs := contentAndLFS(t, "main.go")
+		if want := "package main\n"; content != want {
+			t.Errorf("got content %q, want %q", content, want)
+		}
+		if lfs != nil {
+			t.Errorf("got LFS metadata %+v, want nil", lfs)
+		}
+	})
+}
+
+// zeroReader reads an endless stream of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
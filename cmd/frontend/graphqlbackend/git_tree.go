@@ -2,11 +2,13 @@ package graphqlbackend
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path"
 	"sort"
 	"strings"
 
+	"github.com/gobwas/glob"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -24,6 +26,10 @@ type gitTreeEntryConnectionArgs struct {
 	// If recurseSingleChild is true, we will return a flat list of every
 	// directory and file in a single-child nest.
 	RecursiveSingleChild bool
+	// If WithLastCommit is true, the last commit of all entries is computed
+	// at once (instead of once per entry when the lastCommit field is
+	// resolved).
+	WithLastCommit bool
 }
 
 func (r *GitTreeEntryResolver) Entries(ctx context.Context, args *gitTreeEntryConnectionArgs) ([]*GitTreeEntryResolver, error) {
@@ -43,7 +49,13 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 	if err != nil {
 		return nil, err
 	}
-	entries, err := git.ReadDir(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path(), r.isRecursive || args.Recursive)
+	recursive := r.isRecursive || args.Recursive
+	dir := r.Path()
+	if r.pattern != nil && recursive && r.pattern.dir != "" {
+		// Only the sub-tree below the pattern's literal leading directories can contain matches.
+		dir = path.Join(dir, r.pattern.dir)
+	}
+	entries, err := git.ReadDir(ctx, *cachedRepo, api.CommitID(r.commit.OID()), dir, recursive)
 	if err != nil {
 		if strings.Contains(err.Error(), "file does not exist") { // TODO proper error value
 			// empty tree is not an error
@@ -52,6 +64,16 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 		}
 	}
 
+	if r.pattern != nil {
+		matched := entries[:0]
+		for _, entry := range entries {
+			if r.pattern.match(r.Path(), entry.Name()) {
+				matched = append(matched, entry)
+			}
+		}
+		entries = matched
+	}
+
 	sort.Sort(byDirectory(entries))
 
 	if args.First != nil && len(entries) > int(*args.First) {
@@ -70,6 +92,12 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 		}
 	}
 
+	if args.WithLastCommit {
+		if err := r.setLastCommits(ctx, l); err != nil {
+			return nil, err
+		}
+	}
+
 	if !args.Recursive && args.RecursiveSingleChild && len(l) == 1 {
 		subEntries, err := l[0].entries(ctx, args, filter)
 		if err != nil {
@@ -81,6 +109,83 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 	return l, nil
 }
 
+// setLastCommits sets the last commit of the given entries of the tree with a single batched
+// call. Entries without history (which should not happen) get the tree's commit.
+func (r *GitTreeEntryResolver) setLastCommits(ctx context.Context, entries []*GitTreeEntryResolver) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path()
+	}
+	commits, err := git.LastCommitsForPaths(ctx, *cachedRepo, api.CommitID(r.commit.OID()), paths)
+	if err != nil {
+		return err
+	}
+
+	resolvers := map[api.CommitID]*GitCommitResolver{}
+	for _, entry := range entries {
+		commit, ok := commits[entry.Path()]
+		if !ok {
+			entry.lastCommit = r.commit
+			continue
+		}
+		if resolvers[commit.ID] == nil {
+			resolvers[commit.ID] = toGitCommitResolver(r.commit.repo, commit)
+		}
+		entry.lastCommit = resolvers[commit.ID]
+	}
+	return nil
+}
+
+// treeEntryPattern is a glob pattern that tree entries are filtered by. It is matched against
+// the path of an entry relative to the tree that is listed. "*" and "?" don't match "/", "**"
+// matches any number of path components.
+type treeEntryPattern struct {
+	glob glob.Glob
+	// dir is the literal leading directories of the pattern (e.g. "cmd/frontend" for
+	// "cmd/frontend/**/*.go"), to which a recursive listing can be limited.
+	dir string
+}
+
+func compileTreeEntryPattern(pattern string) (*treeEntryPattern, error) {
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	// A "**/" matches zero or more directories.
+	expanded := strings.Replace(pattern, "/**/", "/{,**/}", -1)
+	if strings.HasPrefix(expanded, "**/") {
+		expanded = "{,**/}" + expanded[len("**/"):]
+	}
+	g, err := glob.Compile(expanded, '/')
+	if err != nil {
+		return nil, fmt.Errorf("invalid tree entry pattern %q: %s", pattern, err)
+	}
+
+	var dirs []string
+	components := strings.Split(pattern, "/")
+	for _, c := range components[:len(components)-1] {
+		if strings.ContainsAny(c, `*?[]{}\`) {
+			break
+		}
+		dirs = append(dirs, c)
+	}
+	return &treeEntryPattern{glob: g, dir: strings.Join(dirs, "/")}, nil
+}
+
+// match reports whether the entry with the given full path, listed in the tree at treePath,
+// matches the pattern.
+func (p *treeEntryPattern) match(treePath, entryPath string) bool {
+	if treePath != "" {
+		entryPath = strings.TrimPrefix(entryPath, treePath+"/")
+	}
+	return p.glob.Match(entryPath)
+}
+
 type byDirectory []os.FileInfo
 
 func (s byDirectory) Len() int {
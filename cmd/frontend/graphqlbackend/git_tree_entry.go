@@ -6,6 +6,7 @@ import (
 	neturl "net/url"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +44,10 @@ type GitTreeEntryResolver struct {
 
 	isRecursive   bool  // whether entries is populated recursively (otherwise just current level of hierarchy)
 	isSingleChild *bool // whether this is the single entry in its parent. Only set by the (&GitTreeEntryResolver) entries.
+
+	pattern *treeEntryPattern // if set, only the entries matching it are listed
+
+	lastCommit *GitCommitResolver // if set, the result of LastCommit, computed in a batch by entries
 }
 
 func NewGitTreeEntryResolver(commit *GitCommitResolver, stat os.FileInfo) *GitTreeEntryResolver {
@@ -106,10 +111,49 @@ func (r *GitTreeEntryResolver) urlPath(prefix string) (string, error) {
 
 func (r *GitTreeEntryResolver) IsDirectory() bool { return r.stat.Mode().IsDir() }
 
+// Mode returns the Git file mode of the tree entry, such as "100644" for a regular file.
+func (r *GitTreeEntryResolver) Mode() string { return gitFileMode(r.stat.Mode()) }
+
+func (r *GitTreeEntryResolver) IsExecutable() bool { return gitFileMode(r.stat.Mode()) == "100755" }
+
+func (r *GitTreeEntryResolver) IsSymlink() bool { return r.stat.Mode()&os.ModeSymlink != 0 }
+
+// gitFileMode returns the Git file mode for the os.FileMode of a tree entry as returned by
+// git.Stat or git.ReadDir. Git only distinguishes between these modes.
+func gitFileMode(mode os.FileMode) string {
+	switch {
+	case mode&git.ModeSubmodule == git.ModeSubmodule:
+		return "160000"
+	case mode.IsDir():
+		return "040000"
+	case mode&os.ModeSymlink != 0:
+		return "120000"
+	case mode.Perm()&0111 != 0:
+		return "100755"
+	default:
+		return "100644"
+	}
+}
+
 func (r *GitTreeEntryResolver) ExternalURLs(ctx context.Context) ([]*externallink.Resolver, error) {
 	return externallink.FileOrDir(ctx, r.commit.repo.repo, r.commit.inputRevOrImmutableRev(), r.Path(), r.stat.Mode().IsDir())
 }
 
+func (r *GitTreeEntryResolver) LastCommit(ctx context.Context) (*GitCommitResolver, error) {
+	if r.lastCommit != nil {
+		return r.lastCommit, nil
+	}
+	commit, err := r.commit.LastCommit(ctx, &struct{ Path string }{Path: r.Path()})
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil {
+		// The entry has no history, so fall back to the commit it was resolved at.
+		return r.commit, nil
+	}
+	return commit, nil
+}
+
 func (r *GitTreeEntryResolver) Submodule() *gitSubmoduleResolver {
 	if submoduleInfo, ok := r.stat.Sys().(git.Submodule); ok {
 		return &gitSubmoduleResolver{submodule: submoduleInfo}
@@ -226,6 +270,71 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context, args *gitTreeE
 	return len(entries) == 1, nil
 }
 
+// ChildCount returns the number of immediate children of this directory. It is 0 for other
+// entries. Only the directory itself is listed, not its subtrees.
+func (r *GitTreeEntryResolver) ChildCount(ctx context.Context) (int32, error) {
+	if !r.IsDirectory() {
+		return 0, nil
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := git.ReadDir(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path(), false)
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(entries)), nil
+}
+
+// Readme returns the README file of this directory, or nil if there is none or this entry is not
+// a directory. README files are matched case-insensitively by their name without extension, and
+// Markdown READMEs are preferred over others.
+func (r *GitTreeEntryResolver) Readme(ctx context.Context) (*GitTreeEntryResolver, error) {
+	if !r.IsDirectory() {
+		return nil, nil
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := git.ReadDir(ctx, *cachedRepo, api.CommitID(r.commit.OID()), r.Path(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var readme os.FileInfo
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || readmeRank(path.Base(entry.Name())) < 0 {
+			continue
+		}
+		if readme == nil || readmeRank(path.Base(entry.Name())) < readmeRank(path.Base(readme.Name())) {
+			readme = entry
+		}
+	}
+	if readme == nil {
+		return nil, nil
+	}
+	return &GitTreeEntryResolver{commit: r.commit, stat: readme}, nil
+}
+
+// readmeRank returns how well the file with the given base name fits as a README, lower being
+// better, or -1 if it is not a README.
+func readmeRank(name string) int {
+	ext := path.Ext(name)
+	if !strings.EqualFold(strings.TrimSuffix(name, ext), "readme") {
+		return -1
+	}
+	switch strings.ToLower(ext) {
+	case ".md", ".markdown":
+		return 0
+	case "":
+		return 1
+	default:
+		return 2
+	}
+}
+
 func (r *GitTreeEntryResolver) LSIF(ctx context.Context) (LSIFQueryResolver, error) {
 	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
 	return EnterpriseResolvers.codeIntelResolver.LSIF(ctx, &LSIFQueryArgs{
@@ -2,7 +2,10 @@ package graphqlbackend
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graph-gophers/graphql-go/gqltesting"
@@ -117,3 +120,292 @@ func TestGitTree(t *testing.T) {
 		},
 	})
 }
+
+func TestGitTreeEntryMode(t *testing.T) {
+	// The file modes as returned by git.Stat and git.ReadDir for the output of git ls-tree.
+	tests := []struct {
+		name         string
+		mode         os.FileMode
+		wantMode     string
+		isExecutable bool
+		isSymlink    bool
+	}{
+		{name: "directory", mode: 040000 | os.ModeDir, wantMode: "040000"},
+		{name: "regular file", mode: 0100644, wantMode: "100644"},
+		{name: "executable file", mode: 0100755, wantMode: "100755", isExecutable: true},
+		{name: "symlink", mode: os.ModeSymlink, wantMode: "120000", isSymlink: true},
+		{name: "submodule", mode: 0160000 | git.ModeSubmodule, wantMode: "160000"},
+		{name: "search result directory", mode: os.ModeDir, wantMode: "040000"},
+		{name: "search result file", mode: 0, wantMode: "100644"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &GitTreeEntryResolver{stat: &util.FileInfo{Name_: "a", Mode_: test.mode}}
+			if have := r.Mode(); have != test.wantMode {
+				t.Errorf("got mode %q, want %q", have, test.wantMode)
+			}
+			if have := r.IsExecutable(); have != test.isExecutable {
+				t.Errorf("got isExecutable %v, want %v", have, test.isExecutable)
+			}
+			if have := r.IsSymlink(); have != test.isSymlink {
+				t.Errorf("got isSymlink %v, want %v", have, test.isSymlink)
+			}
+		})
+	}
+}
+
+func TestGitTreePattern(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		return &util.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+	}
+	entries := []os.FileInfo{
+		&util.FileInfo{Name_: "src/cmd", Mode_: os.ModeDir},
+		&util.FileInfo{Name_: "src/cmd/main.go"},
+		&util.FileInfo{Name_: "src/cmd/main_test.go"},
+		&util.FileInfo{Name_: "src/doc.go"},
+		&util.FileInfo{Name_: "src/README.md"},
+	}
+	var readDirName string
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]os.FileInfo, error) {
+		readDirName = name
+		var l []os.FileInfo
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), name+"/") {
+				l = append(l, e)
+			}
+		}
+		return l, nil
+	}
+	defer git.ResetMocks()
+
+	tests := []struct {
+		pattern     string
+		wantPaths   []string
+		wantReadDir string
+	}{
+		{pattern: "*.go", wantPaths: []string{"src/doc.go"}, wantReadDir: "src"},
+		{pattern: "**/*.go", wantPaths: []string{"src/cmd/main.go", "src/cmd/main_test.go", "src/doc.go"}, wantReadDir: "src"},
+		{pattern: "**/*_test.go", wantPaths: []string{"src/cmd/main_test.go"}, wantReadDir: "src"},
+		{pattern: "cmd/**", wantPaths: []string{"src/cmd/main.go", "src/cmd/main_test.go"}, wantReadDir: "src/cmd"},
+		{pattern: "?md", wantPaths: []string{"src/cmd"}, wantReadDir: "src"},
+		{pattern: "*.rs", wantPaths: nil, wantReadDir: "src"},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+			tree, err := commit.Tree(context.Background(), &struct {
+				Path      string
+				Recursive bool
+				Pattern   *string
+			}{Path: "src", Recursive: true, Pattern: &test.pattern})
+			if err != nil {
+				t.Fatal(err)
+			}
+			l, err := tree.Entries(context.Background(), &gitTreeEntryConnectionArgs{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, e := range l {
+				paths = append(paths, e.Path())
+			}
+			if !reflect.DeepEqual(paths, test.wantPaths) {
+				t.Errorf("got paths %q, want %q", paths, test.wantPaths)
+			}
+			if readDirName != test.wantReadDir {
+				t.Errorf("got ReadDir of %q, want %q", readDirName, test.wantReadDir)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+		pattern := "[a-"
+		if _, err := commit.Tree(context.Background(), &struct {
+			Path      string
+			Recursive bool
+			Pattern   *string
+		}{Path: "src", Pattern: &pattern}); err == nil {
+			t.Fatal("got no error, want an error")
+		}
+	})
+}
+
+func TestGitTreeEntriesWithLastCommit(t *testing.T) {
+	resetMocks()
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		return &util.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+	}
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]os.FileInfo, error) {
+		return []os.FileInfo{
+			&util.FileInfo{Name_: "src/cmd", Mode_: os.ModeDir},
+			&util.FileInfo{Name_: "src/doc.go"},
+			&util.FileInfo{Name_: "src/untouched.go"},
+		}, nil
+	}
+	var calls int
+	git.Mocks.LastCommitsForPaths = func(commit api.CommitID, paths []string) (map[string]*git.Commit, error) {
+		calls++
+		if string(commit) != exampleCommitSHA1 {
+			t.Errorf("got commit %q, want %q", commit, exampleCommitSHA1)
+		}
+		if want := []string{"src/cmd", "src/doc.go", "src/untouched.go"}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("got paths %q, want %q", paths, want)
+		}
+		return map[string]*git.Commit{
+			"src/cmd":    {ID: "1111111111111111111111111111111111111111"},
+			"src/doc.go": {ID: "2222222222222222222222222222222222222222"},
+		}, nil
+	}
+	defer git.ResetMocks()
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						commit(rev: "` + exampleCommitSHA1 + `") {
+							tree(path: "src") {
+								entries(withLastCommit: true) {
+									path
+									lastCommit {
+										oid
+									}
+								}
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+{
+  "repository": {
+    "commit": {
+      "tree": {
+        "entries": [
+          {
+            "path": "src/cmd",
+            "lastCommit": {
+              "oid": "1111111111111111111111111111111111111111"
+            }
+          },
+          {
+            "path": "src/doc.go",
+            "lastCommit": {
+              "oid": "2222222222222222222222222222222222222222"
+            }
+          },
+          {
+            "path": "src/untouched.go",
+            "lastCommit": {
+              "oid": "1234567890123456789012345678901234567890"
+            }
+          }
+        ]
+      }
+    }
+  }
+}
+			`,
+		},
+	})
+	if calls != 1 {
+		t.Errorf("got %d calls to LastCommitsForPaths, want 1", calls)
+	}
+}
+
+func TestGitTreeEntryChildCount(t *testing.T) {
+	children := map[string][]os.FileInfo{"empty": nil}
+	for i := 0; i < 1500; i++ {
+		children["many"] = append(children["many"], &util.FileInfo{Name_: fmt.Sprintf("many/%d.go", i)})
+	}
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]os.FileInfo, error) {
+		if recurse {
+			t.Error("got recurse == true, want false")
+		}
+		return children[name], nil
+	}
+	defer git.ResetMocks()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	tests := map[string]struct {
+		stat os.FileInfo
+		want int32
+	}{
+		"empty directory": {stat: &util.FileInfo{Name_: "empty", Mode_: os.ModeDir}, want: 0},
+		"many children":   {stat: &util.FileInfo{Name_: "many", Mode_: os.ModeDir}, want: 1500},
+		"file":            {stat: &util.FileInfo{Name_: "many/0.go"}, want: 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			count, err := NewGitTreeEntryResolver(commit, test.stat).ChildCount(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != test.want {
+				t.Errorf("got %d children, want %d", count, test.want)
+			}
+		})
+	}
+}
+
+func TestGitTreeEntryReadme(t *testing.T) {
+	children := map[string][]os.FileInfo{
+		"markdown": {
+			&util.FileInfo{Name_: "markdown/README"},
+			&util.FileInfo{Name_: "markdown/README.md"},
+			&util.FileInfo{Name_: "markdown/main.go"},
+		},
+		"text": {
+			&util.FileInfo{Name_: "text/a.go"},
+			&util.FileInfo{Name_: "text/readme.txt"},
+			&util.FileInfo{Name_: "text/readme", Mode_: os.ModeDir},
+		},
+		"none": {
+			&util.FileInfo{Name_: "none/main.go"},
+			&util.FileInfo{Name_: "none/READMEFIRST.md"},
+		},
+	}
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]os.FileInfo, error) {
+		return children[name], nil
+	}
+	defer git.ResetMocks()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	for dir, want := range map[string]string{
+		"markdown": "markdown/README.md",
+		"text":     "text/readme.txt",
+		"none":     "",
+	} {
+		t.Run(dir, func(t *testing.T) {
+			readme, err := NewGitTreeEntryResolver(commit, &util.FileInfo{Name_: dir, Mode_: os.ModeDir}).Readme(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got string
+			if readme != nil {
+				got = readme.Path()
+			}
+			if got != want {
+				t.Errorf("got README %q, want %q", got, want)
+			}
+		})
+	}
+}
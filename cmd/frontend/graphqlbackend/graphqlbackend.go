@@ -259,6 +259,11 @@ type schemaResolver struct {
 	CodeIntelResolver
 }
 
+// RequestContextFuncs are called with the context of every GraphQL request, before it is
+// executed, and return the context to execute it with. They can add request-scoped state (such as
+// caches) to it. They may be appended to at init time.
+var RequestContextFuncs []func(context.Context) context.Context
+
 // EnterpriseResolvers holds the instances of resolvers which are enabled only
 // in enterprise mode. These resolver instances are nil when running as OSS.
 var EnterpriseResolvers = struct {
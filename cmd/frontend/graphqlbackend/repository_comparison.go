@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -118,18 +119,70 @@ func (r *RepositoryComparisonResolver) Commits(
 	}
 }
 
+// defaultDiffSimilarityThreshold is the default minimum similarity (in percent) of an old and a new
+// file for the new file to be considered a rename or copy of the old file. It is git's default.
+const defaultDiffSimilarityThreshold = 50
+
+// execDiff runs `git diff` for the comparison and returns a reader for its output. The caller must
+// close the reader. Renames and copies are detected using the given similarity threshold (in
+// percent).
+func (r *RepositoryComparisonResolver) execDiff(ctx context.Context, similarityThreshold int32) (io.ReadCloser, error) {
+	var rangeSpec string
+	hOid := r.head.OID()
+	if r.base == nil {
+		// Rare case: the base is the empty tree, in which case we need ".." not "..." because the latter only works for commits.
+		rangeSpec = string(r.baseRevspec) + ".." + string(hOid)
+	} else {
+		rangeSpec = string(r.base.OID()) + "..." + string(hOid)
+	}
+	if strings.HasPrefix(rangeSpec, "-") || strings.HasPrefix(rangeSpec, ".") {
+		// This should not be possible since r.head is a SHA returned by ResolveRevision, but be
+		// extra careful to avoid letting user input add additional `git diff` command-line
+		// flags or refer to a file.
+		return nil, fmt.Errorf("invalid diff range argument: %q", rangeSpec)
+	}
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repo.repo)
+	if err != nil {
+		return nil, err
+	}
+	return git.ExecReader(ctx, *cachedRepo, []string{
+		"diff",
+		fmt.Sprintf("--find-renames=%d%%", similarityThreshold),
+		fmt.Sprintf("--find-copies=%d%%", similarityThreshold),
+		"--full-index",
+		"--inter-hunk-context=3",
+		"--no-prefix",
+		rangeSpec,
+		"--",
+	})
+}
+
+type fileDiffsConnectionArgs struct {
+	graphqlutil.ConnectionArgs
+	SimilarityThreshold *int32
+}
+
 func (r *RepositoryComparisonResolver) FileDiffs(
-	args *graphqlutil.ConnectionArgs,
-) *fileDiffConnectionResolver {
-	return &fileDiffConnectionResolver{
-		cmp:   r,
-		first: args.First,
+	args *fileDiffsConnectionArgs,
+) (*fileDiffConnectionResolver, error) {
+	similarityThreshold := int32(defaultDiffSimilarityThreshold)
+	if args.SimilarityThreshold != nil {
+		similarityThreshold = *args.SimilarityThreshold
+	}
+	if similarityThreshold < 1 || similarityThreshold > 100 {
+		return nil, fmt.Errorf("similarityThreshold must be between 1 and 100, got %d", similarityThreshold)
 	}
+	return &fileDiffConnectionResolver{
+		cmp:                 r,
+		first:               args.First,
+		similarityThreshold: similarityThreshold,
+	}, nil
 }
 
 type fileDiffConnectionResolver struct {
-	cmp   *RepositoryComparisonResolver // {base,head}{,RevSpec} and repo
-	first *int32
+	cmp                 *RepositoryComparisonResolver // {base,head}{,RevSpec} and repo
+	first               *int32
+	similarityThreshold int32 // for detecting renames and copies, in percent
 
 	// cache result because it is used by multiple fields
 	once        sync.Once
@@ -140,34 +193,7 @@ type fileDiffConnectionResolver struct {
 
 func (r *fileDiffConnectionResolver) compute(ctx context.Context) ([]*diff.FileDiff, error) {
 	do := func() ([]*diff.FileDiff, error) {
-		var rangeSpec string
-		hOid := r.cmp.head.OID()
-		if r.cmp.base == nil {
-			// Rare case: the base is the empty tree, in which case we need ".." not "..." because the latter only works for commits.
-			rangeSpec = string(r.cmp.baseRevspec) + ".." + string(hOid)
-		} else {
-			rangeSpec = string(r.cmp.base.OID()) + "..." + string(hOid)
-		}
-		if strings.HasPrefix(rangeSpec, "-") || strings.HasPrefix(rangeSpec, ".") {
-			// This should not be possible since r.head is a SHA returned by ResolveRevision, but be
-			// extra careful to avoid letting user input add additional `git diff` command-line
-			// flags or refer to a file.
-			return nil, fmt.Errorf("invalid diff range argument: %q", rangeSpec)
-		}
-		cachedRepo, err := backend.CachedGitRepo(ctx, r.cmp.repo.repo)
-		if err != nil {
-			return nil, err
-		}
-		rdr, err := git.ExecReader(ctx, *cachedRepo, []string{
-			"diff",
-			"--find-renames",
-			"--find-copies",
-			"--full-index",
-			"--inter-hunk-context=3",
-			"--no-prefix",
-			rangeSpec,
-			"--",
-		})
+		rdr, err := r.cmp.execDiff(ctx, r.similarityThreshold)
 		if err != nil {
 			return nil, err
 		}
@@ -177,7 +203,7 @@ func (r *fileDiffConnectionResolver) compute(ctx context.Context) ([]*diff.FileD
 		if r.first != nil {
 			fileDiffs = make([]*diff.FileDiff, 0, int(*r.first)) // preallocate
 		}
-		dr := diff.NewMultiFileDiffReader(rdr)
+		dr := newFileDiffReader(rdr)
 		for {
 			fileDiff, err := dr.ReadFile()
 			if err == io.EOF {
@@ -277,7 +303,7 @@ func (r *fileDiffResolver) NewPath() *string { return diffPathOrNull(r.fileDiff.
 func (r *fileDiffResolver) Hunks() []*DiffHunk {
 	hunks := make([]*DiffHunk, len(r.fileDiff.Hunks))
 	for i, hunk := range r.fileDiff.Hunks {
-		hunks[i] = NewDiffHunk(hunk)
+		hunks[i] = NewDiffHunk(r.fileDiff, hunk)
 	}
 	return hunks
 }
@@ -287,6 +313,40 @@ func (r *fileDiffResolver) Stat() *DiffStat {
 	return NewDiffStat(stat)
 }
 
+// IsBinary reports whether git considers the old or new file to be binary. The diff of a binary
+// file has no hunks.
+func (r *fileDiffResolver) IsBinary() bool {
+	for _, header := range r.fileDiff.Extended {
+		if strings.HasPrefix(header, "Binary files ") {
+			return true
+		}
+	}
+	return false
+}
+
+// OldByteSize returns the size in bytes of the old file if the file is binary, and nil otherwise
+// or if there is no old file.
+func (r *fileDiffResolver) OldByteSize(ctx context.Context) (*int32, error) {
+	return r.binaryFileSize(ctx, r.cmp.base, r.fileDiff.OrigName)
+}
+
+// NewByteSize returns the size in bytes of the new file if the file is binary, and nil otherwise
+// or if there is no new file.
+func (r *fileDiffResolver) NewByteSize(ctx context.Context) (*int32, error) {
+	return r.binaryFileSize(ctx, r.cmp.head, r.fileDiff.NewName)
+}
+
+func (r *fileDiffResolver) binaryFileSize(ctx context.Context, commit *GitCommitResolver, name string) (*int32, error) {
+	if !r.IsBinary() || commit == nil || diffPathOrNull(name) == nil {
+		return nil, nil
+	}
+	size, err := commit.BlobSize(ctx, &struct{ Path string }{Path: name})
+	if err != nil {
+		return nil, err
+	}
+	return &size, nil
+}
+
 func (r *fileDiffResolver) OldFile() *GitTreeEntryResolver {
 	if diffPathOrNull(r.fileDiff.OrigName) == nil {
 		return nil
@@ -307,6 +367,27 @@ func (r *fileDiffResolver) NewFile() *GitTreeEntryResolver {
 	}
 }
 
+func (r *fileDiffResolver) ChangeKind() string {
+	// A rename or copy (which may have modifications, too) is identified by the extended headers
+	// that git writes after the "diff --git" line.
+	for _, header := range r.fileDiff.Extended {
+		switch {
+		case strings.HasPrefix(header, "rename from "):
+			return "RENAMED"
+		case strings.HasPrefix(header, "copy from "):
+			return "COPIED"
+		}
+	}
+	switch {
+	case diffPathOrNull(r.fileDiff.OrigName) == nil:
+		return "ADDED"
+	case diffPathOrNull(r.fileDiff.NewName) == nil:
+		return "DELETED"
+	default:
+		return "MODIFIED"
+	}
+}
+
 func (r *fileDiffResolver) MostRelevantFile() *GitTreeEntryResolver {
 	if newFile := r.NewFile(); newFile != nil {
 		return newFile
@@ -319,6 +400,70 @@ func (r *fileDiffResolver) InternalID() string {
 	return hex.EncodeToString(b[:])[:32]
 }
 
+// fileDiffReader reads the file diffs in `git diff` output. It works around limitations of the
+// diff parser:
+//
+//   - The parser drops the last file diff if it consists only of extended headers (such as a
+//     rename or copy without modifications). To read it, too, a file header without any content
+//     is appended, which ends the last file diff and is itself dropped.
+//   - The parser only takes the names of a file diff without hunks from "rename from" and
+//     "rename to" extended headers, so the names of copies are taken from "copy from" and "copy
+//     to" headers here.
+type fileDiffReader struct {
+	r *diff.MultiFileDiffReader
+}
+
+func newFileDiffReader(r io.Reader) *fileDiffReader {
+	return &fileDiffReader{r: diff.NewMultiFileDiffReader(io.MultiReader(r, strings.NewReader("diff --git \n")))}
+}
+
+// ReadFile reads the next file diff. It returns io.EOF if there are no more file diffs.
+func (r *fileDiffReader) ReadFile() (*diff.FileDiff, error) {
+	fileDiff, err := r.r.ReadFile()
+	if err != nil {
+		return nil, err
+	}
+	if fileDiff.OrigName == "" && fileDiff.NewName == "" {
+		for _, header := range fileDiff.Extended {
+			if name := strings.TrimPrefix(header, "copy from "); name != header {
+				fileDiff.OrigName = unquoteDiffPath(name)
+			} else if name := strings.TrimPrefix(header, "copy to "); name != header {
+				fileDiff.NewName = unquoteDiffPath(name)
+			}
+		}
+	}
+	return fileDiff, nil
+}
+
+// unquoteDiffPath unquotes a path in a `git diff` header, which git quotes if it contains special
+// characters.
+func unquoteDiffPath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+	return path
+}
+
+// findFileDiff returns the first file diff in the `git diff --no-prefix` output read from r whose
+// old or new name is path, or nil if there is none.
+func findFileDiff(r io.Reader, path string) (*diff.FileDiff, error) {
+	dr := newFileDiffReader(r)
+	for {
+		fileDiff, err := dr.ReadFile()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if fileDiff.OrigName == path || fileDiff.NewName == path {
+			return fileDiff, nil
+		}
+	}
+}
+
 func diffPathOrNull(path string) *string {
 	if path == "/dev/null" || path == "" {
 		return nil
@@ -326,12 +471,25 @@ func diffPathOrNull(path string) *string {
 	return &path
 }
 
-func NewDiffHunk(hunk *diff.Hunk) *DiffHunk {
-	return &DiffHunk{hunk: hunk}
+// NewDiffHunk returns a DiffHunk for the given hunk of fileDiff.
+func NewDiffHunk(fileDiff *diff.FileDiff, hunk *diff.Hunk) *DiffHunk {
+	return &DiffHunk{fileDiff: fileDiff, hunk: hunk}
 }
 
 type DiffHunk struct {
-	hunk *diff.Hunk
+	fileDiff *diff.FileDiff
+	hunk     *diff.Hunk
+}
+
+// Anchor returns an identifier of the hunk derived from the file's old and new paths and the
+// hunk's old and new line ranges. It is the same every time the same diff is computed, so that
+// clients can attach data (such as comments) to hunks.
+func (r *DiffHunk) Anchor() string {
+	b := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s:%d,%d:%d,%d",
+		len(r.fileDiff.OrigName), r.fileDiff.OrigName, r.fileDiff.NewName,
+		r.hunk.OrigStartLine, r.hunk.OrigLines, r.hunk.NewStartLine, r.hunk.NewLines,
+	)))
+	return hex.EncodeToString(b[:])[:32]
 }
 
 func (r *DiffHunk) OldRange() *DiffHunkRange {
@@ -0,0 +1,325 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+func TestFindFileDiff(t *testing.T) {
+	// Output of `git diff --find-renames --find-copies --full-index --inter-hunk-context=3 --no-prefix`.
+	const rawDiff = `diff --git add.txt add.txt
+new file mode 100644
+index 0000000000000000000000000000000000000000..8ba3a16384aacc37d01564b28401755ce8053f51
+--- /dev/null
++++ add.txt
+@@ -0,0 +1 @@
++n
+diff --git b.txt c.txt
+similarity index 100%
+rename from b.txt
+rename to c.txt
+diff --git del.txt del.txt
+deleted file mode 100644
+index 587be6b4c3f93f93c489c0111bba5596147a26cb..0000000000000000000000000000000000000000
+--- del.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-x
+diff --git mod.txt mod.txt
+index de980441c3ab03a8c07dda1ad27b8a11f39deb1e..7be73ce3c1b1cdaea86e8168dfee8575175953bf 100644
+--- mod.txt
++++ mod.txt
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`
+
+	tests := []struct {
+		path             string
+		oldPath, newPath string // empty if null
+		hunks            int
+		changeKind       string
+	}{
+		{path: "add.txt", newPath: "add.txt", hunks: 1, changeKind: "ADDED"},
+		{path: "del.txt", oldPath: "del.txt", hunks: 1, changeKind: "DELETED"},
+		{path: "mod.txt", oldPath: "mod.txt", newPath: "mod.txt", hunks: 1, changeKind: "MODIFIED"},
+		{path: "b.txt", oldPath: "b.txt", newPath: "c.txt", hunks: 0, changeKind: "RENAMED"},
+		{path: "c.txt", oldPath: "b.txt", newPath: "c.txt", hunks: 0, changeKind: "RENAMED"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			fileDiff, err := findFileDiff(strings.NewReader(rawDiff), test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fileDiff == nil {
+				t.Fatal("got no file diff")
+			}
+
+			r := &fileDiffResolver{fileDiff: fileDiff}
+			if got := orEmpty(r.OldPath()); got != test.oldPath {
+				t.Errorf("got old path %q, want %q", got, test.oldPath)
+			}
+			if got := orEmpty(r.NewPath()); got != test.newPath {
+				t.Errorf("got new path %q, want %q", got, test.newPath)
+			}
+			if got := len(r.Hunks()); got != test.hunks {
+				t.Errorf("got %d hunks, want %d", got, test.hunks)
+			}
+			if got := r.ChangeKind(); got != test.changeKind {
+				t.Errorf("got change kind %q, want %q", got, test.changeKind)
+			}
+		})
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		fileDiff, err := findFileDiff(strings.NewReader(rawDiff), "other.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fileDiff != nil {
+			t.Errorf("got file diff %+v, want none", fileDiff)
+		}
+	})
+}
+
+func TestFileDiffChangeKind(t *testing.T) {
+	// Output of `git diff --find-renames=50% --find-copies=50% --full-index --inter-hunk-context=3
+	// --no-prefix` after renaming a.txt, renaming and modifying b.txt, and copying c.txt (and
+	// modifying the original).
+	const rawDiff = `diff --git a.txt a2.txt
+similarity index 100%
+rename from a.txt
+rename to a2.txt
+diff --git b.txt b2.txt
+similarity index 94%
+rename from b.txt
+rename to b2.txt
+index d92818a4ff0db01c7c2490e7113e6753ec9230cd..f73ecd5246f7310329c156b4b10c6c8592307d10 100644
+--- b.txt
++++ b2.txt
+@@ -8,7 +8,7 @@
+ 107
+ 108
+ 109
+-110
++110x
+ 111
+ 112
+ 113
+diff --git c.txt c.txt
+index e34c1f92ff3dbc6780933c071ed280b8f9aed69d..87b5b65c1b45457f87357582ca6abcfa23244168 100644
+--- c.txt
++++ c.txt
+@@ -19,3 +19,4 @@
+ 218
+ 219
+ 220
++221
+diff --git c.txt c2.txt
+similarity index 100%
+copy from c.txt
+copy to c2.txt
+`
+	var got []string
+	dr := newFileDiffReader(strings.NewReader(rawDiff))
+	for {
+		fileDiff, err := dr.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &fileDiffResolver{fileDiff: fileDiff}
+		got = append(got, fmt.Sprintf("%s %s -> %s (%d hunks)", r.ChangeKind(), orEmpty(r.OldPath()), orEmpty(r.NewPath()), len(r.Hunks())))
+	}
+	want := []string{
+		"RENAMED a.txt -> a2.txt (0 hunks)",
+		"RENAMED b.txt -> b2.txt (1 hunks)",
+		"MODIFIED c.txt -> c.txt (1 hunks)",
+		"COPIED c.txt -> c2.txt (0 hunks)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFileDiffBinary(t *testing.T) {
+	const (
+		baseOID = "1111111111111111111111111111111111111111"
+		headOID = "2222222222222222222222222222222222222222"
+	)
+	// Output of `git diff --full-index --no-prefix` after modifying a text and a binary file.
+	const rawDiff = `diff --git README.md README.md
+index e34c1f92ff3dbc6780933c071ed280b8f9aed69d..87b5b65c1b45457f87357582ca6abcfa23244168 100644
+--- README.md
++++ README.md
+@@ -1,2 +1,2 @@
+ # Project
+-Old description
++New description
+diff --git img/logo.png img/logo.png
+index d92818a4ff0db01c7c2490e7113e6753ec9230cd..f73ecd5246f7310329c156b4b10c6c8592307d10 100644
+Binary files img/logo.png and img/logo.png differ
+`
+	sizes := map[api.CommitID]map[string]int64{
+		baseOID: {"README.md": 32, "img/logo.png": 2048},
+		headOID: {"README.md": 32, "img/logo.png": 4096},
+	}
+	git.Mocks.Stat = func(commit api.CommitID, name string) (os.FileInfo, error) {
+		size, ok := sizes[commit][name]
+		if !ok {
+			return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+		}
+		return &util.FileInfo{Name_: name, Size_: size}, nil
+	}
+	defer git.ResetMocks()
+
+	repo := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}
+	cmp := &RepositoryComparisonResolver{
+		base: &GitCommitResolver{repo: repo, oid: baseOID},
+		head: &GitCommitResolver{repo: repo, oid: headOID},
+		repo: repo,
+	}
+
+	var got []string
+	dr := newFileDiffReader(strings.NewReader(rawDiff))
+	for {
+		fileDiff, err := dr.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := &fileDiffResolver{fileDiff: fileDiff, cmp: cmp}
+		oldSize, err := r.OldByteSize(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		newSize, err := r.NewByteSize(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		stat := r.Stat()
+		got = append(got, fmt.Sprintf("%s binary=%v lines=+%d~%d-%d bytes=%s->%s", orEmpty(r.NewPath()), r.IsBinary(), stat.Added(), stat.Changed(), stat.Deleted(), int32OrNull(oldSize), int32OrNull(newSize)))
+	}
+	want := []string{
+		"README.md binary=false lines=+0~1-0 bytes=null->null",
+		"img/logo.png binary=true lines=+0~0-0 bytes=2048->4096",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func int32OrNull(n *int32) string {
+	if n == nil {
+		return "null"
+	}
+	return fmt.Sprint(*n)
+}
+
+func TestRepositoryComparisonFileDiffsSimilarityThreshold(t *testing.T) {
+	cmp := &RepositoryComparisonResolver{}
+	threshold := func(n int32) *int32 { return &n }
+
+	for _, test := range []struct {
+		threshold *int32
+		want      int32
+	}{
+		{threshold: nil, want: defaultDiffSimilarityThreshold},
+		{threshold: threshold(1), want: 1},
+		{threshold: threshold(100), want: 100},
+	} {
+		conn, err := cmp.FileDiffs(&fileDiffsConnectionArgs{SimilarityThreshold: test.threshold})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conn.similarityThreshold != test.want {
+			t.Errorf("got similarity threshold %d, want %d", conn.similarityThreshold, test.want)
+		}
+	}
+
+	for _, invalid := range []int32{0, 101} {
+		if _, err := cmp.FileDiffs(&fileDiffsConnectionArgs{SimilarityThreshold: &invalid}); err == nil {
+			t.Errorf("threshold %d: got no error, want an error", invalid)
+		}
+	}
+}
+
+func orEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestDiffHunkAnchor(t *testing.T) {
+	const rawDiff = `diff --git a.txt a.txt
+index de980441c3ab03a8c07dda1ad27b8a11f39deb1e..7be73ce3c1b1cdaea86e8168dfee8575175953bf 100644
+--- a.txt
++++ a.txt
+@@ -1,2 +1,2 @@
+-a
++b
+ c
+@@ -10,2 +10,2 @@
+ d
+-e
++f
+diff --git b.txt b.txt
+index de980441c3ab03a8c07dda1ad27b8a11f39deb1e..7be73ce3c1b1cdaea86e8168dfee8575175953bf 100644
+--- b.txt
++++ b.txt
+@@ -1,2 +1,2 @@
+-a
++b
+ c
+`
+	anchors := func() []string {
+		fileDiffs, err := diff.ParseMultiFileDiff([]byte(rawDiff))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var anchors []string
+		for _, fileDiff := range fileDiffs {
+			for _, hunk := range (&fileDiffResolver{fileDiff: fileDiff}).Hunks() {
+				anchors = append(anchors, hunk.Anchor())
+			}
+		}
+		return anchors
+	}
+
+	first, second := anchors(), anchors()
+	if len(first) != 3 {
+		t.Fatalf("got %d anchors, want 3", len(first))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("anchors changed between diff computations: %q != %q", first, second)
+	}
+
+	// Hunks with different ranges in the same file and hunks with the same ranges in different
+	// files have different anchors.
+	seen := map[string]bool{}
+	for _, a := range first {
+		if seen[a] {
+			t.Errorf("duplicate anchor %q in %q", a, first)
+		}
+		seen[a] = true
+	}
+}
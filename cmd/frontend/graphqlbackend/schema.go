@@ -60,6 +60,8 @@ type Mutation {
         # respective codehosts, where "close" means the appropriate final state
         # on the codehost (e.g. "declined" on Bitbucket Server).
         closeChangesets: Boolean = false
+        # Why the campaign is deleted. It is recorded in the campaign's activity timeline.
+        reason: String
     ): EmptyResponse
     # Closes a campaign.
     # Closing a campaign sets the Campaign's ClosedAt timestamp to the current
@@ -450,6 +452,10 @@ input CreateCampaignInput {
     # When a Campaign is created in draft mode, its changesetPlans are not
     # created on the codehost, but only when publishing the Campaign.
     draft: Boolean
+
+    # An optional key identifying this request. If a campaign was already created in the namespace with the same
+    # key, that campaign is returned instead of creating a new one. This allows clients to safely retry requests.
+    idempotencyKey: String
 }
 
 # Input arguments for updating a campaign.
@@ -718,7 +724,7 @@ type ExternalChangeset implements Node {
     repository: Repository!
 
     # The campaigns that have this changeset in them.
-    campaigns(first: Int, state: CampaignState): CampaignConnection!
+    campaigns(first: Int, after: String, state: CampaignState): CampaignConnection!
 
     # The events belonging to this changeset.
     events(first: Int): ChangesetEventConnection!
@@ -1146,7 +1152,12 @@ type Query {
     campaigns(
         # Returns the first n campaigns from the list.
         first: Int
+        # Opaque pagination cursor, as returned in pageInfo.endCursor.
+        after: String
         state: CampaignState
+        # Only return campaigns whose name or description match all terms of this query. Terms prefixed
+        # with "-" exclude matching campaigns. A literal leading "-" can be escaped as "\-".
+        query: String
     ): CampaignConnection!
 
     # Looks up a repository by either name or cloneURL.
@@ -2126,6 +2137,9 @@ type RepositoryComparison {
     fileDiffs(
         # Return the first n file diffs from the list.
         first: Int
+        # The minimum similarity (in percent, from 1 to 100) of an old and a new file for the new
+        # file to be considered a rename or copy of the old file. Defaults to 50.
+        similarityThreshold: Int
     ): FileDiffConnection!
 }
 
@@ -2156,13 +2170,22 @@ type FileDiff {
     newPath: String
     # The new file, or null if the file was deleted (newFile.path == newPath).
     newFile: File2
+    # The kind of change to the file.
+    changeKind: FileDiffChangeKind!
     # The old file (if the file was deleted) and otherwise the new file. This file field is typically used by
     # clients that want to show a "View" link to the file.
     mostRelevantFile: File2!
-    # Hunks that were changed from old to new.
+    # Hunks that were changed from old to new. This is empty for binary files.
     hunks: [FileDiffHunk!]!
-    # The diff stat for the whole file.
+    # The diff stat for the whole file. It counts no lines for binary files; use oldByteSize and
+    # newByteSize for them instead.
     stat: DiffStat!
+    # Whether the old or new file is binary, in which case the diff has no hunks.
+    isBinary: Boolean!
+    # The size in bytes of the old file, or null if the file is not binary or was added.
+    oldByteSize: Int
+    # The size in bytes of the new file, or null if the file is not binary or was deleted.
+    newByteSize: Int
     # FOR INTERNAL USE ONLY.
     #
     # An identifier for the file diff that is unique among all other file diffs in the list that
@@ -2170,6 +2193,21 @@ type FileDiff {
     internalID: String!
 }
 
+# The kind of change to a file in a file diff.
+enum FileDiffChangeKind {
+    # The file was added.
+    ADDED
+    # The file was deleted.
+    DELETED
+    # The file was modified.
+    MODIFIED
+    # The file was renamed (and possibly modified). The old path is the path before the rename.
+    RENAMED
+    # The file was copied (and possibly modified). The old path is the path of the file that was
+    # copied, which still exists.
+    COPIED
+}
+
 # A changed region ("hunk") in a file diff.
 type FileDiffHunk {
     # The range of the old file that the hunk applies to.
@@ -2182,6 +2220,10 @@ type FileDiffHunk {
     section: String
     # The hunk body, with lines prefixed with '-', '+', or ' '.
     body: String!
+    # An identifier of the hunk that is derived from the file's old and new paths and the hunk's old and new
+    # ranges. It is the same each time the same diff is computed, so clients can use it to attach data (such
+    # as comments) to the hunk.
+    anchor: String!
 }
 
 # A hunk range in one side (old/new) of a diff.
@@ -2441,6 +2483,44 @@ type PageInfo {
     hasNextPage: Boolean!
 }
 
+# The Git LFS metadata of a file.
+type LFS {
+    # The SHA-256 hash of the LFS object.
+    oid: String!
+    # The size of the LFS object in bytes.
+    byteSize: Float!
+    # Whether the content of the LFS object is available. If not, the file's content is the LFS pointer.
+    available: Boolean!
+}
+
+# A Git blob, identified by its object ID instead of a path.
+type GitBlobObject {
+    # The blob's object ID.
+    oid: GitObjectID!
+    # The size of the blob's content in bytes.
+    byteSize: Int!
+    # The content of the blob.
+    content: String!
+    # Whether or not the content is binary.
+    binary: Boolean!
+}
+
+# A commit that added or removed a file.
+type PathLifecycleEvent {
+    # The commit.
+    commit: GitCommit!
+    # Whether the commit added or removed the file.
+    type: PathLifecycleEventType!
+}
+
+# The kinds of PathLifecycleEvent.
+enum PathLifecycleEventType {
+    # The commit added the file.
+    ADDED
+    # The commit removed the file.
+    REMOVED
+}
+
 # A list of Git commits.
 type GitCommitConnection {
     # A list of Git commits.
@@ -2466,6 +2546,16 @@ type LanguageStatistics {
     totalLines: Int!
 }
 
+# Aggregate statistics about the changes of a Git commit.
+type GitCommitStat {
+    # The number of files changed.
+    filesChanged: Int!
+    # The number of lines inserted.
+    insertions: Int!
+    # The number of lines deleted.
+    deletions: Int!
+}
+
 # A Git commit.
 type GitCommit implements Node {
     # The globally addressable ID for this commit.
@@ -2488,10 +2578,21 @@ type GitCommit implements Node {
     body: String
     # Parent commits of this commit.
     parents: [GitCommit!]!
+    # The number of parent commits of this commit (0 for a root commit).
+    parentCount: Int!
+    # Whether this commit is a merge commit, i.e., has more than one parent.
+    isMerge: Boolean!
+    # The number of files changed and lines inserted and deleted by this commit, compared to its first
+    # parent (for merge commits, the branch that was merged into) or to the empty tree for a root commit.
+    stat: GitCommitStat!
     # The URL to this commit (using the input revision specifier, which may not be immutable).
     url: String!
     # The canonical URL to this commit (using an immutable revision specifier).
     canonicalURL: String!
+    # This commit pinned to its full commit SHA instead of the input revision specifier (such as a
+    # branch name), so that the URLs of the commit and of its trees and blobs are permalinks. If the
+    # input revision specifier already is the full commit SHA, this is the same commit.
+    permalink: GitCommit!
     # The URLs to this commit on its repository's external services.
     externalURLs: [ExternalLink!]!
     # The Git tree in this commit at the given path.
@@ -2503,13 +2604,65 @@ type GitCommit implements Node {
         #
         # DEPRECATED: Use the "recursive" parameter on GitTree's fields instead.
         recursive: Boolean = false
+        # A glob pattern that the paths of the tree's entries, relative to the tree, must match. "*" and "?"
+        # don't match "/" and "**/" matches zero or more directories (e.g., "*.go" or "**/*_test.go").
+        pattern: String
     ): GitTree
+    # The unified diff text between base and this commit. Diffs larger than the gitMaxRawDiffBytes site
+    # configuration value are truncated after the last complete line, followed by a line starting with "[diff
+    # truncated".
+    rawDiff(
+        # The Git revspec to compare against. The default is the first parent, or the empty tree for a root
+        # commit.
+        base: String
+    ): String!
+    # The paths of the files that were added, deleted or modified between base and this commit. Both
+    # paths of a renamed file are included. This is cheaper than fileDiffs, since no hunks are computed.
+    changedPaths(
+        # The Git revspec to compare against. The default is the first parent, or the empty tree for a root
+        # commit.
+        base: String
+    ): [String!]!
+    # Whether this commit adds merge conflict markers (such as "<<<<<<< HEAD") to any text file,
+    # compared to its first parent.
+    hasConflictMarkers: Boolean!
+    # The Git tree or blob at the given path in a parent of this commit (e.g., for the "before" side of the
+    # commit's diff), or null if the path does not exist in the parent.
+    treeAtParent(
+        # The path of the tree or blob.
+        path: String = ""
+        # The index of the parent among this commit's parents. The default is the first parent, which for
+        # a merge commit is the branch that was merged into.
+        parentIndex: Int = 0
+    ): TreeEntry
     # The Git blob in this commit at the given path.
     blob(path: String!): GitBlob
     # The file at the given path for this commit.
     #
     # See "File" documentation for the difference between this field and the "blob" field.
     file(path: String!): File2
+    # The size in bytes of the file at the given path in this commit. It is read from the Git tree,
+    # without fetching the file's contents.
+    blobSize(path: String!): Int!
+    # The information needed to download the raw contents of the file at the given path in this
+    # commit, or null if there is no such path.
+    downloadInfo(path: String!): DownloadInfo
+    # The URL to the file at the given path in this commit, pinned to the full commit SHA. If
+    # startLine is given, the URL has a fragment (such as "#L3-L7") that highlights the lines.
+    filePermalink(
+        # The path of the file. It is not checked for existence.
+        path: String!
+        # The first line to highlight (1-based).
+        startLine: Int
+        # The last line to highlight (1-based, inclusive). The default is startLine.
+        endLine: Int
+    ): String!
+    # The files at the given paths for this commit. Duplicate paths are only returned once. Paths
+    # that can't be resolved are reported in their result instead of failing the whole request.
+    files(paths: [String!]!): [GitCommitFileResult!]!
+    # Whether the given path is ignored by the .gitignore files in the tree at this commit. The path
+    # does not need to exist.
+    isIgnored(path: String!): Boolean!
     # Lists the programming languages present in the tree at this commit.
     languages: [String!]!
     # List statistics for each language present in the repository.
@@ -2525,8 +2678,73 @@ type GitCommit implements Node {
         # Return commits more recent than the specified date.
         after: String
     ): GitCommitConnection!
+    # The log of commits consisting of this commit and its ancestors that affect the file at the
+    # given path, following the file's history across renames.
+    history(
+        # The path of the file.
+        path: String!
+        # Returns the first n commits from the list.
+        first: Int
+        # Opaque pagination cursor: returns the commits following the pageInfo.endCursor of a
+        # previous page.
+        after: String
+    ): GitCommitConnection!
+    # The name of this commit relative to the nearest annotated tag it is reachable from, like git
+    # describe: the tag name if this commit is tagged (e.g. "v1.2.0"), otherwise the tag name
+    # followed by the number of commits since the tag and the abbreviated commit ID (e.g.
+    # "v1.2.0-5-gabcdef1"). If no annotated tag is reachable, this is the abbreviated commit ID.
+    describe: String!
+    # The text of the Git note attached to this commit, as git notes show prints it, or the empty
+    # string if there is none.
+    notes(
+        # The notes ref to read the note from. The default is "refs/notes/commits". Like with git
+        # notes --ref, a ref not starting with "refs/notes/" is looked up in "refs/notes/".
+        ref: String
+    ): String!
+    # Whether the file or directory at the given path was added, modified, deleted or had its mode changed by this
+    # commit, compared to its first parent. For a root commit, this is true for every path that exists.
+    changedInCommit(path: String!): Boolean!
+    # The commits that added or removed the file at the given path, oldest first. Only ancestors of this commit
+    # (including itself) that are not ancestors of the since revision are considered, or all of them if since is
+    # not given. A file that was removed and added again has an event for each addition and removal. Renames are
+    # reported as the removal of the old and the addition of the new path, and merge commits are not considered.
+    pathLifecycle(path: String!, since: String): [PathLifecycleEvent!]!
+    # The blob with the given object ID (a 40-character SHA) in this commit's repository, regardless of the
+    # paths it is stored at, or null if there is no such blob.
+    blobByOID(oid: String!): GitBlobObject
+    # Whether this commit exists in the repository. A commit ID that does not resolve to a commit is reported as
+    # false instead of an error.
+    exists: Boolean!
+    # The diff of a single file between base and this commit, or null if the file was not changed. The
+    # diff is computed against the merge base, as in repository comparisons. For a renamed or copied
+    # file, the path may be either its old or its new name.
+    fileDiff(
+        # A Git revspec of the commit to compare against.
+        base: String!
+        # The path of the file.
+        path: String!
+    ): FileDiff
+    # The authors of the commits reachable from this commit, sorted by their number of commits in
+    # descending order.
+    contributors(
+        # Only count commits that touch this path (a file or directory). If omitted, all commits
+        # are counted.
+        path: String
+        # Returns the first n contributors from the list.
+        first: Int
+    ): RepositoryContributorConnection!
     # Returns the number of commits that this commit is behind and ahead of revspec.
     behindAhead(revspec: String!): BehindAheadCounts!
+    # Whether this commit is reachable from the head of the repository's default branch.
+    onDefaultBranch: Boolean!
+    # The most recent commit, at or before this commit, that modified the given path. Null if the path has no
+    # history at this commit.
+    lastCommit(path: String!): GitCommit
+    # The cryptographic (e.g., GPG) signature of this commit and the result of verifying it. Unsigned commits
+    # have a signature whose signed field is false.
+    signature: GitCommitSignature!
+    # The branches and tags whose tips are this commit, sorted by name.
+    refs: [GitRef!]!
     # Symbols defined as of this commit. (All symbols, not just symbols that were newly defined in this commit.)
     symbols(
         # Returns the first n symbols from the list.
@@ -2537,6 +2755,33 @@ type GitCommit implements Node {
         # file paths returned in the list.
         includePatterns: [String!]
     ): SymbolConnection!
+    # Searches the content of the files as of this commit.
+    searchContent(
+        # The pattern to search for.
+        pattern: String!
+        # Whether the pattern is a regular expression (otherwise, it is matched literally).
+        isRegExp: Boolean = false
+        # The maximum number of files to return (at most 500).
+        maxResults: Int = 30
+    ): CommitContentSearchResults!
+}
+
+# The results of searching the content of the files as of a commit.
+type CommitContentSearchResults {
+    # The files with matches, and their matching lines.
+    results: [FileMatch!]!
+    # Whether there were more matching files than the requested maximum number of results.
+    limitHit: Boolean!
+}
+
+# The information needed to download the raw contents of a file.
+type DownloadInfo {
+    # The MIME type of the file, detected from its extension and contents.
+    contentType: String!
+    # The size of the file in bytes.
+    byteSize: Int!
+    # The URL to download the raw contents of the file from.
+    url: String!
 }
 
 # A set of Git behind/ahead counts for one commit relative to another.
@@ -2547,6 +2792,18 @@ type BehindAheadCounts {
     ahead: Int!
 }
 
+# The cryptographic signature of a Git commit and the result of verifying it.
+type GitCommitSignature {
+    # Whether the commit is signed.
+    signed: Boolean!
+    # Whether the signature is good and was made by a trusted key.
+    verified: Boolean!
+    # The name of the signer, if available.
+    signer: String
+    # The ID of the key used to sign the commit, if available.
+    key: String
+}
+
 # A signature.
 type Signature {
     # The person.
@@ -2587,12 +2844,22 @@ interface TreeEntry {
     name: String!
     # Whether this tree entry is a directory.
     isDirectory: Boolean!
+    # The Git file mode of this tree entry: "040000" (directory), "100644" (regular file), "100755"
+    # (executable file), "120000" (symbolic link) or "160000" (submodule).
+    mode: String!
+    # Whether this tree entry is an executable file.
+    isExecutable: Boolean!
+    # Whether this tree entry is a symbolic link.
+    isSymlink: Boolean!
     # The URL to this tree entry (using the input revision specifier, which may not be immutable).
     url: String!
     # The canonical URL to this tree entry (using an immutable revision specifier).
     canonicalURL: String!
     # The URLs to this tree entry on external services.
     externalURLs: [ExternalLink!]!
+    # The most recent commit at or before this tree entry's commit that modified this tree entry (or,
+    # for a directory, any file below it).
+    lastCommit: GitCommit!
     # Symbols defined in this file or directory.
     symbols(
         # Returns the first n symbols from the list.
@@ -2622,6 +2889,12 @@ type GitTree implements TreeEntry {
     # True because this is a directory. (The value differs for other TreeEntry interface implementations, such as
     # File.)
     isDirectory: Boolean!
+    # The Git file mode of this tree: "040000", or "160000" if it is a submodule.
+    mode: String!
+    # False because this is a directory.
+    isExecutable: Boolean!
+    # False because this is a directory.
+    isSymlink: Boolean!
     # The Git commit containing this tree.
     commit: GitCommit!
     # The repository containing this tree.
@@ -2632,14 +2905,23 @@ type GitTree implements TreeEntry {
     canonicalURL: String!
     # The URLs to this tree on external services.
     externalURLs: [ExternalLink!]!
+    # The most recent commit at or before this tree's commit that modified any file below this tree.
+    lastCommit: GitCommit!
     # Submodule metadata if this tree points to a submodule
     submodule: Submodule
+    # The number of immediate children (files and directories) of this tree.
+    childCount: Int!
+    # The README file of this tree, if any. Markdown READMEs are preferred over others.
+    readme: GitBlob
     # A list of directories in this tree.
     directories(
         # Returns the first n files in the tree.
         first: Int
         # Recurse into sub-trees.
         recursive: Boolean = false
+        # Compute the lastCommit field of all returned entries at once. This is much faster than
+        # computing it for each entry separately.
+        withLastCommit: Boolean = false
     ): [GitTree!]!
     # A list of files in this tree.
     files(
@@ -2647,6 +2929,9 @@ type GitTree implements TreeEntry {
         first: Int
         # Recurse into sub-trees.
         recursive: Boolean = false
+        # Compute the lastCommit field of all returned entries at once. This is much faster than
+        # computing it for each entry separately.
+        withLastCommit: Boolean = false
     ): [File!]!
     # A list of entries in this tree.
     entries(
@@ -2658,6 +2943,9 @@ type GitTree implements TreeEntry {
         # every directory that is a single child, and any directories or files that are
         # nested in a single child.
         recursiveSingleChild: Boolean = false
+        # Compute the lastCommit field of all returned entries at once. This is much faster than
+        # computing it for each entry separately.
+        withLastCommit: Boolean = false
     ): [TreeEntry!]!
     # Symbols defined in this tree.
     symbols(
@@ -2692,9 +2980,29 @@ interface File2 {
     # False because this is a file, not a directory.
     isDirectory: Boolean!
     # The content of this file.
-    content: String!
+    content(
+        # Whether to transcode the content to UTF-8 from its detected encoding (see the encoding
+        # field). Binary content is never transcoded.
+        transcodeToUTF8: Boolean = false
+    ): String!
+    # A byte range of the raw content of this file, encoded in base64. The range is clamped to the
+    # end of the file. For a Git LFS pointer file, it is a range of the pointer, not of the LFS object.
+    contentBytes(
+        # The offset of the first byte to return. Must not be negative.
+        offset: Int!
+        # The maximum number of bytes to return (at most 10 MiB).
+        length: Int!
+    ): String!
     # Whether or not it is binary.
     binary: Boolean!
+    # Whether this file is vendored (copied from a third party), based on its path.
+    isVendored: Boolean!
+    # Whether this file was generated by a tool, based on its path and on markers (such as "Code
+    # generated ... DO NOT EDIT.") at the beginning of its content.
+    isGenerated: Boolean!
+    # The detected character encoding of the content: "utf-8", "utf-16le", "utf-16be",
+    # "iso-8859-1", or "binary".
+    encoding: String!
     # The file rendered as rich HTML, or an empty string if it is not a supported
     # rich file type.
     #
@@ -2732,6 +3040,16 @@ type File {
     repository: Repository!
 }
 
+# The result of resolving a single path in GitCommit.files.
+type GitCommitFileResult {
+    # The requested path.
+    path: String!
+    # The file at the path, or null if it could not be resolved.
+    file: File2
+    # The reason the file could not be resolved, or null if it was resolved.
+    error: String
+}
+
 # A Git blob in a repository.
 type GitBlob implements TreeEntry & File2 {
     # The full path (relative to the repository root) of this blob.
@@ -2740,10 +3058,41 @@ type GitBlob implements TreeEntry & File2 {
     name: String!
     # False because this is a blob (file), not a directory.
     isDirectory: Boolean!
+    # The Git file mode of this blob: "100644" (regular file), "100755" (executable file) or
+    # "120000" (symbolic link).
+    mode: String!
+    # Whether this blob is an executable file.
+    isExecutable: Boolean!
+    # Whether this blob is a symbolic link.
+    isSymlink: Boolean!
     # The content of this blob.
-    content: String!
+    content(
+        # Whether to transcode the content to UTF-8 from its detected encoding (see the encoding
+        # field). Binary content is never transcoded.
+        transcodeToUTF8: Boolean = false
+    ): String!
+    # A byte range of the raw content of this file, encoded in base64. The range is clamped to the
+    # end of the file. For a Git LFS pointer file, it is a range of the pointer, not of the LFS object.
+    contentBytes(
+        # The offset of the first byte to return. Must not be negative.
+        offset: Int!
+        # The maximum number of bytes to return (at most 10 MiB).
+        length: Int!
+    ): String!
+    # The Git LFS metadata of this blob if it is a Git LFS pointer file, and null otherwise. The content
+    # of an LFS pointer file is the content of the LFS object if it is available and at most 50 MiB
+    # large, and the pointer otherwise.
+    lfs: LFS
     # Whether or not it is binary.
     binary: Boolean!
+    # Whether this file is vendored (copied from a third party), based on its path.
+    isVendored: Boolean!
+    # Whether this file was generated by a tool, based on its path and on markers (such as "Code
+    # generated ... DO NOT EDIT.") at the beginning of its content.
+    isGenerated: Boolean!
+    # The detected character encoding of the content: "utf-8", "utf-16le", "utf-16be",
+    # "iso-8859-1", or "binary".
+    encoding: String!
     # The blob contents rendered as rich HTML, or an empty string if it is not a supported
     # rich file type.
     #
@@ -2759,6 +3108,8 @@ type GitBlob implements TreeEntry & File2 {
     canonicalURL: String!
     # The URLs to this blob on its repository's external services.
     externalURLs: [ExternalLink!]!
+    # The most recent commit at or before this blob's commit that modified this blob's path.
+    lastCommit: GitCommit!
     # Blame the blob.
     blame(startLine: Int!, endLine: Int!): [Hunk!]!
     # Highlight the blob contents.
@@ -3,6 +3,8 @@ package graphqlbackend
 import (
 	"context"
 	"errors"
+	"path"
+	"regexp"
 	"regexp/syntax"
 	"strings"
 	"time"
@@ -23,14 +25,36 @@ type symbolsArgs struct {
 	IncludePatterns *[]string
 }
 
+// Symbols returns the symbols defined in the file or directory of the tree entry. Files in languages
+// that ctags does not support have no symbols.
 func (r *GitTreeEntryResolver) Symbols(ctx context.Context, args *symbolsArgs) (*symbolConnectionResolver, error) {
-	symbols, err := computeSymbols(ctx, r.commit, args.Query, args.First, args.IncludePatterns)
+	symbols, err := computeSymbols(ctx, r.commit, args.Query, args.First, r.symbolsIncludePatterns(args.IncludePatterns))
 	if err != nil && len(symbols) == 0 {
 		return nil, err
 	}
 	return &symbolConnectionResolver{symbols: symbols, first: args.First}, nil
 }
 
+// symbolsIncludePatterns returns includePatterns with an additional pattern that limits symbols to
+// those defined in the file or directory of the tree entry.
+func (r *GitTreeEntryResolver) symbolsIncludePatterns(includePatterns *[]string) *[]string {
+	p := path.Clean(r.Path())
+	if p == "." {
+		return includePatterns
+	}
+
+	var patterns []string
+	if includePatterns != nil {
+		patterns = append(patterns, *includePatterns...)
+	}
+	if r.IsDirectory() {
+		patterns = append(patterns, "^"+regexp.QuoteMeta(p)+"/")
+	} else {
+		patterns = append(patterns, "^"+regexp.QuoteMeta(p)+"$")
+	}
+	return &patterns
+}
+
 func (r *GitCommitResolver) Symbols(ctx context.Context, args *symbolsArgs) (*symbolConnectionResolver, error) {
 	symbols, err := computeSymbols(ctx, r, args.Query, args.First, args.IncludePatterns)
 	if err != nil && len(symbols) == 0 {
@@ -0,0 +1,85 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestGitTreeEntrySymbols(t *testing.T) {
+	// The mock behaves like the symbols service, which only knows about symbols in files of
+	// languages that ctags supports.
+	repoSymbols := []protocol.Symbol{
+		{Name: "NewRouter", Path: "mux.go", Line: 24, Kind: "func", Language: "Go"},
+		{Name: "Router", Path: "mux.go", Line: 46, Kind: "type", Language: "Go"},
+		{Name: "Route", Path: "route.go", Line: 17, Kind: "type", Language: "Go"},
+	}
+	var includePatterns []string
+	backend.Mocks.Symbols.ListTags = func(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+		includePatterns = args.IncludePatterns
+		var symbols []protocol.Symbol
+	nextSymbol:
+		for _, symbol := range repoSymbols {
+			for _, p := range args.IncludePatterns {
+				if !regexp.MustCompile(p).MatchString(symbol.Path) {
+					continue nextSymbol
+				}
+			}
+			symbols = append(symbols, symbol)
+		}
+		return symbols, nil
+	}
+	defer func() { backend.Mocks = backend.MockServices{} }()
+
+	commit := &GitCommitResolver{repo: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}}, oid: exampleCommitSHA1}
+	symbolsIn := func(path string, isDir bool) []*symbolResolver {
+		t.Helper()
+		entry := &GitTreeEntryResolver{commit: commit, stat: CreateFileInfo(path, isDir)}
+		conn, err := entry.Symbols(context.Background(), &symbolsArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := conn.Nodes(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nodes
+	}
+
+	t.Run("file with symbols", func(t *testing.T) {
+		symbols := symbolsIn("mux.go", false)
+		if want := []string{`^mux\.go$`}; !reflect.DeepEqual(includePatterns, want) {
+			t.Errorf("got include patterns %q, want %q", includePatterns, want)
+		}
+		if len(symbols) != 2 {
+			t.Fatalf("got %d symbols, want 2", len(symbols))
+		}
+		if name, kind := symbols[0].Name(), symbols[0].Kind(); name != "NewRouter" || kind != "FUNCTION" {
+			t.Errorf("got symbol %s %s, want NewRouter FUNCTION", name, kind)
+		}
+		if line := symbols[1].symbol.Line; line != 46 {
+			t.Errorf("got line %d, want 46", line)
+		}
+	})
+
+	t.Run("file of an unsupported language", func(t *testing.T) {
+		if symbols := symbolsIn("README.txt", false); len(symbols) != 0 {
+			t.Errorf("got %d symbols, want none", len(symbols))
+		}
+	})
+
+	t.Run("root directory", func(t *testing.T) {
+		if symbols := symbolsIn("", true); len(symbols) != 3 {
+			t.Errorf("got %d symbols, want 3", len(symbols))
+		}
+		if len(includePatterns) != 0 {
+			t.Errorf("got include patterns %q, want none", includePatterns)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
@@ -24,7 +25,11 @@ func serveGraphQL(schema *graphql.Schema) func(w http.ResponseWriter, r *http.Re
 		if r.URL.RawQuery != "" {
 			requestName = r.URL.RawQuery
 		}
-		r = r.WithContext(trace.WithGraphQLRequestName(r.Context(), requestName))
+		ctx := trace.WithGraphQLRequestName(r.Context(), requestName)
+		for _, f := range graphqlbackend.RequestContextFuncs {
+			ctx = f(ctx)
+		}
+		r = r.WithContext(ctx)
 
 		relayHandler.ServeHTTP(w, r)
 		return nil
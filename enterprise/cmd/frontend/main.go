@@ -115,6 +115,7 @@ func initLicensing() {
 
 func initResolvers() {
 	graphqlbackend.NewCampaignsResolver = campaignsResolvers.NewResolver
+	graphqlbackend.RequestContextFuncs = append(graphqlbackend.RequestContextFuncs, campaignsResolvers.WithCampaignLoader)
 	graphqlbackend.NewCodeIntelResolver = codeIntelResolvers.NewResolver
 	graphqlbackend.NewAuthzResolver = func() graphqlbackend.AuthzResolver {
 		return authzResolvers.NewResolver(dbconn.Global, func() time.Time {
@@ -0,0 +1,113 @@
+package campaigns
+
+import "github.com/sourcegraph/sourcegraph/internal/errcode"
+
+// The errors returned by the Store and the Service fall into a few
+// categories, so that callers such as the GraphQL resolvers can map them to
+// user-facing errors without comparing against every error value:
+//
+//   - not found (IsNotFound), e.g. ErrNoResults
+//   - conflict (IsConflict), e.g. ErrUpdateProcessingCampaign
+//   - invalid input (IsInvalidInput), e.g. ErrCampaignNameBlank
+//   - name exists (IsNameExists), e.g. *CampaignNameCollisionError
+//   - permission denied (IsPermissionDenied), e.g. the access errors of the
+//     GraphQL resolvers
+//
+// As with the errors recognized by package errcode, an error belongs to a
+// category if it or one of its causes implements the category's method and
+// that method returns true. Not found and invalid input use the errcode
+// methods (NotFound and BadRequest), so errcode recognizes them too.
+
+type errorKind int
+
+const (
+	kindNotFound errorKind = iota + 1
+	kindConflict
+	kindInvalidInput
+)
+
+// campaignError is an error in a single category. Sentinel errors are
+// pointers to distinct campaignErrors, so that they can be compared with ==
+// even if they have the same message.
+type campaignError struct {
+	msg  string
+	kind errorKind
+}
+
+func (e *campaignError) Error() string { return e.msg }
+
+func (e *campaignError) NotFound() bool   { return e.kind == kindNotFound }
+func (e *campaignError) Conflict() bool   { return e.kind == kindConflict }
+func (e *campaignError) BadRequest() bool { return e.kind == kindInvalidInput }
+
+func notFoundError(msg string) error     { return &campaignError{msg: msg, kind: kindNotFound} }
+func conflictError(msg string) error     { return &campaignError{msg: msg, kind: kindConflict} }
+func invalidInputError(msg string) error { return &campaignError{msg: msg, kind: kindInvalidInput} }
+
+// IsNotFound reports whether err indicates that a Campaign (or another
+// record) does not exist.
+func IsNotFound(err error) bool {
+	return errcode.IsNotFound(err)
+}
+
+// IsConflict reports whether err indicates that an operation conflicts with
+// the current state of a Campaign or with other Campaigns.
+func IsConflict(err error) bool {
+	type conflicter interface {
+		Conflict() bool
+	}
+	return isErrorPredicate(err, func(err error) bool {
+		e, ok := err.(conflicter)
+		return ok && e.Conflict()
+	})
+}
+
+// IsInvalidInput reports whether err indicates that the input of an
+// operation is invalid.
+func IsInvalidInput(err error) bool {
+	return errcode.IsBadRequest(err)
+}
+
+// IsNameExists reports whether err indicates that a Campaign with the same
+// name already exists. Such errors are conflicts, too.
+func IsNameExists(err error) bool {
+	type nameExister interface {
+		NameExists() bool
+	}
+	return isErrorPredicate(err, func(err error) bool {
+		e, ok := err.(nameExister)
+		return ok && e.NameExists()
+	})
+}
+
+// IsPermissionDenied reports whether err indicates that the current user may
+// not perform an operation.
+func IsPermissionDenied(err error) bool {
+	type permissionDenieder interface {
+		PermissionDenied() bool
+	}
+	return isErrorPredicate(err, func(err error) bool {
+		e, ok := err.(permissionDenieder)
+		return ok && e.PermissionDenied()
+	})
+}
+
+// isErrorPredicate returns true if err or one of its causes returns true when
+// passed to p.
+func isErrorPredicate(err error, p func(err error) bool) bool {
+	type causer interface {
+		Cause() error
+	}
+
+	for err != nil {
+		if p(err) {
+			return true
+		}
+		cause, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package campaigns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestErrorPredicates(t *testing.T) {
+	type categories struct {
+		notFound, conflict, invalidInput, nameExists, permissionDenied bool
+	}
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want categories
+	}{
+		{name: "nil", err: nil},
+		{name: "untyped", err: errors.New("boom")},
+		{name: "ErrNoResults", err: ErrNoResults, want: categories{notFound: true}},
+		{name: "wrapped ErrNoResults", err: pkgerrors.Wrap(ErrNoResults, "getting campaign"), want: categories{notFound: true}},
+		{name: "ErrUpdateProcessingCampaign", err: ErrUpdateProcessingCampaign, want: categories{conflict: true}},
+		{name: "ErrDeleteProcessingCampaign", err: ErrDeleteProcessingCampaign, want: categories{conflict: true}},
+		{name: "ErrCampaignIdempotencyKeyExists", err: ErrCampaignIdempotencyKeyExists, want: categories{conflict: true}},
+		{name: "AlreadyExistError", err: AlreadyExistError{ChangesetIDs: []int64{1}}, want: categories{conflict: true}},
+		{name: "ErrCampaignNameBlank", err: ErrCampaignNameBlank, want: categories{invalidInput: true}},
+		{name: "ErrCampaignNamespace", err: ErrCampaignNamespace, want: categories{invalidInput: true}},
+		{name: "ErrNoCampaignTemplate", err: ErrNoCampaignTemplate, want: categories{invalidInput: true}},
+		{name: "CampaignDescriptionTooLongError", err: &CampaignDescriptionTooLongError{Length: 2, Max: 1}, want: categories{invalidInput: true}},
+		{name: "CampaignNameCollisionError", err: &CampaignNameCollisionError{Names: []string{"a"}}, want: categories{conflict: true, nameExists: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := categories{
+				notFound:         IsNotFound(tc.err),
+				conflict:         IsConflict(tc.err),
+				invalidInput:     IsInvalidInput(tc.err),
+				nameExists:       IsNameExists(tc.err),
+				permissionDenied: IsPermissionDenied(tc.err),
+			}
+			if have != tc.want {
+				t.Fatalf("have %+v, want %+v", have, tc.want)
+			}
+		})
+	}
+
+	// Sentinel errors with the same message must still be distinguishable.
+	if ErrCloseProcessingCampaign == ErrDeleteProcessingCampaign {
+		t.Fatal("ErrCloseProcessingCampaign == ErrDeleteProcessingCampaign")
+	}
+}
+
+func TestCampaignWriteError(t *testing.T) {
+	for constraint, want := range map[string]error{
+		"campaigns_name_not_blank":                    ErrCampaignNameBlank,
+		"campaigns_has_1_namespace":                   ErrCampaignNamespace,
+		"campaigns_namespace_user_id_idempotency_key": ErrCampaignIdempotencyKeyExists,
+		"campaigns_namespace_org_id_idempotency_key":  ErrCampaignIdempotencyKeyExists,
+	} {
+		if have := campaignWriteError(&pq.Error{Constraint: constraint}); have != want {
+			t.Errorf("%s: have %v, want %v", constraint, have, want)
+		}
+	}
+
+	other := &pq.Error{Constraint: "campaigns_author_id_fkey"}
+	if have := campaignWriteError(other); have != other {
+		t.Errorf("have %v, want %v", have, other)
+	}
+}
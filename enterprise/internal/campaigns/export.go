@@ -0,0 +1,254 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// CampaignExportVersion is the version of the document format produced by
+// ExportCampaign. It must be incremented whenever the format changes in a way
+// that older versions can't read.
+const CampaignExportVersion = 1
+
+// CampaignExport is the portable representation of a Campaign produced by
+// ExportCampaign. It references repositories by name rather than by ID, so
+// that it can be imported into other Sourcegraph instances.
+type CampaignExport struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Branch        string            `json:"branch,omitempty"`
+	Changesets    []ChangesetExport `json:"changesets"`
+}
+
+// ChangesetExport is the portable reference to a Changeset of an exported
+// Campaign.
+type ChangesetExport struct {
+	Repository          string `json:"repository"`
+	ExternalServiceType string `json:"externalServiceType"`
+	ExternalID          string `json:"externalID"`
+}
+
+// CampaignImportReport describes the parts of an imported Campaign that could
+// not be recreated.
+type CampaignImportReport struct {
+	// MissingChangesets are the references to Changesets whose repository or
+	// Changeset doesn't exist in this instance. They're not linked to the
+	// imported Campaign.
+	MissingChangesets []ChangesetExport
+}
+
+// ExportCampaign serializes the Campaign with the given ID, together with
+// references to its Changesets, into a versioned JSON document.
+func (s *Service) ExportCampaign(ctx context.Context, id int64) (json.RawMessage, error) {
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign")
+	}
+
+	var (
+		changesets []*campaigns.Changeset
+		reposByID  map[api.RepoID]*repos.Repo
+	)
+
+	if len(campaign.ChangesetIDs) > 0 {
+		changesets, _, err = s.store.ListChangesets(ctx, ListChangesetsOpts{
+			IDs:   campaign.ChangesetIDs,
+			Limit: -1,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing changesets")
+		}
+
+		repoIDs := make([]api.RepoID, len(changesets))
+		for i, c := range changesets {
+			repoIDs[i] = c.RepoID
+		}
+
+		reposStore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+		rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: repoIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing repos")
+		}
+
+		reposByID = make(map[api.RepoID]*repos.Repo, len(rs))
+		for _, r := range rs {
+			reposByID[r.ID] = r
+		}
+	}
+
+	return json.Marshal(newCampaignExport(campaign, changesets, reposByID))
+}
+
+// ImportCampaign creates a Campaign in the given namespace from a document
+// produced by ExportCampaign. The Changesets referenced by the document that
+// exist in this instance are linked to the new Campaign; the others are
+// skipped and listed in the returned report.
+func (s *Service) ImportCampaign(ctx context.Context, doc json.RawMessage, namespaceUserID, namespaceOrgID, authorID int32) (campaign *campaigns.Campaign, report *CampaignImportReport, err error) {
+	export, err := parseCampaignExport(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkCampaignDescriptionLength(export.Description); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Done(&err)
+
+	changesets, report, err := findExportedChangesets(ctx, tx, export.Changesets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	campaign = &campaigns.Campaign{
+		Name:            export.Name,
+		Description:     export.Description,
+		Branch:          export.Branch,
+		AuthorID:        authorID,
+		NamespaceUserID: namespaceUserID,
+		NamespaceOrgID:  namespaceOrgID,
+		ChangesetIDs:    make([]int64, 0, len(changesets)),
+	}
+	for _, c := range changesets {
+		campaign.ChangesetIDs = append(campaign.ChangesetIDs, c.ID)
+	}
+
+	// Campaigns that create their own Changesets can't be exported, so there
+	// is no CampaignPlan to create ChangesetJobs from.
+	if err = s.createCampaignWithStore(ctx, tx, campaign, true); err != nil {
+		return nil, nil, errors.Wrap(err, "creating campaign")
+	}
+
+	if len(changesets) > 0 {
+		for _, c := range changesets {
+			c.CampaignIDs = append(c.CampaignIDs, campaign.ID)
+		}
+		if err = tx.UpdateChangesets(ctx, changesets...); err != nil {
+			return nil, nil, errors.Wrap(err, "linking changesets")
+		}
+	}
+
+	return campaign, report, nil
+}
+
+// findExportedChangesets returns the Changesets referenced by refs that exist
+// in the store, and a report of those that don't.
+func findExportedChangesets(ctx context.Context, store *Store, refs []ChangesetExport) ([]*campaigns.Changeset, *CampaignImportReport, error) {
+	report := &CampaignImportReport{}
+	if len(refs) == 0 {
+		return nil, report, nil
+	}
+
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Repository)
+	}
+
+	reposStore := repos.NewDBStore(store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{Names: names})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing repos")
+	}
+
+	reposByName := make(map[string]*repos.Repo, len(rs))
+	for _, r := range rs {
+		reposByName[r.Name] = r
+	}
+
+	var (
+		changesets []*campaigns.Changeset
+		seen       = map[int64]bool{}
+	)
+	for _, ref := range refs {
+		r, ok := reposByName[ref.Repository]
+		if !ok {
+			report.MissingChangesets = append(report.MissingChangesets, ref)
+			continue
+		}
+
+		c, err := store.GetChangeset(ctx, GetChangesetOpts{
+			RepoID:              r.ID,
+			ExternalServiceType: ref.ExternalServiceType,
+			ExternalID:          ref.ExternalID,
+		})
+		if err == ErrNoResults {
+			report.MissingChangesets = append(report.MissingChangesets, ref)
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "getting changeset")
+		}
+
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			changesets = append(changesets, c)
+		}
+	}
+
+	return changesets, report, nil
+}
+
+func newCampaignExport(c *campaigns.Campaign, cs []*campaigns.Changeset, reposByID map[api.RepoID]*repos.Repo) *CampaignExport {
+	export := &CampaignExport{
+		SchemaVersion: CampaignExportVersion,
+		Name:          c.Name,
+		Description:   c.Description,
+		Branch:        c.Branch,
+		Changesets:    make([]ChangesetExport, 0, len(cs)),
+	}
+
+	for _, ch := range cs {
+		// Changesets in repositories that don't exist anymore can't be
+		// referenced by name, so they're left out.
+		r, ok := reposByID[ch.RepoID]
+		if !ok {
+			continue
+		}
+
+		export.Changesets = append(export.Changesets, ChangesetExport{
+			Repository:          r.Name,
+			ExternalServiceType: ch.ExternalServiceType,
+			ExternalID:          ch.ExternalID,
+		})
+	}
+
+	sort.Slice(export.Changesets, func(i, j int) bool {
+		a, b := export.Changesets[i], export.Changesets[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return a.ExternalID < b.ExternalID
+	})
+
+	return export
+}
+
+func parseCampaignExport(doc json.RawMessage) (*CampaignExport, error) {
+	var export CampaignExport
+	if err := json.Unmarshal(doc, &export); err != nil {
+		return nil, errors.Wrap(err, "parsing campaign export")
+	}
+
+	if export.SchemaVersion < 1 || export.SchemaVersion > CampaignExportVersion {
+		return nil, fmt.Errorf("unsupported campaign export schema version %d", export.SchemaVersion)
+	}
+
+	if export.Name == "" {
+		return nil, ErrCampaignNameBlank
+	}
+
+	return &export, nil
+}
@@ -0,0 +1,106 @@
+package campaigns
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestCampaignExport(t *testing.T) {
+	campaign := &campaigns.Campaign{
+		ID:           1,
+		Name:         "Upgrade ES-Lint",
+		Description:  "All the Javascripts are belong to us",
+		Branch:       "upgrade-es-lint",
+		ChangesetIDs: []int64{1, 2, 3},
+	}
+
+	changesets := []*campaigns.Changeset{
+		{ID: 1, RepoID: 2, ExternalServiceType: github.ServiceType, ExternalID: "12"},
+		{ID: 2, RepoID: 1, ExternalServiceType: github.ServiceType, ExternalID: "34"},
+		{ID: 3, RepoID: 3, ExternalServiceType: github.ServiceType, ExternalID: "56"},
+	}
+
+	reposByID := map[api.RepoID]*repos.Repo{
+		1: {ID: 1, Name: "github.com/sourcegraph/a"},
+		2: {ID: 2, Name: "github.com/sourcegraph/b"},
+	}
+
+	want := &CampaignExport{
+		SchemaVersion: CampaignExportVersion,
+		Name:          campaign.Name,
+		Description:   campaign.Description,
+		Branch:        campaign.Branch,
+		Changesets: []ChangesetExport{
+			{Repository: "github.com/sourcegraph/a", ExternalServiceType: github.ServiceType, ExternalID: "34"},
+			{Repository: "github.com/sourcegraph/b", ExternalServiceType: github.ServiceType, ExternalID: "12"},
+		},
+	}
+
+	export := newCampaignExport(campaign, changesets, reposByID)
+	if diff := cmp.Diff(export, want); diff != "" {
+		t.Fatal(diff)
+	}
+
+	doc, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := parseCampaignExport(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(have, want); diff != "" {
+		t.Fatalf("round trip: %s", diff)
+	}
+}
+
+func TestParseCampaignExport(t *testing.T) {
+	tests := map[string]struct {
+		doc     string
+		wantErr string
+	}{
+		"valid": {
+			doc: `{"schemaVersion": 1, "name": "foo", "changesets": []}`,
+		},
+		"future version": {
+			doc:     `{"schemaVersion": 2, "name": "foo", "changesets": []}`,
+			wantErr: "unsupported campaign export schema version 2",
+		},
+		"missing version": {
+			doc:     `{"name": "foo"}`,
+			wantErr: "unsupported campaign export schema version 0",
+		},
+		"blank name": {
+			doc:     `{"schemaVersion": 1}`,
+			wantErr: ErrCampaignNameBlank.Error(),
+		},
+		"invalid": {
+			doc:     `[]`,
+			wantErr: "parsing campaign export: json: cannot unmarshal array into Go value of type campaigns.CampaignExport",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseCampaignExport(json.RawMessage(tc.doc))
+			if have, want := errString(err), tc.wantErr; have != want {
+				t.Fatalf("have error %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
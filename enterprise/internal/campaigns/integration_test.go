@@ -24,5 +24,6 @@ func TestIntegration(t *testing.T) {
 
 	// The following tests need to be separate because testStore above wraps everything in a global transaction
 	t.Run("StoreLocking", testStoreLocking(db))
+	t.Run("StoreCampaignWriteErrors", testStoreCampaignWriteErrors(db))
 	t.Run("ProcessCampaignJob", testProcessCampaignJob(db))
 }
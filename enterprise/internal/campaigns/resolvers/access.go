@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// campaignAccessDeniedError is returned by checkCampaignAccess if the current user may not access
+// a campaign.
+type campaignAccessDeniedError struct {
+	CampaignID int64
+}
+
+func (e *campaignAccessDeniedError) Error() string {
+	return fmt.Sprintf("access to campaign %d denied: must be a site admin or have access to the campaign's namespace", e.CampaignID)
+}
+
+// PermissionDenied makes ee.IsPermissionDenied recognize the error.
+func (e *campaignAccessDeniedError) PermissionDenied() bool { return true }
+
+// checkCampaignAccess returns an error if the current user is NEITHER (1) a site admin NOR (2) the
+// user whose namespace the campaign is in NOR (3) a member of the organization whose namespace
+// the campaign is in. It returns backend.ErrNotAuthenticated for anonymous users and a
+// *campaignAccessDeniedError for other users without access.
+func checkCampaignAccess(ctx context.Context, c *campaigns.Campaign) error {
+	var err error
+	if c.NamespaceOrgID != 0 {
+		err = backend.CheckOrgAccess(ctx, c.NamespaceOrgID)
+	} else {
+		err = backend.CheckSiteAdminOrSameUser(ctx, c.NamespaceUserID)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if !actor.FromContext(ctx).IsAuthenticated() || err == backend.ErrNotAuthenticated {
+		return backend.ErrNotAuthenticated
+	}
+	if _, ok := err.(*backend.InsufficientAuthorizationError); ok || err == backend.ErrNotAnOrgMember {
+		return &campaignAccessDeniedError{CampaignID: c.ID}
+	}
+	return err
+}
+
+// campaignAccessUserID returns the ID of the user to whose namespaces campaign listings must be
+// limited, or 0 if the current user is a site admin and may see all campaigns. It applies the
+// rules of checkCampaignAccess to listings, which are filtered in the database so that their
+// total count matches their nodes.
+func campaignAccessUserID(ctx context.Context) (int32, error) {
+	err := backend.CheckCurrentUserIsSiteAdmin(ctx)
+	if err == nil {
+		return 0, nil
+	}
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || err == backend.ErrNotAuthenticated {
+		return 0, backend.ErrNotAuthenticated
+	}
+	if err != backend.ErrMustBeSiteAdmin {
+		return 0, err
+	}
+	return a.UID, nil
+}
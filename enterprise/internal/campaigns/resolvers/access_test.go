@@ -0,0 +1,127 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestCheckCampaignAccess(t *testing.T) {
+	const (
+		ownerID  = 1
+		memberID = 2
+		otherID  = 3
+		adminID  = 4
+		orgID    = 10
+	)
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return nil, db.ErrNoCurrentUser
+		}
+		return &types.User{ID: a.UID, SiteAdmin: a.UID == adminID}, nil
+	}
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "owner"}, nil
+	}
+	db.Mocks.OrgMembers.GetByOrgIDAndUserID = func(ctx context.Context, org, user int32) (*types.OrgMembership, error) {
+		if org == orgID && user == memberID {
+			return &types.OrgMembership{OrgID: org, UserID: user}, nil
+		}
+		return nil, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	userCampaign := &campaigns.Campaign{ID: 1, NamespaceUserID: ownerID}
+	orgCampaign := &campaigns.Campaign{ID: 2, NamespaceOrgID: orgID}
+
+	tests := []struct {
+		name     string
+		userID   int32
+		campaign *campaigns.Campaign
+		wantErr  error
+	}{
+		{name: "owner", userID: ownerID, campaign: userCampaign},
+		{name: "other user in user namespace", userID: otherID, campaign: userCampaign, wantErr: &campaignAccessDeniedError{CampaignID: 1}},
+		{name: "org member", userID: memberID, campaign: orgCampaign},
+		{name: "org non-member", userID: otherID, campaign: orgCampaign, wantErr: &campaignAccessDeniedError{CampaignID: 2}},
+		{name: "site admin in user namespace", userID: adminID, campaign: userCampaign},
+		{name: "site admin in org namespace", userID: adminID, campaign: orgCampaign},
+		{name: "anonymous", campaign: orgCampaign, wantErr: backend.ErrNotAuthenticated},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.userID != 0 {
+				ctx = actor.WithActor(ctx, actor.FromUser(tc.userID))
+			}
+
+			err := checkCampaignAccess(ctx, tc.campaign)
+			if have, want := errString(err), errString(tc.wantErr); have != want {
+				t.Fatalf("have error %q, want %q", have, want)
+			}
+			if _, ok := tc.wantErr.(*campaignAccessDeniedError); ok {
+				if _, ok := err.(*campaignAccessDeniedError); !ok {
+					t.Fatalf("have error of type %T, want %T", err, tc.wantErr)
+				}
+				if !ee.IsPermissionDenied(err) {
+					t.Fatalf("have IsPermissionDenied(%q) false, want true", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCampaignAccessUserID(t *testing.T) {
+	const userID, adminID = 1, 2
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return nil, db.ErrNoCurrentUser
+		}
+		return &types.User{ID: a.UID, SiteAdmin: a.UID == adminID}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	tests := []struct {
+		name    string
+		userID  int32
+		want    int32
+		wantErr error
+	}{
+		{name: "user", userID: userID, want: userID},
+		{name: "site admin", userID: adminID, want: 0},
+		{name: "anonymous", wantErr: backend.ErrNotAuthenticated},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.userID != 0 {
+				ctx = actor.WithActor(ctx, actor.FromUser(tc.userID))
+			}
+
+			have, err := campaignAccessUserID(ctx)
+			if have, want := errString(err), errString(tc.wantErr); have != want {
+				t.Fatalf("have error %q, want %q", have, want)
+			}
+			if have != tc.want {
+				t.Fatalf("have user ID %d, want %d", have, tc.want)
+			}
+		})
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -374,7 +374,7 @@ func (r *previewFileDiffResolver) NewPath() *string { return diffPathOrNull(r.fi
 func (r *previewFileDiffResolver) Hunks() []*graphqlbackend.DiffHunk {
 	hunks := make([]*graphqlbackend.DiffHunk, len(r.fileDiff.Hunks))
 	for i, hunk := range r.fileDiff.Hunks {
-		hunks[i] = graphqlbackend.NewDiffHunk(hunk)
+		hunks[i] = graphqlbackend.NewDiffHunk(r.fileDiff, hunk)
 	}
 	return hunks
 }
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"sync"
 	"time"
@@ -41,8 +42,7 @@ func (r *campaignsConnectionResolver) Nodes(ctx context.Context) ([]graphqlbacke
 }
 
 func (r *campaignsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
-	opts := ee.CountCampaignsOpts{ChangesetID: r.opts.ChangesetID, State: r.opts.State}
-	count, err := r.store.CountCampaigns(ctx, opts)
+	count, err := r.store.CountCampaigns(ctx, *r.opts.CountOpts())
 	return int32(count), err
 }
 
@@ -51,7 +51,10 @@ func (r *campaignsConnectionResolver) PageInfo(ctx context.Context) (*graphqluti
 	if err != nil {
 		return nil, err
 	}
-	return graphqlutil.HasNextPage(next != 0), nil
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(marshalCampaignCursor(next)), nil
 }
 
 func (r *campaignsConnectionResolver) compute(ctx context.Context) ([]*campaigns.Campaign, int64, error) {
@@ -79,6 +82,22 @@ func unmarshalCampaignID(id graphql.ID) (campaignID int64, err error) {
 	return
 }
 
+const campaignCursorKind = "CampaignCursor"
+
+// marshalCampaignCursor returns the opaque pagination cursor for the page of campaigns starting at
+// the given ID. Since campaigns are listed by ID, pages stay stable when campaigns are created.
+func marshalCampaignCursor(next int64) string {
+	return string(relay.MarshalID(campaignCursorKind, next))
+}
+
+func unmarshalCampaignCursor(cursor string) (next int64, err error) {
+	if kind := relay.UnmarshalKind(graphql.ID(cursor)); kind != campaignCursorKind {
+		return 0, fmt.Errorf("invalid campaigns cursor %q", cursor)
+	}
+	err = relay.UnmarshalSpec(graphql.ID(cursor), &next)
+	return
+}
+
 func (r *campaignResolver) ID() graphql.ID {
 	return marshalCampaignID(r.Campaign.ID)
 }
@@ -115,9 +134,15 @@ func (r *campaignResolver) URL(ctx context.Context) (string, error) {
 }
 
 func (r *campaignResolver) Namespace(ctx context.Context) (n graphqlbackend.NamespaceResolver, err error) {
-	if r.NamespaceUserID != 0 {
+	typ, err := r.NamespaceType()
+	if err != nil {
+		return n, err
+	}
+
+	switch typ {
+	case campaigns.CampaignNamespaceTypeUser:
 		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.NamespaceUserID)
-	} else {
+	case campaigns.CampaignNamespaceTypeOrg:
 		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.NamespaceOrgID)
 	}
 
@@ -167,8 +167,14 @@ func (r *changesetResolver) Repository(ctx context.Context) (*graphqlbackend.Rep
 }
 
 func (r *changesetResolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampaignArgs) (graphqlbackend.CampaignsConnectionResolver, error) {
+	// 🚨 SECURITY: Only campaigns in namespaces the current user has access to are listed.
+	accessibleTo, err := campaignAccessUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	opts := ee.ListCampaignsOpts{
-		ChangesetID: r.Changeset.ID,
+		ChangesetID:        r.Changeset.ID,
+		AccessibleToUserID: accessibleTo,
 	}
 	state, err := parseCampaignState(args.State)
 	if err != nil {
@@ -178,6 +184,13 @@ func (r *changesetResolver) Campaigns(ctx context.Context, args *graphqlbackend.
 	if args.First != nil {
 		opts.Limit = int(*args.First)
 	}
+	if args.After != nil {
+		cursor, err := unmarshalCampaignCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
 	return &campaignsConnectionResolver{
 		store: r.store,
 		opts:  opts,
@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+type campaignLoaderKey struct{}
+
+// campaignLoader memoizes the campaigns loaded by ID during a single GraphQL request, which often
+// resolves the same campaign many times (e.g. through several node lookups). It must not outlive
+// the request, so that changes made by other requests are seen.
+type campaignLoader struct {
+	mu        sync.Mutex
+	campaigns map[int64]*campaignLoad
+}
+
+type campaignLoad struct {
+	once     sync.Once
+	campaign *campaigns.Campaign
+	err      error
+}
+
+// WithCampaignLoader returns a copy of ctx in which campaigns looked up by ID are loaded only once.
+// It is called with the context of every GraphQL request.
+func WithCampaignLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, campaignLoaderKey{}, &campaignLoader{campaigns: map[int64]*campaignLoad{}})
+}
+
+// loadCampaign returns the campaign with the given ID using fetch. If ctx carries a
+// campaignLoader, fetch is called at most once per ID, also for concurrent lookups. The returned
+// campaign is shared and must not be modified.
+func loadCampaign(ctx context.Context, id int64, fetch func(context.Context, int64) (*campaigns.Campaign, error)) (*campaigns.Campaign, error) {
+	l, ok := ctx.Value(campaignLoaderKey{}).(*campaignLoader)
+	if !ok {
+		return fetch(ctx, id)
+	}
+
+	l.mu.Lock()
+	load, ok := l.campaigns[id]
+	if !ok {
+		load = &campaignLoad{}
+		l.campaigns[id] = load
+	}
+	l.mu.Unlock()
+
+	load.once.Do(func() {
+		load.campaign, load.err = fetch(ctx, id)
+	})
+	return load.campaign, load.err
+}
@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestLoadCampaign(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[int64]int{}
+	fetch := func(ctx context.Context, id int64) (*campaigns.Campaign, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[id]++
+		return &campaigns.Campaign{ID: id}, nil
+	}
+
+	load := func(ctx context.Context, id int64) {
+		c, err := loadCampaign(ctx, id, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != id {
+			t.Fatalf("have campaign %d, want %d", c.ID, id)
+		}
+	}
+
+	t.Run("repeated lookups in a request", func(t *testing.T) {
+		calls = map[int64]int{}
+		ctx := WithCampaignLoader(context.Background())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				load(ctx, 1)
+			}()
+		}
+		wg.Wait()
+		load(ctx, 2)
+
+		if have, want := calls[1], 1; have != want {
+			t.Fatalf("have %d fetches of campaign 1, want %d", have, want)
+		}
+		if have, want := calls[2], 1; have != want {
+			t.Fatalf("have %d fetches of campaign 2, want %d", have, want)
+		}
+	})
+
+	t.Run("separate requests", func(t *testing.T) {
+		calls = map[int64]int{}
+		load(WithCampaignLoader(context.Background()), 1)
+		load(WithCampaignLoader(context.Background()), 1)
+
+		if have, want := calls[1], 2; have != want {
+			t.Fatalf("have %d fetches, want %d", have, want)
+		}
+	})
+
+	t.Run("without a loader", func(t *testing.T) {
+		calls = map[int64]int{}
+		load(context.Background(), 1)
+		load(context.Background(), 1)
+
+		if have, want := calls[1], 2; have != want {
+			t.Fatalf("have %d fetches, want %d", have, want)
+		}
+	})
+}
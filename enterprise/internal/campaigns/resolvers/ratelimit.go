@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is the state of the rate limit of a single key. The bucket holds up to burst tokens,
+// is refilled at a constant rate and every action takes one token out of it.
+type tokenBucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// take refills the bucket for the time passed since it was last updated and removes a token from
+// it, if there is one. If there is none, it returns how long it takes until the next token is
+// available.
+func (b *tokenBucket) take(now time.Time, perSecond float64, burst int) (wait time.Duration) {
+	if b.Updated.IsZero() {
+		b.Tokens = float64(burst)
+	} else if elapsed := now.Sub(b.Updated); elapsed > 0 {
+		b.Tokens = math.Min(float64(burst), b.Tokens+elapsed.Seconds()*perSecond)
+	}
+	b.Updated = now
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.Tokens) / perSecond * float64(time.Second)))
+}
+
+// refund puts a token back into the bucket, without filling it up beyond burst.
+func (b *tokenBucket) refund(burst int) {
+	b.Tokens = math.Min(float64(burst), b.Tokens+1)
+}
+
+// rateLimitStore stores the token buckets of a rateLimiter. memoryRateLimitStore keeps them in
+// the current process, other implementations can share them between frontend replicas.
+type rateLimitStore interface {
+	// Update calls fn with the bucket stored for key, which is the zero value if there is none,
+	// and stores the modified bucket. Calls for the same key must not run concurrently.
+	Update(key string, fn func(*tokenBucket))
+}
+
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{buckets: map[string]*tokenBucket{}}
+}
+
+func (s *memoryRateLimitStore) Update(key string, fn func(*tokenBucket)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{}
+		s.buckets[key] = b
+	}
+	fn(b)
+}
+
+// rateLimiter is a token bucket rate limiter with a bucket per key.
+type rateLimiter struct {
+	store rateLimitStore
+	// limit returns the number of actions allowed per hour and in quick succession. It is called
+	// for every action, so that changes to the site configuration apply immediately.
+	limit func() (perHour float64, burst int)
+	now   func() time.Time
+}
+
+func newRateLimiter(store rateLimitStore, limit func() (perHour float64, burst int)) *rateLimiter {
+	return &rateLimiter{store: store, limit: limit, now: time.Now}
+}
+
+// wait takes a token out of the bucket for key. If the bucket is empty, it returns how long the
+// caller must wait before retrying.
+func (l *rateLimiter) wait(key string) (wait time.Duration) {
+	perHour, burst := l.limit()
+	now := l.now()
+	l.store.Update(key, func(b *tokenBucket) {
+		wait = b.take(now, perHour/time.Hour.Seconds(), burst)
+	})
+	return wait
+}
+
+// refund puts back the token taken out of the bucket for key by a call to wait whose action
+// didn't happen, e.g. because it failed.
+func (l *rateLimiter) refund(key string) {
+	_, burst := l.limit()
+	l.store.Update(key, func(b *tokenBucket) {
+		b.refund(burst)
+	})
+}
+
+// campaignCreationRateLimitError is returned when too many campaigns are created in a namespace.
+type campaignCreationRateLimitError struct {
+	Wait time.Duration
+}
+
+func (e *campaignCreationRateLimitError) Error() string {
+	return fmt.Sprintf("Too many campaigns have been created in this namespace recently. You may create a new one after %v", (e.Wait + time.Second - 1).Truncate(time.Second))
+}
@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tick := func(d time.Duration) { now = now.Add(d) }
+
+	// One token every 6 minutes.
+	l := newRateLimiter(newMemoryRateLimitStore(), func() (float64, int) { return 10, 3 })
+	l.now = func() time.Time { return now }
+
+	t.Run("burst is throttled", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if wait := l.wait("user:1"); wait != 0 {
+				t.Fatalf("action %d: have wait %v, want 0", i, wait)
+			}
+		}
+
+		if have, want := l.wait("user:1"), 6*time.Minute; have != want {
+			t.Fatalf("have wait %v, want %v", have, want)
+		}
+
+		tick(time.Minute)
+		if have, want := l.wait("user:1"), 5*time.Minute; have != want {
+			t.Fatalf("have wait %v, want %v", have, want)
+		}
+	})
+
+	t.Run("keys are limited separately", func(t *testing.T) {
+		if wait := l.wait("org:1"); wait != 0 {
+			t.Fatalf("have wait %v, want 0", wait)
+		}
+	})
+
+	t.Run("bucket refills over time", func(t *testing.T) {
+		tick(5 * time.Minute)
+		if wait := l.wait("user:1"); wait != 0 {
+			t.Fatalf("have wait %v, want 0", wait)
+		}
+		if wait := l.wait("user:1"); wait == 0 {
+			t.Fatal("have no wait, want a wait")
+		}
+
+		// The bucket doesn't fill up beyond the burst.
+		tick(24 * time.Hour)
+		for i := 0; i < 3; i++ {
+			if wait := l.wait("user:1"); wait != 0 {
+				t.Fatalf("action %d: have wait %v, want 0", i, wait)
+			}
+		}
+		if wait := l.wait("user:1"); wait == 0 {
+			t.Fatal("have no wait, want a wait")
+		}
+	})
+
+	t.Run("refunded tokens can be taken again", func(t *testing.T) {
+		l.refund("user:1")
+		if wait := l.wait("user:1"); wait != 0 {
+			t.Fatalf("have wait %v, want 0", wait)
+		}
+
+		// Refunds don't fill the bucket up beyond the burst.
+		tick(24 * time.Hour)
+		for i := 0; i < 5; i++ {
+			l.refund("user:1")
+		}
+		for i := 0; i < 3; i++ {
+			if wait := l.wait("user:1"); wait != 0 {
+				t.Fatalf("action %d: have wait %v, want 0", i, wait)
+			}
+		}
+		if wait := l.wait("user:1"); wait == 0 {
+			t.Fatal("have no wait, want a wait")
+		}
+	})
+}
+
+func TestCampaignCreationRateLimitError(t *testing.T) {
+	err := &campaignCreationRateLimitError{Wait: 90*time.Second + time.Millisecond}
+	want := "Too many campaigns have been created in this namespace recently. You may create a new one after 1m31s"
+	if have := err.Error(); have != want {
+		t.Fatalf("have %q, want %q", have, want)
+	}
+}
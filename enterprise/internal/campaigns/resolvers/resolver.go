@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -30,11 +31,17 @@ import (
 type Resolver struct {
 	store       *ee.Store
 	httpFactory *httpcli.Factory
+
+	// createCampaignLimiter limits the rate at which campaigns are created per namespace.
+	createCampaignLimiter *rateLimiter
 }
 
 // NewResolver returns a new Resolver whose store uses the given db
 func NewResolver(db *sql.DB) graphqlbackend.CampaignsResolver {
-	return &Resolver{store: ee.NewStore(db)}
+	return &Resolver{
+		store:                 ee.NewStore(db),
+		createCampaignLimiter: newRateLimiter(newMemoryRateLimitStore(), conf.CampaignsCreationRateLimit),
+	}
 }
 
 func allowReadAccess(ctx context.Context) error {
@@ -69,7 +76,8 @@ func (r *Resolver) ChangesetByID(ctx context.Context, id graphql.ID) (graphqlbac
 }
 
 func (r *Resolver) CampaignByID(ctx context.Context, id graphql.ID) (graphqlbackend.CampaignResolver, error) {
-	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign, and
+	// only if they have access to the campaign's namespace (checked below).
 	if err := allowReadAccess(ctx); err != nil {
 		return nil, err
 	}
@@ -79,11 +87,17 @@ func (r *Resolver) CampaignByID(ctx context.Context, id graphql.ID) (graphqlback
 		return nil, err
 	}
 
-	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: campaignID})
+	campaign, err := loadCampaign(ctx, campaignID, func(ctx context.Context, id int64) (*campaigns.Campaign, error) {
+		return r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: id})
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkCampaignAccess(ctx, campaign); err != nil {
+		return nil, err
+	}
+
 	return &campaignResolver{store: r.store, Campaign: campaign}, nil
 }
 
@@ -185,6 +199,9 @@ func (r *Resolver) AddChangesetsToCampaign(ctx context.Context, args *graphqlbac
 	}
 
 	campaign.ChangesetIDs = append(campaign.ChangesetIDs, changesetIDs...)
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		campaign.LastUpdatedBy = a.UID
+	}
 	if err = tx.UpdateCampaign(ctx, campaign); err != nil {
 		return nil, err
 	}
@@ -219,6 +236,10 @@ func (r *Resolver) CreateCampaign(ctx context.Context, args *graphqlbackend.Crea
 		campaign.Branch = *args.Input.Branch
 	}
 
+	if args.Input.IdempotencyKey != nil {
+		campaign.IdempotencyKey = *args.Input.IdempotencyKey
+	}
+
 	if args.Input.Plan != nil {
 		planID, err := unmarshalCampaignPlanID(*args.Input.Plan)
 		if err != nil {
@@ -245,6 +266,40 @@ func (r *Resolver) CreateCampaign(ctx context.Context, args *graphqlbackend.Crea
 		return nil, err
 	}
 
+	if r.createCampaignLimiter != nil {
+		// Retries with the idempotency key of an existing campaign return
+		// that campaign instead of creating one, so they aren't limited.
+		var replay bool
+		if campaign.IdempotencyKey != "" {
+			_, err = r.store.GetCampaign(ctx, ee.GetCampaignOpts{
+				IdempotencyKey:  campaign.IdempotencyKey,
+				NamespaceUserID: campaign.NamespaceUserID,
+				NamespaceOrgID:  campaign.NamespaceOrgID,
+			})
+			if err != nil && err != ee.ErrNoResults {
+				return nil, err
+			}
+			replay, err = err == nil, nil
+		}
+
+		if !replay {
+			namespace := fmt.Sprintf("user:%d", campaign.NamespaceUserID)
+			if campaign.NamespaceOrgID != 0 {
+				namespace = fmt.Sprintf("org:%d", campaign.NamespaceOrgID)
+			}
+			if wait := r.createCampaignLimiter.wait(namespace); wait > 0 {
+				err = &campaignCreationRateLimitError{Wait: wait}
+				return nil, err
+			}
+			// Failed creations don't count towards the limit.
+			defer func() {
+				if err != nil {
+					r.createCampaignLimiter.refund(namespace)
+				}
+			}()
+		}
+	}
+
 	svc := ee.NewService(r.store, gitserver.DefaultClient, nil, r.httpFactory)
 	err = svc.CreateCampaign(ctx, campaign, draft)
 	if err != nil {
@@ -271,7 +326,7 @@ func (r *Resolver) UpdateCampaign(ctx context.Context, args *graphqlbackend.Upda
 		return nil, err
 	}
 
-	updateArgs := ee.UpdateCampaignArgs{Campaign: campaignID}
+	updateArgs := ee.UpdateCampaignArgs{Campaign: campaignID, UpdatedBy: actor.FromContext(ctx).UID}
 	updateArgs.Name = args.Input.Name
 	updateArgs.Description = args.Input.Description
 	updateArgs.Branch = args.Input.Branch
@@ -320,8 +375,13 @@ func (r *Resolver) DeleteCampaign(ctx context.Context, args *graphqlbackend.Dele
 		return nil, err
 	}
 
+	var reason string
+	if args.Reason != nil {
+		reason = *args.Reason
+	}
+
 	svc := ee.NewService(r.store, gitserver.DefaultClient, nil, r.httpFactory)
-	err = svc.DeleteCampaign(ctx, campaignID, args.CloseChangesets)
+	err = svc.DeleteCampaign(ctx, campaignID, args.CloseChangesets, reason)
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
@@ -357,11 +417,17 @@ func (r *Resolver) RetryCampaign(ctx context.Context, args *graphqlbackend.Retry
 }
 
 func (r *Resolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampaignArgs) (graphqlbackend.CampaignsConnectionResolver, error) {
-	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign.
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign, and
+	// only campaigns in namespaces they have access to are listed.
 	if err := allowReadAccess(ctx); err != nil {
 		return nil, err
 	}
 	var opts ee.ListCampaignsOpts
+	accessibleTo, err := campaignAccessUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.AccessibleToUserID = accessibleTo
 	state, err := parseCampaignState(args.State)
 	if err != nil {
 		return nil, err
@@ -370,6 +436,16 @@ func (r *Resolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampa
 	if args.First != nil {
 		opts.Limit = int(*args.First)
 	}
+	if args.After != nil {
+		cursor, err := unmarshalCampaignCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+	if args.Query != nil {
+		opts.Query = *args.Query
+	}
 	return &campaignsConnectionResolver{
 		store: r.store,
 		opts:  opts,
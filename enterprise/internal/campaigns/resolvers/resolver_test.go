@@ -1316,6 +1316,167 @@ func TestCampaignPlanResolver(t *testing.T) {
 	}
 }
 
+func TestCampaignsConnectionPagination(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	user := createTestUser(ctx, t)
+	ctx = actor.WithActor(ctx, actor.FromUser(user.ID))
+
+	store := ee.NewStore(dbconn.Global)
+	createCampaign := func(name string) string {
+		c := &campaigns.Campaign{Name: name, AuthorID: user.ID, NamespaceUserID: user.ID}
+		if err := store.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return string(marshalCampaignID(c.ID))
+	}
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, createCampaign(fmt.Sprintf("campaign-%d", i)))
+	}
+
+	s, err := graphqlbackend.NewSchema(&Resolver{store: store}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type CampaignConnection struct {
+		Nodes      []struct{ ID string }
+		TotalCount int
+		PageInfo   struct {
+			HasNextPage bool
+			EndCursor   *string
+		}
+	}
+
+	listPage := func(after *string) CampaignConnection {
+		var response struct{ Campaigns CampaignConnection }
+		mustExec(ctx, t, s, map[string]interface{}{"after": after}, &response, `
+			query($after: String) {
+				campaigns(first: 2, after: $after) {
+					nodes { id }
+					totalCount
+					pageInfo { hasNextPage, endCursor }
+				}
+			}
+		`)
+		return response.Campaigns
+	}
+
+	nodeIDs := func(c CampaignConnection) (ids []string) {
+		for _, n := range c.Nodes {
+			ids = append(ids, n.ID)
+		}
+		return ids
+	}
+
+	first := listPage(nil)
+	if diff := cmp.Diff(nodeIDs(first), ids[:2]); diff != "" {
+		t.Fatalf("first page: %s", diff)
+	}
+	if first.TotalCount != 5 || !first.PageInfo.HasNextPage || first.PageInfo.EndCursor == nil {
+		t.Fatalf("first page: wrong totalCount or pageInfo: %+v", first)
+	}
+
+	// Campaigns created in the meantime don't shift the following pages.
+	ids = append(ids, createCampaign("campaign-5"))
+
+	middle := listPage(first.PageInfo.EndCursor)
+	if diff := cmp.Diff(nodeIDs(middle), ids[2:4]); diff != "" {
+		t.Fatalf("middle page: %s", diff)
+	}
+	if middle.TotalCount != 6 || !middle.PageInfo.HasNextPage || middle.PageInfo.EndCursor == nil {
+		t.Fatalf("middle page: wrong totalCount or pageInfo: %+v", middle)
+	}
+
+	last := listPage(middle.PageInfo.EndCursor)
+	if diff := cmp.Diff(nodeIDs(last), ids[4:]); diff != "" {
+		t.Fatalf("last page: %s", diff)
+	}
+	if last.PageInfo.HasNextPage || last.PageInfo.EndCursor != nil {
+		t.Fatalf("last page: wrong pageInfo: %+v", last.PageInfo)
+	}
+
+	invalid := "not-a-cursor"
+	errs := exec(ctx, t, s, map[string]interface{}{"after": invalid}, &struct{}{}, `
+		query($after: String) { campaigns(after: $after) { totalCount } }
+	`)
+	if len(errs) == 0 {
+		t.Fatal("expected error for invalid cursor")
+	}
+}
+
+func TestCreateCampaignRateLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	user := createTestUser(ctx, t)
+	ctx = actor.WithActor(ctx, actor.FromUser(user.ID))
+
+	// A single campaign can be created before the limit kicks in.
+	r := &Resolver{
+		store:                 ee.NewStore(dbconn.Global),
+		createCampaignLimiter: newRateLimiter(newMemoryRateLimitStore(), func() (float64, int) { return 1, 1 }),
+	}
+
+	create := func(name, idempotencyKey string) error {
+		var args graphqlbackend.CreateCampaignArgs
+		args.Input.Namespace = graphqlbackend.MarshalUserID(user.ID)
+		args.Input.Name = name
+		if idempotencyKey != "" {
+			args.Input.IdempotencyKey = &idempotencyKey
+		}
+		_, err := r.CreateCampaign(ctx, &args)
+		return err
+	}
+
+	t.Run("FailedCreateIsRefunded", func(t *testing.T) {
+		if err := create("", ""); err != ee.ErrCampaignNameBlank {
+			t.Fatalf("have err %v, want %v", err, ee.ErrCampaignNameBlank)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		if err := create("Limited campaign", "limited-campaign"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("IdempotentReplayIsNotLimited", func(t *testing.T) {
+		if err := create("Limited campaign", "limited-campaign"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Limited", func(t *testing.T) {
+		err := create("Another campaign", "")
+		if _, ok := err.(*campaignCreationRateLimitError); !ok {
+			t.Fatalf("have err %v, want *campaignCreationRateLimitError", err)
+		}
+	})
+}
+
+func TestCampaignCursor(t *testing.T) {
+	cursor := marshalCampaignCursor(42)
+	if have, err := unmarshalCampaignCursor(cursor); err != nil || have != 42 {
+		t.Fatalf("have %d, %v; want 42", have, err)
+	}
+
+	if _, err := unmarshalCampaignCursor(string(marshalCampaignID(42))); err == nil {
+		t.Fatal("expected error for campaign ID used as cursor")
+	}
+}
+
 func mustExec(
 	ctx context.Context,
 	t testing.TB,
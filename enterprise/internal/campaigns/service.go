@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/opentracing/opentracing-go/log"
@@ -12,8 +14,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -146,6 +150,10 @@ func (s *Service) CreateCampaignPlanFromPatches(ctx context.Context, patches []c
 // Campaign and the Campaign is not created as a draft, it calls
 // CreateChangesetJobs inside the same transaction in which it creates the
 // Campaign.
+//
+// When an IdempotencyKey is set on the Campaign and a Campaign with the same
+// key already exists in its namespace, c is set to the existing Campaign
+// instead of creating a new one.
 func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign, draft bool) error {
 	var err error
 	tr, ctx := trace.New(ctx, "Service.CreateCampaign", fmt.Sprintf("Name: %q", c.Name))
@@ -158,12 +166,39 @@ func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign, dra
 		return ErrCampaignNameBlank
 	}
 
+	if err := checkCampaignDescriptionLength(c.Description); err != nil {
+		return err
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Done(&err)
 
+	err = s.createCampaignWithStore(ctx, tx, c, draft)
+	return err
+}
+
+// createCampaignWithStore creates the Campaign as described in CreateCampaign
+// using the given store, which must be in a transaction. The Campaign must
+// have been validated by the caller.
+func (s *Service) createCampaignWithStore(ctx context.Context, tx *Store, c *campaigns.Campaign, draft bool) error {
+	if c.IdempotencyKey != "" {
+		existing, err := tx.GetCampaign(ctx, GetCampaignOpts{
+			IdempotencyKey:  c.IdempotencyKey,
+			NamespaceUserID: c.NamespaceUserID,
+			NamespaceOrgID:  c.NamespaceOrgID,
+		})
+		if err != nil && err != ErrNoResults {
+			return err
+		}
+		if existing != nil {
+			*c = *existing
+			return nil
+		}
+	}
+
 	c.CreatedAt = s.clock()
 	c.UpdatedAt = c.CreatedAt
 
@@ -171,6 +206,17 @@ func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign, dra
 		return err
 	}
 
+	err := tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+		CampaignID: c.ID,
+		ActorID:    c.AuthorID,
+		Kind:       campaigns.CampaignEventKindCreated,
+		Changes:    createdCampaignEventChanges(c),
+		CreatedAt:  c.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
 	if c.CampaignPlanID != 0 && c.Branch == "" {
 		return ErrCampaignBranchBlank
 	}
@@ -185,7 +231,7 @@ func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign, dra
 // ErrNoCampaignJobs is returned by CreateCampaign or UpdateCampaign if a
 // CampaignPlanID was specified but the CampaignPlan does not have any
 // (finished) CampaignJobs.
-var ErrNoCampaignJobs = errors.New("cannot create or update a Campaign without any changesets")
+var ErrNoCampaignJobs = invalidInputError("cannot create or update a Campaign without any changesets")
 
 func (s *Service) createChangesetJobsWithStore(ctx context.Context, store *Store, c *campaigns.Campaign) error {
 	if c.CampaignPlanID == 0 {
@@ -463,7 +509,7 @@ func RunChangesetJob(
 // ErrCloseProcessingCampaign is returned by CloseCampaign if the Campaign has
 // been published at the time of closing but its ChangesetJobs have not
 // finished execution.
-var ErrCloseProcessingCampaign = errors.New("cannot delete a Campaign while changesets are being created on codehosts")
+var ErrCloseProcessingCampaign = conflictError("cannot delete a Campaign while changesets are being created on codehosts")
 
 // CloseCampaign closes the Campaign with the given ID if it has not been closed yet.
 func (s *Service) CloseCampaign(ctx context.Context, id int64, closeChangesets bool) (campaign *campaigns.Campaign, err error) {
@@ -500,8 +546,17 @@ func (s *Service) CloseCampaign(ctx context.Context, id int64, closeChangesets b
 		}
 
 		campaign.ClosedAt = time.Now().UTC()
+		campaign.LastUpdatedBy = actorUserID(ctx, campaign.LastUpdatedBy)
 
-		return tx.UpdateCampaign(ctx, campaign)
+		if err = tx.UpdateCampaign(ctx, campaign); err != nil {
+			return err
+		}
+
+		return tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+			CampaignID: campaign.ID,
+			ActorID:    actor.FromContext(ctx).UID,
+			Kind:       campaigns.CampaignEventKindClosed,
+		})
 	}
 
 	err = transaction()
@@ -560,12 +615,16 @@ func (s *Service) PublishCampaign(ctx context.Context, id int64) (campaign *camp
 // ErrDeleteProcessingCampaign is returned by DeleteCampaign if the Campaign
 // has been published at the time of deletion but its ChangesetJobs have not
 // finished execution.
-var ErrDeleteProcessingCampaign = errors.New("cannot delete a Campaign while changesets are being created on codehosts")
+var ErrDeleteProcessingCampaign = conflictError("cannot delete a Campaign while changesets are being created on codehosts")
 
-// DeleteCampaign deletes the Campaign with the given ID if it hasn't been
-// deleted yet. If closeChangesets is true, the changesets associated with the
-// Campaign will be closed on the codehosts.
-func (s *Service) DeleteCampaign(ctx context.Context, id int64, closeChangesets bool) (err error) {
+// DeleteCampaign deletes the Campaign with the given ID. It returns
+// ErrNoResults if the Campaign doesn't exist. If closeChangesets is true, the
+// changesets associated with the Campaign will be closed on the codehosts.
+//
+// The deletion is recorded in the Campaign's activity timeline, together with
+// the given reason and the user in ctx. An empty reason is allowed but
+// logged.
+func (s *Service) DeleteCampaign(ctx context.Context, id int64, closeChangesets bool, reason string) (err error) {
 	traceTitle := fmt.Sprintf("campaign: %d, closeChangesets: %t", id, closeChangesets)
 	tr, ctx := trace.New(ctx, "service.DeleteCampaign", traceTitle)
 	defer func() {
@@ -588,6 +647,28 @@ func (s *Service) DeleteCampaign(ctx context.Context, id int64, closeChangesets
 			return nil, ErrDeleteProcessingCampaign
 		}
 
+		campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		if reason == "" {
+			log15.Warn("Campaign deleted without a reason", "campaign", id)
+		}
+
+		err = tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+			CampaignID: id,
+			ActorID:    actor.FromContext(ctx).UID,
+			Kind:       campaigns.CampaignEventKindDeleted,
+			Changes: map[string]campaigns.CampaignEventChange{
+				"name":   {Old: campaign.Name},
+				"reason": {New: reason},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		// If we don't have to close the changesets, we can simply delete the
 		// Campaign and return. The triggers in the database will remove the
 		// campaign's ID from the changesets' CampaignIDs.
@@ -597,7 +678,7 @@ func (s *Service) DeleteCampaign(ctx context.Context, id int64, closeChangesets
 
 		// First load the Changesets with the given campaignID, before deleting
 		// the campaign would remove the association.
-		cs, _, err = s.store.ListChangesets(ctx, ListChangesetsOpts{
+		cs, _, err = tx.ListChangesets(ctx, ListChangesetsOpts{
 			CampaignID: id,
 			Limit:      -1,
 		})
@@ -612,7 +693,7 @@ func (s *Service) DeleteCampaign(ctx context.Context, id int64, closeChangesets
 			c.RemoveCampaignID(id)
 		}
 
-		return cs, s.store.DeleteCampaign(ctx, id)
+		return cs, tx.DeleteCampaign(ctx, id)
 	}
 
 	cs, err := transaction()
@@ -732,7 +813,7 @@ func (s *Service) CreateChangesetJobForCampaignJob(ctx context.Context, campaign
 // ErrUpdateProcessingCampaign is returned by UpdateCampaign if the Campaign
 // has been published at the time of update but its ChangesetJobs have not
 // finished execution.
-var ErrUpdateProcessingCampaign = errors.New("cannot update a Campaign while changesets are being created on codehosts")
+var ErrUpdateProcessingCampaign = conflictError("cannot update a Campaign while changesets are being created on codehosts")
 
 type UpdateCampaignArgs struct {
 	Campaign    int64
@@ -740,19 +821,56 @@ type UpdateCampaignArgs struct {
 	Description *string
 	Branch      *string
 	Plan        *int64
+	// UpdatedBy is the ID of the user performing the update. It defaults to
+	// the authenticated user in ctx and is recorded as the actor of the
+	// update event and as the Campaign's LastUpdatedBy, unless it's the
+	// Campaign's author.
+	UpdatedBy int32
 }
 
 // ErrCampaignNameBlank is returned by CreateCampaign or UpdateCampaign if the
 // specified Campaign name is blank.
-var ErrCampaignNameBlank = errors.New("Campaign title cannot be blank")
+var ErrCampaignNameBlank = invalidInputError("Campaign title cannot be blank")
+
+// CampaignDescriptionTooLongError is returned by CreateCampaign or
+// UpdateCampaign if the specified Campaign description is longer than the
+// maximum length.
+type CampaignDescriptionTooLongError struct {
+	// Length is the length of the description in runes.
+	Length int
+	// Max is the maximum length of a description in runes.
+	Max int
+}
+
+func (e *CampaignDescriptionTooLongError) Error() string {
+	return fmt.Sprintf("Campaign description is too long (%d characters, maximum is %d)", e.Length, e.Max)
+}
+
+func (e *CampaignDescriptionTooLongError) BadRequest() bool { return true }
+
+// checkCampaignDescriptionLength returns a *CampaignDescriptionTooLongError
+// if the given description is longer than the maximum length set by
+// campaigns.descriptionLength in the site config. Descriptions above its
+// warning length are accepted but logged.
+func checkCampaignDescriptionLength(description string) error {
+	warn, max := conf.CampaignsDescriptionLength()
+	n := utf8.RuneCountInString(description)
+	if n > max {
+		return &CampaignDescriptionTooLongError{Length: n, Max: max}
+	}
+	if n > warn {
+		log15.Warn("Campaign description is very long", "length", n, "max", max)
+	}
+	return nil
+}
 
 // ErrCampaignBranchBlank is returned by CreateCampaign if the specified Campaign's
 // branch is blank. This is only enforced when creating published campaigns with a plan.
-var ErrCampaignBranchBlank = errors.New("Campaign branch cannot be blank")
+var ErrCampaignBranchBlank = invalidInputError("Campaign branch cannot be blank")
 
 // ErrPublishedCampaignBranchChange is returned by UpdateCampaign if there is an
 // attempt to change the branch of a published campaign with a plan (or a campaign with individually published changesets).
-var ErrPublishedCampaignBranchChange = errors.New("Published campaign branch cannot be changed")
+var ErrPublishedCampaignBranchChange = invalidInputError("Published campaign branch cannot be changed")
 
 // UpdateCampaign updates the Campaign with the given arguments.
 func (s *Service) UpdateCampaign(ctx context.Context, args UpdateCampaignArgs) (campaign *campaigns.Campaign, detachedChangesets []*campaigns.Changeset, err error) {
@@ -776,23 +894,34 @@ func (s *Service) UpdateCampaign(ctx context.Context, args UpdateCampaignArgs) (
 	}
 
 	var updateAttributes, updatePlanID, updateBranch bool
+	changes := map[string]campaigns.CampaignEventChange{}
 
 	if args.Name != nil && campaign.Name != *args.Name {
 		if *args.Name == "" {
 			return nil, nil, ErrCampaignNameBlank
 		}
 
+		changes["name"] = campaigns.CampaignEventChange{Old: campaign.Name, New: *args.Name}
 		campaign.Name = *args.Name
 		updateAttributes = true
 	}
 
 	if args.Description != nil && campaign.Description != *args.Description {
+		if err := checkCampaignDescriptionLength(*args.Description); err != nil {
+			return nil, nil, err
+		}
+
+		changes["description"] = campaigns.CampaignEventChange{Old: campaign.Description, New: *args.Description}
 		campaign.Description = *args.Description
 		updateAttributes = true
 	}
 
 	oldPlanID := campaign.CampaignPlanID
 	if args.Plan != nil && oldPlanID != *args.Plan {
+		changes["plan"] = campaigns.CampaignEventChange{
+			Old: strconv.FormatInt(oldPlanID, 10),
+			New: strconv.FormatInt(*args.Plan, 10),
+		}
 		campaign.CampaignPlanID = *args.Plan
 		updatePlanID = true
 	}
@@ -802,6 +931,7 @@ func (s *Service) UpdateCampaign(ctx context.Context, args UpdateCampaignArgs) (
 			return nil, nil, ErrCampaignBranchBlank
 		}
 
+		changes["branch"] = campaigns.CampaignEventChange{Old: campaign.Branch, New: *args.Branch}
 		campaign.Branch = *args.Branch
 		updateBranch = true
 	}
@@ -810,6 +940,24 @@ func (s *Service) UpdateCampaign(ctx context.Context, args UpdateCampaignArgs) (
 		return campaign, nil, nil
 	}
 
+	updatedBy := args.UpdatedBy
+	if updatedBy == 0 {
+		updatedBy = actor.FromContext(ctx).UID
+	}
+	if updatedBy != 0 {
+		campaign.LastUpdatedBy = updatedBy
+	}
+
+	err = tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+		CampaignID: campaign.ID,
+		ActorID:    updatedBy,
+		Kind:       campaigns.CampaignEventKindUpdated,
+		Changes:    changes,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	status, err := tx.GetCampaignStatus(ctx, campaign.ID)
 	if err != nil {
 		return nil, nil, err
@@ -3,8 +3,10 @@ package campaigns
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,13 +15,16 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func init() {
@@ -342,7 +347,7 @@ func TestService(t *testing.T) {
 				}
 
 				newName := "this is a new campaign name"
-				args := UpdateCampaignArgs{Campaign: campaign.ID, Name: &newName, Branch: tc.branch}
+				args := UpdateCampaignArgs{Campaign: campaign.ID, Name: &newName, Branch: tc.branch, UpdatedBy: user.ID}
 
 				updatedCampaign, _, err := svc.UpdateCampaign(ctx, args)
 				if have, want := fmt.Sprint(err), tc.err; have != want {
@@ -360,9 +365,312 @@ func TestService(t *testing.T) {
 				if tc.branch != nil && updatedCampaign.Branch != *tc.branch {
 					t.Errorf("Branch not updated. want=%q, have %q", updatedCampaign.Branch, *tc.branch)
 				}
+
+				// The author updated the Campaign, so LastUpdatedBy stays
+				// empty while the event records them as the actor.
+				if updatedCampaign.LastUpdatedBy != 0 {
+					t.Errorf("LastUpdatedBy set for author. want=0, have=%d", updatedCampaign.LastUpdatedBy)
+				}
+
+				reloaded, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if reloaded.LastUpdatedBy != 0 {
+					t.Errorf("LastUpdatedBy persisted for author. want=0, have=%d", reloaded.LastUpdatedBy)
+				}
+
+				events, err := store.ListCampaignEvents(ctx, campaign.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(events) == 0 || events[len(events)-1].ActorID != user.ID {
+					t.Errorf("update event not attributed to user %d: %+v", user.ID, events)
+				}
 			})
 		}
 	})
+
+	t.Run("CreateCampaignWithIdempotencyKey", func(t *testing.T) {
+		svc := NewServiceWithClock(store, gitClient, nil, cf, clock)
+
+		first := testCampaign(user.ID, 0)
+		first.IdempotencyKey = "create-campaign-1"
+		if err := svc.CreateCampaign(ctx, first, true); err != nil {
+			t.Fatal(err)
+		}
+
+		duplicate := testCampaign(user.ID, 0)
+		duplicate.Name = "Retried campaign"
+		duplicate.IdempotencyKey = first.IdempotencyKey
+		if err := svc.CreateCampaign(ctx, duplicate, true); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(duplicate, first); diff != "" {
+			t.Fatalf("duplicate key didn't return existing campaign: %s", diff)
+		}
+
+		distinct := testCampaign(user.ID, 0)
+		distinct.IdempotencyKey = "create-campaign-2"
+		if err := svc.CreateCampaign(ctx, distinct, true); err != nil {
+			t.Fatal(err)
+		}
+
+		if distinct.ID == first.ID {
+			t.Fatalf("distinct key returned existing campaign %d", first.ID)
+		}
+	})
+
+	t.Run("CampaignDescriptionLength", func(t *testing.T) {
+		svc := NewServiceWithClock(store, gitClient, nil, cf, clock)
+
+		const warnLength, maxLength = 5, 10
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			CampaignsDescriptionLength: &schema.CampaignsDescriptionLength{Warn: warnLength, Max: maxLength},
+		}})
+		defer conf.Mock(nil)
+
+		// Lengths are measured in runes, not bytes.
+		atWarnLength := strings.Repeat("ü", warnLength+1)
+		aboveMaxLength := strings.Repeat("ü", maxLength+1)
+
+		campaign := testCampaign(user.ID, 0)
+		campaign.Description = atWarnLength
+		if err := svc.CreateCampaign(ctx, campaign, true); err != nil {
+			t.Fatalf("description above warning threshold rejected: %s", err)
+		}
+
+		tooLong := testCampaign(user.ID, 0)
+		tooLong.Description = aboveMaxLength
+		err := svc.CreateCampaign(ctx, tooLong, true)
+		want := &CampaignDescriptionTooLongError{Length: maxLength + 1, Max: maxLength}
+		if e, ok := err.(*CampaignDescriptionTooLongError); !ok || *e != *want {
+			t.Fatalf("CreateCampaign returned wrong error. want=%v, have=%v", want, err)
+		}
+
+		args := UpdateCampaignArgs{Campaign: campaign.ID, Description: &aboveMaxLength}
+		_, _, err = svc.UpdateCampaign(ctx, args)
+		if e, ok := err.(*CampaignDescriptionTooLongError); !ok || *e != *want {
+			t.Fatalf("UpdateCampaign returned wrong error. want=%v, have=%v", want, err)
+		}
+	})
+
+	t.Run("CampaignEvents", func(t *testing.T) {
+		svc := NewServiceWithClock(store, gitClient, nil, cf, clock)
+		campaign := testCampaign(user.ID, 0)
+
+		if err := svc.CreateCampaign(ctx, campaign, true); err != nil {
+			t.Fatal(err)
+		}
+
+		newName := "Renamed campaign"
+		args := UpdateCampaignArgs{Campaign: campaign.ID, Name: &newName, UpdatedBy: user.ID}
+		if _, _, err := svc.UpdateCampaign(ctx, args); err != nil {
+			t.Fatal(err)
+		}
+
+		// Updates that don't change anything aren't recorded.
+		if _, _, err := svc.UpdateCampaign(ctx, args); err != nil {
+			t.Fatal(err)
+		}
+
+		actorCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+		if _, err := svc.CloseCampaign(actorCtx, campaign.ID, false); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := store.ListCampaignEvents(ctx, campaign.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, e := range have {
+			e.ID = 0
+			e.CreatedAt = time.Time{}
+		}
+
+		want := []*campaigns.CampaignEvent{
+			{
+				CampaignID: campaign.ID,
+				ActorID:    user.ID,
+				Kind:       campaigns.CampaignEventKindCreated,
+				Changes: map[string]campaigns.CampaignEventChange{
+					"name":        {New: "Testing Campaign"},
+					"description": {New: "Testing Campaign"},
+				},
+			},
+			{
+				CampaignID: campaign.ID,
+				ActorID:    user.ID,
+				Kind:       campaigns.CampaignEventKindUpdated,
+				Changes: map[string]campaigns.CampaignEventChange{
+					"name": {Old: "Testing Campaign", New: newName},
+				},
+			},
+			{
+				CampaignID: campaign.ID,
+				ActorID:    user.ID,
+				Kind:       campaigns.CampaignEventKindClosed,
+				Changes:    map[string]campaigns.CampaignEventChange{},
+			},
+		}
+
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("DeleteCampaignWithReason", func(t *testing.T) {
+		svc := NewServiceWithClock(store, gitClient, nil, cf, clock)
+		actorCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+
+		for _, reason := range []string{"Superseded by another campaign", ""} {
+			campaign := testCampaign(user.ID, 0)
+			if err := svc.CreateCampaign(ctx, campaign, true); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := svc.DeleteCampaign(actorCtx, campaign.ID, false, reason); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID}); err != ErrNoResults {
+				t.Fatalf("campaign not deleted: have err %v, want %v", err, ErrNoResults)
+			}
+
+			// The timeline outlives the deleted Campaign.
+			events, err := store.ListCampaignEvents(ctx, campaign.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have := events[len(events)-1]
+			have.ID = 0
+			have.CreatedAt = time.Time{}
+
+			want := &campaigns.CampaignEvent{
+				CampaignID: campaign.ID,
+				ActorID:    user.ID,
+				Kind:       campaigns.CampaignEventKindDeleted,
+				Changes: map[string]campaigns.CampaignEventChange{
+					"name":   {Old: campaign.Name},
+					"reason": {New: reason},
+				},
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatalf("reason %q: %s", reason, diff)
+			}
+		}
+
+		// Deleting a missing Campaign fails and isn't recorded.
+		for _, closeChangesets := range []bool{false, true} {
+			const missing = 99999
+			if err := svc.DeleteCampaign(actorCtx, missing, closeChangesets, "Gone"); err != ErrNoResults {
+				t.Fatalf("closeChangesets %t: have err %v, want %v", closeChangesets, err, ErrNoResults)
+			}
+			events, err := store.ListCampaignEvents(ctx, missing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 0 {
+				t.Fatalf("closeChangesets %t: have events %v, want none", closeChangesets, events)
+			}
+		}
+	})
+
+	t.Run("ImportCampaign", func(t *testing.T) {
+		svc := NewServiceWithClock(store, gitClient, nil, cf, clock)
+
+		changesets := []*campaigns.Changeset{
+			{RepoID: rs[0].ID, ExternalServiceType: github.ServiceType, ExternalID: "import-1"},
+			{RepoID: rs[1].ID, ExternalServiceType: github.ServiceType, ExternalID: "import-2"},
+		}
+		if err := store.CreateChangesets(ctx, changesets...); err != nil {
+			t.Fatal(err)
+		}
+
+		existing := []ChangesetExport{
+			{Repository: rs[0].Name, ExternalServiceType: github.ServiceType, ExternalID: "import-1"},
+			{Repository: rs[1].Name, ExternalServiceType: github.ServiceType, ExternalID: "import-2"},
+		}
+		missing := []ChangesetExport{
+			// The repository exists, the changeset doesn't.
+			{Repository: rs[0].Name, ExternalServiceType: github.ServiceType, ExternalID: "import-3"},
+			// Neither exists.
+			{Repository: "github.com/sourcegraph/missing", ExternalServiceType: github.ServiceType, ExternalID: "import-1"},
+		}
+
+		for name, tc := range map[string]struct {
+			refs        []ChangesetExport
+			wantMissing []ChangesetExport
+		}{
+			"all changesets exist": {refs: existing},
+			"missing changesets":   {refs: append(append([]ChangesetExport{}, existing...), missing...), wantMissing: missing},
+		} {
+			t.Run(name, func(t *testing.T) {
+				doc, err := json.Marshal(&CampaignExport{
+					SchemaVersion: CampaignExportVersion,
+					Name:          "Imported campaign",
+					Description:   name,
+					Branch:        "imported",
+					Changesets:    tc.refs,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				campaign, report, err := svc.ImportCampaign(ctx, doc, user.ID, 0, user.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer func() {
+					if err := store.DeleteCampaign(ctx, campaign.ID); err != nil {
+						t.Fatal(err)
+					}
+				}()
+
+				if diff := cmp.Diff(report.MissingChangesets, tc.wantMissing); diff != "" {
+					t.Fatalf("missing changesets: %s", diff)
+				}
+
+				have, err := store.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have.Name != "Imported campaign" || have.Description != name || have.Branch != "imported" || have.NamespaceUserID != user.ID || have.AuthorID != user.ID {
+					t.Fatalf("imported campaign has wrong fields: %+v", have)
+				}
+				sort.Slice(have.ChangesetIDs, func(i, j int) bool { return have.ChangesetIDs[i] < have.ChangesetIDs[j] })
+				if diff := cmp.Diff(have.ChangesetIDs, []int64{changesets[0].ID, changesets[1].ID}); diff != "" {
+					t.Fatalf("changeset IDs: %s", diff)
+				}
+
+				for _, c := range changesets {
+					linked, err := store.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+					if err != nil {
+						t.Fatal(err)
+					}
+					var isLinked bool
+					for _, id := range linked.CampaignIDs {
+						isLinked = isLinked || id == campaign.ID
+					}
+					if !isLinked {
+						t.Fatalf("changeset %d not linked to campaign %d: %v", c.ID, campaign.ID, linked.CampaignIDs)
+					}
+				}
+			})
+		}
+
+		t.Run("unknown schema version", func(t *testing.T) {
+			doc := json.RawMessage(`{"schemaVersion": 99, "name": "foo", "changesets": []}`)
+			_, _, err := svc.ImportCampaign(ctx, doc, user.ID, 0, user.ID)
+			if have, want := errString(err), "unsupported campaign export schema version 99"; have != want {
+				t.Fatalf("have error %q, want %q", have, want)
+			}
+		})
+	})
 }
 
 type repoNames []string
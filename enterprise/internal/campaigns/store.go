@@ -6,14 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/segmentio/fasthash/fnv1"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -189,6 +197,23 @@ func (s *Store) Done(errs ...*error) {
 	}
 }
 
+// transact runs f with a Store operating within a transaction, which is
+// committed if f succeeds and rolled back otherwise. If s is already in a
+// transaction, f runs within it and ending it is left to the caller.
+func (s *Store) transact(ctx context.Context, f func(tx *Store) error) (err error) {
+	if _, ok := s.db.(dbutil.Tx); ok {
+		return f(s)
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	return f(tx)
+}
+
 var NoTransactionError = errors.New("Not in a transaction")
 
 var lockNamespace = int32(fnv1.HashString32("campaigns"))
@@ -259,6 +284,8 @@ func (e AlreadyExistError) Error() string {
 	return fmt.Sprintf("Changesets already exist: %v", e.ChangesetIDs)
 }
 
+func (e AlreadyExistError) Conflict() bool { return true }
+
 // CreateChangesets creates the given Changesets. If a subset of the given
 // Changesets with the same RepoID and ExternalID already exists in the
 // database, it overwrites the fields of the affected changeset pointers with
@@ -476,12 +503,13 @@ func countChangesetsQuery(opts *CountChangesetsOpts) *sqlf.Query {
 // GetChangesetOpts captures the query options needed for getting a Changeset
 type GetChangesetOpts struct {
 	ID                  int64
+	RepoID              api.RepoID
 	ExternalID          string
 	ExternalServiceType string
 }
 
 // ErrNoResults is returned by Store method calls that found no results.
-var ErrNoResults = errors.New("no results")
+var ErrNoResults = notFoundError("no results")
 
 // GetChangeset gets a changeset matching the given options.
 func (s *Store) GetChangeset(ctx context.Context, opts GetChangesetOpts) (*campaigns.Changeset, error) {
@@ -526,6 +554,10 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
 	}
 
+	if opts.RepoID != 0 {
+		preds = append(preds, sqlf.Sprintf("repo_id = %s", opts.RepoID))
+	}
+
 	if opts.ExternalID != "" && opts.ExternalServiceType != "" {
 		preds = append(preds,
 			sqlf.Sprintf("external_id = %s", opts.ExternalID),
@@ -590,8 +622,19 @@ WHERE %s
 ORDER BY id ASC
 `
 
+// defaultListLimit is the page size used by the List methods when no limit
+// is given.
 const defaultListLimit = 50
 
+// maxCampaignsListLimit returns the maximum number of Campaigns returned by a
+// single call to ListCampaigns, as configured by campaigns.maxListLimit in
+// the site config. Requests for more, including those without a limit that
+// would otherwise default to defaultListLimit, are reduced to it, and the
+// returned cursor indicates that more results exist.
+func maxCampaignsListLimit() int {
+	return conf.CampaignsMaxListLimit()
+}
+
 func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 	if opts.Limit == 0 {
 		opts.Limit = defaultListLimit
@@ -998,17 +1041,49 @@ func batchChangesetEventsQuery(fmtstr string, es []*campaigns.ChangesetEvent) (*
 	return sqlf.Sprintf(fmtstr, string(batch)), nil
 }
 
-// CreateCampaign creates the given Campaign.
+// CreateCampaign creates the given Campaign. If ctx carries an authenticated
+// user, that user is recorded as the Campaign's author. Otherwise the given
+// AuthorID is used. Constraint violations are reported as
+// ErrCampaignNameBlank, ErrCampaignNamespace and
+// ErrCampaignIdempotencyKeyExists.
 func (s *Store) CreateCampaign(ctx context.Context, c *campaigns.Campaign) error {
+	c.AuthorID = actorUserID(ctx, c.AuthorID)
+
 	q, err := s.createCampaignQuery(c)
 	if err != nil {
 		return err
 	}
 
-	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+	err = s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
 		err = scanCampaign(c, sc)
 		return c.ID, 1, err
 	})
+	return campaignWriteError(err)
+}
+
+// ErrCampaignNamespace is returned by CreateCampaign and UpdateCampaign if a
+// Campaign is not in exactly one namespace.
+var ErrCampaignNamespace = invalidInputError("Campaign must belong to either a user or an organization")
+
+// ErrCampaignIdempotencyKeyExists is returned by CreateCampaign,
+// UpdateCampaign and ReassignNamespaceOrg if another Campaign in the same
+// namespace has the same IdempotencyKey.
+var ErrCampaignIdempotencyKeyExists = conflictError("a campaign with this idempotency key already exists in the namespace")
+
+// campaignWriteError maps the constraint violations reported when writing a
+// Campaign to the errors of this package. Other errors are returned as is.
+func campaignWriteError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Constraint {
+		case "campaigns_name_not_blank":
+			return ErrCampaignNameBlank
+		case "campaigns_has_1_namespace":
+			return ErrCampaignNamespace
+		case "campaigns_namespace_user_id_idempotency_key", "campaigns_namespace_org_id_idempotency_key":
+			return ErrCampaignIdempotencyKeyExists
+		}
+	}
+	return err
 }
 
 var createCampaignQueryFmtstr = `
@@ -1024,9 +1099,12 @@ INSERT INTO campaigns (
   updated_at,
   changeset_ids,
   campaign_plan_id,
-  closed_at
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template
 )
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING
   id,
   name,
@@ -1039,7 +1117,11 @@ RETURNING
   updated_at,
   changeset_ids,
   campaign_plan_id,
-  closed_at
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
 `
 
 func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error) {
@@ -1069,6 +1151,9 @@ func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullInt64Column(c.CampaignPlanID),
 		nullTimeColumn(c.ClosedAt),
+		nullInt32Column(c.LastUpdatedBy),
+		nullStringColumn(c.IdempotencyKey),
+		c.IsTemplate,
 	), nil
 }
 
@@ -1100,17 +1185,33 @@ func nullStringColumn(s string) *string {
 	return &s
 }
 
-// UpdateCampaign updates the given Campaign.
+// UpdateCampaign updates the given Campaign. The given LastUpdatedBy is
+// recorded as is, unless it's the Campaign's author, in which case it's
+// cleared. It returns ErrNoResults if the Campaign doesn't exist,
+// ErrCampaignLocked if it is locked (see WithCampaignLockOverride) and
+// reports constraint violations like CreateCampaign.
 func (s *Store) UpdateCampaign(ctx context.Context, c *campaigns.Campaign) error {
-	q, err := s.updateCampaignQuery(c)
+	if c.LastUpdatedBy == c.AuthorID {
+		c.LastUpdatedBy = 0
+	}
+
+	q, err := s.updateCampaignQuery(ctx, c)
 	if err != nil {
 		return err
 	}
 
-	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+	id := c.ID
+	_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
 		err = scanCampaign(c, sc)
 		return c.ID, 1, err
 	})
+	if err != nil {
+		return campaignWriteError(err)
+	}
+	if count == 0 {
+		return s.lockedOrMissingCampaignError(ctx, id)
+	}
+	return nil
 }
 
 var updateCampaignQueryFmtstr = `
@@ -1126,9 +1227,11 @@ SET (
   updated_at,
   changeset_ids,
   campaign_plan_id,
-  closed_at
-) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
-WHERE id = %s
+  closed_at,
+  last_updated_by,
+  is_template
+) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s AND (NOT is_locked OR %s)
 RETURNING
   id,
   name,
@@ -1141,10 +1244,14 @@ RETURNING
   updated_at,
   changeset_ids,
   campaign_plan_id,
-  closed_at
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
 `
 
-func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error) {
+func (s *Store) updateCampaignQuery(ctx context.Context, c *campaigns.Campaign) (*sqlf.Query, error) {
 	changesetIDs, err := jsonSetColumn(c.ChangesetIDs)
 	if err != nil {
 		return nil, err
@@ -1164,13 +1271,1975 @@ func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullInt64Column(c.CampaignPlanID),
 		nullTimeColumn(c.ClosedAt),
+		nullInt32Column(c.LastUpdatedBy),
+		c.IsTemplate,
 		c.ID,
+		hasCampaignLockOverride(ctx),
 	), nil
 }
 
-// DeleteCampaign deletes the Campaign with the given ID.
-func (s *Store) DeleteCampaign(ctx context.Context, id int64) error {
-	q := sqlf.Sprintf(deleteCampaignQueryFmtstr, id)
+// ErrNoCampaignTemplate is returned by InstantiateCampaignTemplate if the
+// Campaign to instantiate is not a template.
+var ErrNoCampaignTemplate = invalidInputError("campaign is not a template")
+
+// InstantiateCampaignTemplate creates a Campaign with the given name in the
+// given namespace from the Campaign template with the given ID. The
+// description and branch are copied from the template. If ctx carries an
+// authenticated user, that user is recorded as the Campaign's author.
+// Otherwise the given authorID is used. The creation is recorded as a
+// CampaignEvent.
+func (s *Store) InstantiateCampaignTemplate(ctx context.Context, templateID int64, name string, authorID, namespaceUserID, namespaceOrgID int32) (*campaigns.Campaign, error) {
+	now := s.now()
+	q := sqlf.Sprintf(
+		instantiateCampaignTemplateQueryFmtstr,
+		name,
+		actorUserID(ctx, authorID),
+		nullInt32Column(namespaceUserID),
+		nullInt32Column(namespaceOrgID),
+		now,
+		now,
+		templateID,
+	)
+
+	var c campaigns.Campaign
+	err := s.transact(ctx, func(tx *Store) error {
+		_, count, err := tx.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			err = scanCampaign(&c, sc)
+			return c.ID, 1, err
+		})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrNoCampaignTemplate
+		}
+
+		changes := createdCampaignEventChanges(&c)
+		changes["template"] = campaigns.CampaignEventChange{New: strconv.FormatInt(templateID, 10)}
+
+		return tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+			CampaignID: c.ID,
+			ActorID:    c.AuthorID,
+			Kind:       campaigns.CampaignEventKindCreated,
+			Changes:    changes,
+			CreatedAt:  c.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// createdCampaignEventChanges returns the changes recorded by the
+// CampaignEvent of the creation of the given Campaign.
+func createdCampaignEventChanges(c *campaigns.Campaign) map[string]campaigns.CampaignEventChange {
+	changes := map[string]campaigns.CampaignEventChange{
+		"name": {New: c.Name},
+	}
+	if c.Description != "" {
+		changes["description"] = campaigns.CampaignEventChange{New: c.Description}
+	}
+	if c.Branch != "" {
+		changes["branch"] = campaigns.CampaignEventChange{New: c.Branch}
+	}
+	return changes
+}
+
+var instantiateCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:InstantiateCampaignTemplate
+INSERT INTO campaigns (
+  name,
+  description,
+  branch,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+)
+SELECT %s, description, branch, %s, %s, %s, %s, %s
+FROM campaigns
+WHERE id = %s AND is_template
+RETURNING
+  id,
+  name,
+  description,
+  branch,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at,
+  changeset_ids,
+  campaign_plan_id,
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
+`
+
+// CampaignsUpdate describes the changes UpdateCampaignsByID applies to
+// Campaigns. Nil fields are left unchanged.
+type CampaignsUpdate struct {
+	Description *string
+	Branch      *string
+	// Closed closes the Campaigns if true and reopens them if false.
+	Closed *bool
+	// UpdatedBy is recorded as the Campaigns' LastUpdatedBy, except for
+	// Campaigns authored by that user. Defaults to the authenticated user in
+	// ctx.
+	UpdatedBy int32
+}
+
+// UpdateCampaignsByID applies the given update to all Campaigns with the
+// given IDs in a single statement and returns the number of Campaigns that
+// were updated. The changes to each Campaign are recorded as a CampaignEvent.
+// It returns ErrCampaignLocked without updating any Campaigns if any of them
+// is locked (see WithCampaignLockOverride).
+func (s *Store) UpdateCampaignsByID(ctx context.Context, ids []int64, u CampaignsUpdate) (int, error) {
+	q := s.updateCampaignsByIDQuery(ctx, ids, u)
+	if q == nil {
+		return 0, nil
+	}
+
+	updatedBy := u.UpdatedBy
+	if updatedBy == 0 {
+		updatedBy = actorUserID(ctx, 0)
+	}
+
+	var count int64
+	err := s.transact(ctx, func(tx *Store) (err error) {
+		if err = tx.checkCampaignsUnlocked(ctx, sqlf.Sprintf("id = ANY(%s)", pq.Array(ids))); err != nil {
+			return err
+		}
+
+		var events []*campaigns.CampaignEvent
+		_, count, err = tx.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			var old, updated campaigns.Campaign
+			err = sc.Scan(
+				&updated.ID,
+				&old.Description,
+				&updated.Description,
+				&dbutil.NullString{S: &old.Branch},
+				&dbutil.NullString{S: &updated.Branch},
+				&dbutil.NullTime{Time: &old.ClosedAt},
+				&dbutil.NullTime{Time: &updated.ClosedAt},
+			)
+			if err != nil {
+				return 0, 0, err
+			}
+
+			if changes := updatedCampaignEventChanges(&old, &updated); len(changes) > 0 {
+				events = append(events, &campaigns.CampaignEvent{
+					CampaignID: updated.ID,
+					ActorID:    updatedBy,
+					Kind:       campaigns.CampaignEventKindUpdated,
+					Changes:    changes,
+				})
+			}
+			return updated.ID, 1, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			if err = tx.CreateCampaignEvent(ctx, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// updatedCampaignEventChanges returns the changes UpdateCampaignsByID made
+// to a Campaign, which are recorded by its CampaignEvent.
+func updatedCampaignEventChanges(old, updated *campaigns.Campaign) map[string]campaigns.CampaignEventChange {
+	changes := map[string]campaigns.CampaignEventChange{}
+	if old.Description != updated.Description {
+		changes["description"] = campaigns.CampaignEventChange{Old: old.Description, New: updated.Description}
+	}
+	if old.Branch != updated.Branch {
+		changes["branch"] = campaigns.CampaignEventChange{Old: old.Branch, New: updated.Branch}
+	}
+	if wasClosed, closed := !old.ClosedAt.IsZero(), !updated.ClosedAt.IsZero(); wasClosed != closed {
+		changes["closed"] = campaigns.CampaignEventChange{
+			Old: strconv.FormatBool(wasClosed),
+			New: strconv.FormatBool(closed),
+		}
+	}
+	return changes
+}
+
+// The campaigns table is joined with itself to return the old values of
+// the updated columns along with the new ones.
+var updateCampaignsByIDQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateCampaignsByID
+UPDATE campaigns
+SET %s
+FROM campaigns AS prev
+WHERE campaigns.id = prev.id
+AND campaigns.id IN (%s)
+AND (NOT campaigns.is_locked OR %s)
+RETURNING
+  campaigns.id,
+  prev.description,
+  campaigns.description,
+  prev.branch,
+  campaigns.branch,
+  prev.closed_at,
+  campaigns.closed_at
+`
+
+func (s *Store) updateCampaignsByIDQuery(ctx context.Context, ids []int64, u CampaignsUpdate) *sqlf.Query {
+	var sets []*sqlf.Query
+	if u.Description != nil {
+		sets = append(sets, sqlf.Sprintf("description = %s", *u.Description))
+	}
+	if u.Branch != nil {
+		sets = append(sets, sqlf.Sprintf("branch = %s", *u.Branch))
+	}
+	if u.Closed != nil {
+		if *u.Closed {
+			// Campaigns that are already closed keep their closed_at.
+			sets = append(sets, sqlf.Sprintf("closed_at = COALESCE(campaigns.closed_at, %s)", s.now()))
+		} else {
+			sets = append(sets, sqlf.Sprintf("closed_at = NULL"))
+		}
+	}
+	if len(sets) == 0 || len(ids) == 0 {
+		return nil
+	}
+
+	sets = append(sets, sqlf.Sprintf("updated_at = %s", s.now()))
+	updatedBy := u.UpdatedBy
+	if updatedBy == 0 {
+		updatedBy = actorUserID(ctx, 0)
+	}
+	if updatedBy != 0 {
+		sets = append(sets, sqlf.Sprintf("last_updated_by = NULLIF(%s, campaigns.author_id)", updatedBy))
+	}
+
+	in := make([]*sqlf.Query, 0, len(ids))
+	for _, id := range ids {
+		in = append(in, sqlf.Sprintf("%s", id))
+	}
+
+	return sqlf.Sprintf(
+		updateCampaignsByIDQueryFmtstr,
+		sqlf.Join(sets, ",\n  "),
+		sqlf.Join(in, ","),
+		hasCampaignLockOverride(ctx),
+	)
+}
+
+// CampaignNameCollisionError is returned by ReassignNamespaceOrg with
+// OnConflictFail when Campaigns in the source organization have the same
+// names as Campaigns in the target organization.
+type CampaignNameCollisionError struct {
+	Names []string
+}
+
+func (e *CampaignNameCollisionError) Error() string {
+	return fmt.Sprintf("campaigns with these names already exist in the target namespace: %s", strings.Join(e.Names, ", "))
+}
+
+func (e *CampaignNameCollisionError) Conflict() bool   { return true }
+func (e *CampaignNameCollisionError) NameExists() bool { return true }
+
+// OnConflict determines how ReassignNamespaceOrg handles Campaigns whose
+// names are already taken in the target organization.
+type OnConflict string
+
+// Valid OnConflict policies.
+const (
+	// OnConflictFail moves no Campaigns if any name is taken.
+	OnConflictFail OnConflict = ""
+	// OnConflictSkip leaves the Campaigns whose names are taken in the source
+	// organization and moves the others.
+	OnConflictSkip OnConflict = "skip"
+	// OnConflictSuffix moves all Campaigns, appending " (n)" to the names
+	// that are taken, with the smallest n >= 1 that makes them unique.
+	OnConflictSuffix OnConflict = "suffix"
+)
+
+// CampaignReassignment is the outcome of ReassignNamespaceOrg for a single
+// Campaign.
+type CampaignReassignment struct {
+	CampaignID int64
+	// Name is the name of the Campaign after the reassignment.
+	Name    string
+	Outcome CampaignReassignmentOutcome
+}
+
+// CampaignReassignmentOutcome is what ReassignNamespaceOrg did with a
+// Campaign.
+type CampaignReassignmentOutcome string
+
+// Valid CampaignReassignmentOutcomes.
+const (
+	CampaignReassignmentMoved   CampaignReassignmentOutcome = "moved"
+	CampaignReassignmentRenamed CampaignReassignmentOutcome = "renamed"
+	CampaignReassignmentSkipped CampaignReassignmentOutcome = "skipped"
+)
+
+// ReassignNamespaceOrg moves all Campaigns in the organization fromOrgID to
+// the organization toOrgID and returns the outcome for each of them, ordered
+// by ID. Campaigns whose names are already taken in toOrgID are handled
+// according to onConflict. With OnConflictFail, no Campaigns are moved if any
+// name is taken and a *CampaignNameCollisionError listing the names is
+// returned. If any of the Campaigns to move is locked, none are moved and
+// ErrCampaignLocked is returned (see WithCampaignLockOverride). If any of them
+// has the same IdempotencyKey as a Campaign in toOrgID, none are moved and
+// ErrCampaignIdempotencyKeyExists is returned. If ctx carries an
+// authenticated user, that user is recorded as the moved Campaigns'
+// LastUpdatedBy and as the actor of the CampaignEvents recording the moves.
+func (s *Store) ReassignNamespaceOrg(ctx context.Context, fromOrgID, toOrgID int32, onConflict OnConflict) (rs []*CampaignReassignment, err error) {
+	if fromOrgID == toOrgID {
+		return []*CampaignReassignment{}, nil
+	}
+
+	err = s.transact(ctx, func(tx *Store) (err error) {
+		rs, err = tx.reassignNamespaceOrg(ctx, fromOrgID, toOrgID, onConflict)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (s *Store) reassignNamespaceOrg(ctx context.Context, fromOrgID, toOrgID int32, onConflict OnConflict) ([]*CampaignReassignment, error) {
+	q := sqlf.Sprintf(listReassignedCampaignsQueryFmtstr, toOrgID, fromOrgID, toOrgID)
+
+	var (
+		moving []*campaigns.Campaign
+		taken  []string
+	)
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var (
+			c        campaigns.Campaign
+			existing bool
+		)
+		if err = sc.Scan(&c.ID, &c.Name, &existing); err != nil {
+			return 0, 0, err
+		}
+		if existing {
+			taken = append(taken, c.Name)
+		} else {
+			moving = append(moving, &c)
+		}
+		return c.ID, 1, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := planCampaignReassignments(moving, taken, onConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ids   []int64
+		names []string
+	)
+	for _, r := range rs {
+		if r.Outcome != CampaignReassignmentSkipped {
+			ids = append(ids, r.CampaignID)
+			names = append(names, r.Name)
+		}
+	}
+	if len(ids) == 0 {
+		return rs, nil
+	}
+
+	if err = s.checkCampaignsUnlocked(ctx, sqlf.Sprintf("id = ANY(%s)", pq.Array(ids))); err != nil {
+		return nil, err
+	}
+
+	// Check for idempotency key collisions up front, since a unique index
+	// violation would abort the transaction.
+	q = sqlf.Sprintf(reassignedIdempotencyKeyTakenQueryFmtstr, pq.Array(ids), toOrgID)
+	var keyTaken bool
+	err = s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, sc.Scan(&keyTaken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if keyTaken {
+		return nil, ErrCampaignIdempotencyKeyExists
+	}
+
+	actorID := actorUserID(ctx, 0)
+	q = sqlf.Sprintf(
+		reassignNamespaceOrgQueryFmtstr,
+		toOrgID,
+		s.now(),
+		nullInt32Column(actorID),
+		pq.Array(ids),
+		pq.Array(names),
+		fromOrgID,
+		hasCampaignLockOverride(ctx),
+	)
+
+	moved := make(map[int64]bool, len(ids))
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		if err = sc.Scan(&last); err != nil {
+			return 0, 0, err
+		}
+		moved[last] = true
+		return last, 1, nil
+	})
+	if err != nil {
+		return nil, campaignWriteError(err)
+	}
+
+	oldNames := make(map[int64]string, len(moving))
+	for _, c := range moving {
+		oldNames[c.ID] = c.Name
+	}
+
+	// Campaigns that were deleted or moved elsewhere in the meantime are
+	// omitted.
+	outcomes := rs[:0]
+	for _, r := range rs {
+		if r.Outcome == CampaignReassignmentSkipped {
+			outcomes = append(outcomes, r)
+			continue
+		}
+		if !moved[r.CampaignID] {
+			continue
+		}
+
+		changes := map[string]campaigns.CampaignEventChange{
+			"namespace": {
+				Old: strconv.FormatInt(int64(fromOrgID), 10),
+				New: strconv.FormatInt(int64(toOrgID), 10),
+			},
+		}
+		if old := oldNames[r.CampaignID]; old != r.Name {
+			changes["name"] = campaigns.CampaignEventChange{Old: old, New: r.Name}
+		}
+		err = s.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+			CampaignID: r.CampaignID,
+			ActorID:    actorID,
+			Kind:       campaigns.CampaignEventKindUpdated,
+			Changes:    changes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, r)
+	}
+	return outcomes, nil
+}
+
+var listReassignedCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ReassignNamespaceOrg
+SELECT id, name, namespace_org_id = %s AS existing
+FROM campaigns
+WHERE namespace_org_id IN (%s, %s)
+ORDER BY id ASC
+`
+
+var reassignedIdempotencyKeyTakenQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ReassignNamespaceOrg
+SELECT EXISTS (
+  SELECT 1
+  FROM campaigns AS moving
+  JOIN campaigns AS existing ON existing.idempotency_key = moving.idempotency_key
+  WHERE moving.id = ANY(%s)
+  AND existing.namespace_org_id = %s
+)
+`
+
+var reassignNamespaceOrgQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ReassignNamespaceOrg
+UPDATE campaigns
+SET
+  namespace_org_id = %s,
+  name = batch.name,
+  updated_at = %s,
+  last_updated_by = COALESCE(%s, last_updated_by)
+FROM unnest(%s::bigint[], %s::text[]) AS batch(id, name)
+WHERE campaigns.id = batch.id
+AND campaigns.namespace_org_id = %s
+AND (NOT campaigns.is_locked OR %s)
+RETURNING campaigns.id
+`
+
+// planCampaignReassignments returns the outcomes of moving the given
+// Campaigns to a namespace in which the given names are taken, in the order
+// of moving. It returns a *CampaignNameCollisionError if a name is taken and
+// onConflict is OnConflictFail.
+func planCampaignReassignments(moving []*campaigns.Campaign, taken []string, onConflict OnConflict) ([]*CampaignReassignment, error) {
+	collides := make(map[string]bool, len(taken))
+	for _, name := range taken {
+		collides[name] = true
+	}
+
+	// Suffixed names must not be taken by any Campaign in the target
+	// namespace afterwards, including the moved ones.
+	used := make(map[string]bool, len(taken)+len(moving))
+	for _, name := range taken {
+		used[name] = true
+	}
+	for _, c := range moving {
+		used[c.Name] = true
+	}
+
+	rs := make([]*CampaignReassignment, 0, len(moving))
+	var collisions []string
+	reported := map[string]bool{}
+	for _, c := range moving {
+		r := &CampaignReassignment{CampaignID: c.ID, Name: c.Name, Outcome: CampaignReassignmentMoved}
+		if collides[c.Name] {
+			switch onConflict {
+			case OnConflictSkip:
+				r.Outcome = CampaignReassignmentSkipped
+			case OnConflictSuffix:
+				for n := 1; ; n++ {
+					if name := fmt.Sprintf("%s (%d)", c.Name, n); !used[name] {
+						r.Name = name
+						break
+					}
+				}
+				used[r.Name] = true
+				r.Outcome = CampaignReassignmentRenamed
+			default:
+				if !reported[c.Name] {
+					reported[c.Name] = true
+					collisions = append(collisions, c.Name)
+				}
+			}
+		}
+		rs = append(rs, r)
+	}
+
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return nil, &CampaignNameCollisionError{Names: collisions}
+	}
+	return rs, nil
+}
+
+// ReassignAuthorOnLeave makes newAuthorID the author of all Campaigns in the
+// organization orgID that were authored by leavingUserID, e.g. when that user
+// leaves the organization. Campaigns in other namespaces are left untouched.
+// It returns the number of Campaigns that were reassigned. Each reassignment
+// is recorded as a CampaignEvent. If any of the Campaigns is locked, none are
+// reassigned and ErrCampaignLocked is returned (see
+// WithCampaignLockOverride).
+func (s *Store) ReassignAuthorOnLeave(ctx context.Context, orgID, leavingUserID, newAuthorID int32) (int, error) {
+	actorID := actorUserID(ctx, 0)
+	q := sqlf.Sprintf(
+		reassignAuthorOnLeaveQueryFmtstr,
+		newAuthorID,
+		s.now(),
+		nullInt32Column(actorID),
+		orgID,
+		leavingUserID,
+		hasCampaignLockOverride(ctx),
+	)
+
+	var ids []int64
+	err := s.transact(ctx, func(tx *Store) (err error) {
+		pred := sqlf.Sprintf("namespace_org_id = %s AND author_id = %s", orgID, leavingUserID)
+		if err = tx.checkCampaignsUnlocked(ctx, pred); err != nil {
+			return err
+		}
+
+		_, _, err = tx.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			if err = sc.Scan(&last); err != nil {
+				return 0, 0, err
+			}
+			ids = append(ids, last)
+			return last, 1, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		change := campaigns.CampaignEventChange{
+			Old: strconv.FormatInt(int64(leavingUserID), 10),
+			New: strconv.FormatInt(int64(newAuthorID), 10),
+		}
+		for _, id := range ids {
+			err = tx.CreateCampaignEvent(ctx, &campaigns.CampaignEvent{
+				CampaignID: id,
+				ActorID:    actorID,
+				Kind:       campaigns.CampaignEventKindUpdated,
+				Changes:    map[string]campaigns.CampaignEventChange{"author": change},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+var reassignAuthorOnLeaveQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ReassignAuthorOnLeave
+UPDATE campaigns
+SET
+  author_id = %s,
+  updated_at = %s,
+  last_updated_by = COALESCE(%s, last_updated_by)
+WHERE namespace_org_id = %s
+AND author_id = %s
+AND (NOT is_locked OR %s)
+RETURNING id
+`
+
+// DeleteCampaign deletes the Campaign with the given ID. It returns
+// ErrNoResults if the Campaign doesn't exist and ErrCampaignLocked if it is
+// locked (see WithCampaignLockOverride).
+func (s *Store) DeleteCampaign(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(deleteCampaignQueryFmtstr, id, hasCampaignLockOverride(ctx))
+
+	_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = sc.Scan(&last)
+		return last, 1, err
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return s.lockedOrMissingCampaignError(ctx, id)
+	}
+	return nil
+}
+
+// actorUserID returns the ID of the authenticated user in ctx, or fallback
+// if there is none (e.g. for internal actors).
+func actorUserID(ctx context.Context, fallback int32) int32 {
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		return a.UID
+	}
+	return fallback
+}
+
+var deleteCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteCampaign
+DELETE FROM campaigns WHERE id = %s AND (NOT is_locked OR %s) RETURNING id
+`
+
+// ErrCampaignLocked is returned by UpdateCampaign and DeleteCampaign if the
+// Campaign is locked, and by the methods updating many Campaigns at once if
+// any of them is locked.
+var ErrCampaignLocked = conflictError("campaign is locked")
+
+// WithCampaignLockOverride returns a context with which UpdateCampaign,
+// DeleteCampaign and the methods updating many Campaigns at once also modify
+// locked Campaigns.
+//
+// 🚨 SECURITY: The caller MUST ensure that the current user is a site admin.
+func WithCampaignLockOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, campaignLockOverride, struct{}{})
+}
+
+func hasCampaignLockOverride(ctx context.Context) bool {
+	return ctx.Value(campaignLockOverride) != nil
+}
+
+// checkCampaignsUnlocked returns ErrCampaignLocked if any Campaign matching
+// the given predicate is locked, unless ctx overrides locks (see
+// WithCampaignLockOverride). Methods updating many Campaigns at once call it
+// before updating them, so that they update all of them or none.
+func (s *Store) checkCampaignsUnlocked(ctx context.Context, pred *sqlf.Query) error {
+	if hasCampaignLockOverride(ctx) {
+		return nil
+	}
+
+	q := sqlf.Sprintf(checkCampaignsUnlockedQueryFmtstr, pred)
+
+	var locked bool
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, sc.Scan(&locked)
+	})
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrCampaignLocked
+	}
+	return nil
+}
+
+var checkCampaignsUnlockedQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:checkCampaignsUnlocked
+SELECT EXISTS (SELECT 1 FROM campaigns WHERE %s AND is_locked)
+`
+
+type contextKey int
+
+const campaignLockOverride contextKey = iota
+
+// LockCampaign locks the Campaign with the given ID, so that it can't be
+// updated or deleted until it's unlocked. Locking a locked Campaign is a
+// no-op. It returns ErrNoResults if the Campaign doesn't exist.
+func (s *Store) LockCampaign(ctx context.Context, id int64) error {
+	return s.setCampaignLocked(ctx, id, true)
+}
+
+// UnlockCampaign unlocks the Campaign with the given ID. Unlocking an
+// unlocked Campaign is a no-op. It returns ErrNoResults if the Campaign
+// doesn't exist.
+func (s *Store) UnlockCampaign(ctx context.Context, id int64) error {
+	return s.setCampaignLocked(ctx, id, false)
+}
+
+func (s *Store) setCampaignLocked(ctx context.Context, id int64, locked bool) error {
+	q := sqlf.Sprintf(setCampaignLockedQueryFmtstr, locked, id)
+
+	_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = sc.Scan(&last)
+		return last, 1, err
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var setCampaignLockedQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:setCampaignLocked
+UPDATE campaigns SET is_locked = %s WHERE id = %s RETURNING id
+`
+
+// lockedOrMissingCampaignError returns the error for a write to the Campaign
+// with the given ID that matched no rows: ErrCampaignLocked if the Campaign
+// is locked and ErrNoResults otherwise.
+func (s *Store) lockedOrMissingCampaignError(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(getCampaignLockedQueryFmtstr, id)
+
+	var locked bool
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, sc.Scan(&locked)
+	})
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrCampaignLocked
+	}
+	return ErrNoResults
+}
+
+var getCampaignLockedQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:lockedOrMissingCampaignError
+SELECT is_locked FROM campaigns WHERE id = %s
+`
+
+// CountCampaignsOpts captures the query options needed for
+// counting campaigns.
+type CountCampaignsOpts struct {
+	ChangesetID int64
+	State       campaigns.CampaignState
+	Query       string
+
+	// WholeWord makes the terms of Query only match whole words instead of
+	// any substring.
+	WholeWord bool
+
+	// AccessibleToUserID, if set, limits the Campaigns to those in the
+	// namespace of the user with the given ID and in the namespaces of the
+	// organizations the user is a member of.
+	AccessibleToUserID int32
+
+	// AuthorID, if set, limits the Campaigns to those authored by the user
+	// with the given ID.
+	AuthorID int32
+
+	// CollaboratorUserID, if set, limits the Campaigns to those the user
+	// with the given ID was added to as a collaborator, excluding those they
+	// authored.
+	CollaboratorUserID int32
+
+	// RelatedToUserID, if set, limits the Campaigns to those the user with
+	// the given ID authored or was added to as a collaborator.
+	RelatedToUserID int32
+
+	// HasCollaborators, if set, limits the Campaigns to those with (if true)
+	// or without (if false) collaborators other than their author.
+	HasCollaborators *bool
+
+	// TemplateOnly limits the Campaigns to templates. Templates are
+	// excluded otherwise.
+	TemplateOnly bool
+}
+
+// CountCampaigns returns the number of campaigns in the database.
+func (s *Store) CountCampaigns(ctx context.Context, opts CountCampaignsOpts) (count int64, _ error) {
+	q := countCampaignsQuery(&opts)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CountCampaigns
+SELECT COUNT(id)
+FROM campaigns
+WHERE %s
+`
+
+func countCampaignsQuery(opts *CountCampaignsOpts) *sqlf.Query {
+	preds := campaignsFilterPreds(opts)
+	return sqlf.Sprintf(countCampaignsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// CountDistinctCampaignAuthors returns the number of distinct authors of the
+// Campaigns matching opts.
+func (s *Store) CountDistinctCampaignAuthors(ctx context.Context, opts CountCampaignsOpts) (int64, error) {
+	return s.countDistinctCampaigns(ctx, sqlf.Sprintf("author_id"), &opts)
+}
+
+// CountDistinctCampaignNamespaces returns the number of distinct namespaces
+// (users and organizations) of the Campaigns matching opts.
+func (s *Store) CountDistinctCampaignNamespaces(ctx context.Context, opts CountCampaignsOpts) (int64, error) {
+	return s.countDistinctCampaigns(ctx, sqlf.Sprintf("(namespace_user_id, namespace_org_id)"), &opts)
+}
+
+func (s *Store) countDistinctCampaigns(ctx context.Context, expr *sqlf.Query, opts *CountCampaignsOpts) (count int64, _ error) {
+	q := countDistinctCampaignsQuery(expr, opts)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countDistinctCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:countDistinctCampaigns
+SELECT COUNT(DISTINCT %s)
+FROM campaigns
+WHERE %s
+`
+
+// countDistinctCampaignsQuery returns a query counting the distinct values of
+// expr among the Campaigns matching opts. It applies the same filters as
+// countCampaignsQuery.
+func countDistinctCampaignsQuery(expr *sqlf.Query, opts *CountCampaignsOpts) *sqlf.Query {
+	preds := campaignsFilterPreds(opts)
+	return sqlf.Sprintf(countDistinctCampaignsQueryFmtstr, expr, sqlf.Join(preds, "\n AND "))
+}
+
+// campaignsFilterPreds returns the predicates that filter the Campaigns
+// counted by CountCampaigns and, combined with pagination, listed by
+// ListCampaigns, so that both apply the same filters and the same default
+// exclusions (such as templates).
+func campaignsFilterPreds(opts *CountCampaignsOpts) []*sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_ids ? %s", opts.ChangesetID))
+	}
+
+	switch opts.State {
+	case campaigns.CampaignStateOpen:
+		preds = append(preds, sqlf.Sprintf("closed_at IS NULL"))
+	case campaigns.CampaignStateClosed:
+		preds = append(preds, sqlf.Sprintf("closed_at IS NOT NULL"))
+	}
+
+	preds = append(preds, campaignsSearchQueryPreds(opts.Query, opts.WholeWord)...)
+
+	if opts.AccessibleToUserID != 0 {
+		preds = append(preds, campaignsAccessibleToUserPred(opts.AccessibleToUserID))
+	}
+
+	if opts.AuthorID != 0 {
+		preds = append(preds, sqlf.Sprintf("author_id = %s", opts.AuthorID))
+	}
+
+	if opts.CollaboratorUserID != 0 {
+		preds = append(preds, sqlf.Sprintf(
+			"(author_id <> %s AND id IN (SELECT campaign_id FROM campaign_collaborators WHERE user_id = %s))",
+			opts.CollaboratorUserID,
+			opts.CollaboratorUserID,
+		))
+	}
+
+	if opts.RelatedToUserID != 0 {
+		preds = append(preds, sqlf.Sprintf(
+			"(author_id = %s OR id IN (SELECT campaign_id FROM campaign_collaborators WHERE user_id = %s))",
+			opts.RelatedToUserID,
+			opts.RelatedToUserID,
+		))
+	}
+
+	if opts.HasCollaborators != nil {
+		pred := sqlf.Sprintf("EXISTS (SELECT 1 FROM campaign_collaborators WHERE campaign_id = campaigns.id AND user_id <> campaigns.author_id)")
+		if !*opts.HasCollaborators {
+			pred = sqlf.Sprintf("NOT %s", pred)
+		}
+		preds = append(preds, pred)
+	}
+
+	preds = append(preds, sqlf.Sprintf("is_template = %s", opts.TemplateOnly))
+
+	return preds
+}
+
+// GetCampaignOpts captures the query options needed for getting a Campaign
+type GetCampaignOpts struct {
+	ID             int64
+	CampaignPlanID int64
+
+	// IdempotencyKey, if set, must be combined with the namespace the key is
+	// scoped to.
+	IdempotencyKey  string
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// GetCampaign gets a campaign matching the given options.
+func (s *Store) GetCampaign(ctx context.Context, opts GetCampaignOpts) (*campaigns.Campaign, error) {
+	q := getCampaignQuery(&opts)
+
+	var c campaigns.Campaign
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanCampaign(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetCampaign
+SELECT
+  id,
+  name,
+  description,
+  branch,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at,
+  changeset_ids,
+  campaign_plan_id,
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
+FROM campaigns
+WHERE %s
+LIMIT 1
+`
+
+func getCampaignQuery(opts *GetCampaignOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+
+	if opts.CampaignPlanID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_plan_id = %s", opts.CampaignPlanID))
+	}
+
+	if opts.IdempotencyKey != "" {
+		preds = append(preds, sqlf.Sprintf("idempotency_key = %s", opts.IdempotencyKey))
+	}
+
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", opts.NamespaceUserID))
+	}
+
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(getCampaignsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
+// ListCampaignsOpts captures the query options needed for
+// listing campaigns.
+type ListCampaignsOpts struct {
+	ChangesetID int64
+	Cursor      int64
+	Limit       int
+	State       campaigns.CampaignState
+	Query       string
+
+	// WholeWord makes the terms of Query only match whole words instead of
+	// any substring.
+	WholeWord bool
+
+	// AccessibleToUserID, if set, limits the Campaigns to those in the
+	// namespace of the user with the given ID and in the namespaces of the
+	// organizations the user is a member of.
+	AccessibleToUserID int32
+
+	// AuthorID, if set, limits the Campaigns to those authored by the user
+	// with the given ID.
+	AuthorID int32
+
+	// CollaboratorUserID, if set, limits the Campaigns to those the user
+	// with the given ID was added to as a collaborator, excluding those they
+	// authored.
+	CollaboratorUserID int32
+
+	// RelatedToUserID, if set, limits the Campaigns to those the user with
+	// the given ID authored or was added to as a collaborator.
+	RelatedToUserID int32
+
+	// HasCollaborators, if set, limits the Campaigns to those with (if true)
+	// or without (if false) collaborators other than their author.
+	HasCollaborators *bool
+
+	// TemplateOnly limits the Campaigns to templates. Templates are
+	// excluded otherwise.
+	TemplateOnly bool
+
+	// WithNamespaceName populates the NamespaceName of the listed Campaigns
+	// with the display name (or, if it has none, the name) of their user or
+	// organization namespace. It is DeletedNamespaceName for namespaces that
+	// have been deleted.
+	WithNamespaceName bool
+
+	// OrderBy is the order of the listed Campaigns. The default is
+	// CampaignOrderByID.
+	OrderBy CampaignOrderBy
+}
+
+// CampaignOrderBy is the order in which ListCampaigns lists Campaigns.
+type CampaignOrderBy string
+
+// Valid CampaignOrderBys.
+const (
+	// CampaignOrderByID orders Campaigns by ID, i.e. by creation.
+	CampaignOrderByID CampaignOrderBy = ""
+	// CampaignOrderByManual orders Campaigns in the order set with
+	// Reorder. Campaigns that haven't been reordered are ordered by
+	// creation, after those that were moved to the top.
+	CampaignOrderByManual CampaignOrderBy = "manual"
+)
+
+// DeletedNamespaceName is the NamespaceName of Campaigns whose namespace has
+// been deleted.
+const DeletedNamespaceName = "(deleted)"
+
+// ListCampaigns lists Campaigns with the given filters.
+func (s *Store) ListCampaigns(ctx context.Context, opts ListCampaignsOpts) (cs []*campaigns.Campaign, next int64, err error) {
+	q := listCampaignsQuery(&opts)
+
+	cs = make([]*campaigns.Campaign, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var c campaigns.Campaign
+		if opts.WithNamespaceName {
+			sc = extraColumnsScanner{scanner: sc, dst: []interface{}{&c.NamespaceName}}
+		}
+		if err = scanCampaign(&c, sc); err != nil {
+			return 0, 0, err
+		}
+		cs = append(cs, &c)
+		return c.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(cs) == opts.Limit {
+		next = cs[len(cs)-1].ID
+		cs = cs[:len(cs)-1]
+	}
+
+	return cs, next, err
+}
+
+// ListCampaignsByAuthor lists the Campaigns authored by the user with the
+// given authorID across all namespaces, ordered by ID. Campaign templates are
+// omitted.
+//
+// 🚨 SECURITY: Only Campaigns in namespaces the user with the given viewerID
+// can access are returned, i.e. the viewer's own user namespace and the
+// namespaces of organizations the viewer is a member of. No Campaigns are
+// returned for a viewerID of 0. The caller must ensure that viewerID is the
+// ID of the current user.
+func (s *Store) ListCampaignsByAuthor(ctx context.Context, authorID, viewerID int32) ([]*campaigns.Campaign, error) {
+	cs := []*campaigns.Campaign{}
+	if viewerID == 0 {
+		return cs, nil
+	}
+
+	opts := ListCampaignsOpts{
+		AuthorID:           authorID,
+		AccessibleToUserID: viewerID,
+		Limit:              maxCampaignsListLimit(),
+	}
+	for {
+		page, next, err := s.ListCampaigns(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, page...)
+		if next == 0 {
+			return cs, nil
+		}
+		opts.Cursor = next
+	}
+}
+
+var listCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaigns
+SELECT
+  id,
+  name,
+  description,
+  branch,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at,
+  changeset_ids,
+  campaign_plan_id,
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
+FROM campaigns
+WHERE %s
+ORDER BY %s
+LIMIT %s
+`
+
+// CountOpts returns the CountCampaignsOpts that count the Campaigns listed
+// with o, across all pages. Callers counting the results of a listing must
+// use it, so that the count and the listing apply the same filters.
+func (o *ListCampaignsOpts) CountOpts() *CountCampaignsOpts {
+	return &CountCampaignsOpts{
+		ChangesetID:        o.ChangesetID,
+		State:              o.State,
+		Query:              o.Query,
+		WholeWord:          o.WholeWord,
+		AccessibleToUserID: o.AccessibleToUserID,
+		AuthorID:           o.AuthorID,
+		CollaboratorUserID: o.CollaboratorUserID,
+		RelatedToUserID:    o.RelatedToUserID,
+		HasCollaborators:   o.HasCollaborators,
+		TemplateOnly:       o.TemplateOnly,
+	}
+}
+
+func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
+	if opts.Limit <= 0 {
+		opts.Limit = defaultListLimit
+	}
+	if max := maxCampaignsListLimit(); opts.Limit > max {
+		opts.Limit = max
+	}
+	opts.Limit++
+
+	var preds []*sqlf.Query
+	orderBy := sqlf.Sprintf("id ASC")
+	outerOrderBy := sqlf.Sprintf("campaigns.id ASC")
+	switch opts.OrderBy {
+	case CampaignOrderByManual:
+		if opts.Cursor != 0 {
+			preds = append(preds, sqlf.Sprintf("(sort_key, id) >= (SELECT sort_key, id FROM campaigns WHERE id = %s)", opts.Cursor))
+		}
+		orderBy = sqlf.Sprintf("sort_key ASC, id ASC")
+		// The FROM clause refers to the campaigns table, not to the
+		// campaigns subquery, which doesn't select sort_key.
+		outerOrderBy = sqlf.Sprintf("(SELECT sort_key FROM campaigns AS c WHERE c.id = campaigns.id) ASC, campaigns.id ASC")
+	default:
+		preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+	}
+	preds = append(preds, campaignsFilterPreds(opts.CountOpts())...)
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	q := sqlf.Sprintf(
+		listCampaignsQueryFmtstr,
+		sqlf.Join(preds, "\n AND "),
+		orderBy,
+		opts.Limit,
+	)
+	if opts.WithNamespaceName {
+		q = sqlf.Sprintf(listCampaignsWithNamespaceNameQueryFmtstr, DeletedNamespaceName, q, outerOrderBy)
+	}
+	return q
+}
+
+// listCampaignsWithNamespaceNameQueryFmtstr wraps listCampaignsQueryFmtstr,
+// so that joining the namespaces doesn't make its columns ambiguous.
+var listCampaignsWithNamespaceNameQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaigns
+SELECT
+  campaigns.*,
+  COALESCE(
+    NULLIF(users.display_name, ''),
+    users.username::text,
+    NULLIF(orgs.display_name, ''),
+    orgs.name::text,
+    %s
+  )
+FROM (%s) AS campaigns
+LEFT JOIN users ON users.id = campaigns.namespace_user_id AND users.deleted_at IS NULL
+LEFT JOIN orgs ON orgs.id = campaigns.namespace_org_id AND orgs.deleted_at IS NULL
+ORDER BY %s
+`
+
+// FindSimilarCampaignsOpts captures the query options needed for finding
+// Campaigns with names similar to a given one.
+type FindSimilarCampaignsOpts struct {
+	Name            string
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Limit           int
+}
+
+// FindSimilarCampaigns returns the Campaigns in the given namespace whose
+// names are similar to the given name, using trigram similarity. The most
+// similar Campaigns are returned first.
+func (s *Store) FindSimilarCampaigns(ctx context.Context, opts FindSimilarCampaignsOpts) ([]*campaigns.Campaign, error) {
+	q := findSimilarCampaignsQuery(&opts)
+
+	cs := make([]*campaigns.Campaign, 0, opts.Limit)
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var c campaigns.Campaign
+		if err = scanCampaign(&c, sc); err != nil {
+			return 0, 0, err
+		}
+		cs = append(cs, &c)
+		return c.ID, 1, err
+	})
+
+	return cs, err
+}
+
+// similarCampaignsThreshold is the minimum trigram similarity, between 0 and
+// 1, of the names of Campaigns returned by FindSimilarCampaigns. It matches
+// the default of pg_trgm's similarity_threshold.
+const similarCampaignsThreshold = 0.3
+
+var findSimilarCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:FindSimilarCampaigns
+SELECT
+  id,
+  name,
+  description,
+  branch,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at,
+  changeset_ids,
+  campaign_plan_id,
+  closed_at,
+  last_updated_by,
+  idempotency_key,
+  is_template,
+  is_locked
+FROM campaigns
+WHERE %s
+ORDER BY similarity(name, %s) DESC, id ASC
+LIMIT %s
+`
+
+func findSimilarCampaignsQuery(opts *FindSimilarCampaignsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("similarity(name, %s) >= %s", opts.Name, similarCampaignsThreshold),
+	}
+
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", opts.NamespaceUserID))
+	}
+
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+
+	return sqlf.Sprintf(
+		findSimilarCampaignsQueryFmtstr,
+		sqlf.Join(preds, "\n AND "),
+		opts.Name,
+		opts.Limit,
+	)
+}
+
+// campaignsSearchQueryPreds returns the predicates matching campaigns
+// against the given search query. Every term must match the name or
+// description of a campaign. Terms prefixed with "-" exclude campaigns whose
+// name or description match the rest of the term. A leading "-" can be
+// matched literally by escaping it as "\-".
+//
+// By default, terms match any substring. If wholeWord is set, they only match
+// whole words, i.e. they must be delimited by non-word characters or the
+// start or end of the text.
+//
+// The ILIKE conditions of terms with at least 3 characters are served by the
+// campaigns_name_trgm and campaigns_description_trgm trigram indexes, so
+// that searches don't scan all campaigns. Shorter terms and exclusions can't
+// use them.
+func campaignsSearchQueryPreds(query string, wholeWord bool) []*sqlf.Query {
+	include, exclude := parseCampaignsSearchQuery(query)
+
+	match, noMatch := "ILIKE", "NOT ILIKE"
+	pattern := func(term string) string {
+		return "%" + escapeLikePattern(term) + "%"
+	}
+	if wholeWord {
+		// \m and \M match the beginning and end of a word.
+		match, noMatch = "~*", "!~*"
+		pattern = func(term string) string {
+			return `\m` + regexp.QuoteMeta(term) + `\M`
+		}
+	}
+
+	preds := make([]*sqlf.Query, 0, len(include)+len(exclude))
+	for _, term := range include {
+		p := pattern(term)
+		preds = append(preds, sqlf.Sprintf("(name "+match+" %s OR description "+match+" %s)", p, p))
+	}
+	for _, term := range exclude {
+		p := pattern(term)
+		preds = append(preds, sqlf.Sprintf("(name "+noMatch+" %s AND description "+noMatch+" %s)", p, p))
+	}
+	return preds
+}
+
+// campaignsAccessibleToUserPred returns a predicate matching the Campaigns in
+// the namespace of the user with the given ID and in the namespaces of the
+// organizations the user is a member of.
+func campaignsAccessibleToUserPred(userID int32) *sqlf.Query {
+	return sqlf.Sprintf(
+		"(namespace_user_id = %s OR namespace_org_id IN (SELECT org_id FROM org_members WHERE user_id = %s))",
+		userID,
+		userID,
+	)
+}
+
+// parseCampaignsSearchQuery splits the given search query into the terms
+// that must be included and those that must be excluded.
+func parseCampaignsSearchQuery(query string) (include, exclude []string) {
+	for _, term := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(term, `\-`):
+			include = append(include, term[1:])
+		case strings.HasPrefix(term, "-"):
+			if term = term[1:]; term != "" {
+				exclude = append(exclude, term)
+			}
+		default:
+			include = append(include, term)
+		}
+	}
+	return include, exclude
+}
+
+// The fields of a Campaign that search queries are matched against.
+const (
+	CampaignSearchFieldName        = "name"
+	CampaignSearchFieldDescription = "description"
+)
+
+// CampaignSearchMatch is a match of a search query term in a field of a
+// Campaign. Offset and Length are measured in characters (runes), not bytes.
+type CampaignSearchMatch struct {
+	Field  string
+	Offset int
+	Length int
+}
+
+// CampaignSearchMatches returns where the terms of the given search query
+// match the name and description of c, so that they can be highlighted in
+// search results. Like ListCampaigns, it matches terms case-insensitively
+// and, if wholeWord is set, only as whole words. Excluded terms don't match
+// anything. The matches are ordered by field (name first) and offset.
+//
+// The matches are computed from the already fetched Campaign rather than by
+// the database.
+func CampaignSearchMatches(c *campaigns.Campaign, query string, wholeWord bool) []CampaignSearchMatch {
+	include, _ := parseCampaignsSearchQuery(query)
+
+	var matches []CampaignSearchMatch
+	for _, f := range []struct{ name, value string }{
+		{CampaignSearchFieldName, c.Name},
+		{CampaignSearchFieldDescription, c.Description},
+	} {
+		start := len(matches)
+		value := []rune(f.value)
+		for _, term := range include {
+			n := utf8.RuneCountInString(term)
+			for i := 0; i+n <= len(value); i++ {
+				if wholeWord && !isWordBoundary(value, i, i+n) {
+					continue
+				}
+				if strings.EqualFold(string(value[i:i+n]), term) {
+					matches = append(matches, CampaignSearchMatch{Field: f.name, Offset: i, Length: n})
+					i += n - 1
+				}
+			}
+		}
+		fieldMatches := matches[start:]
+		sort.SliceStable(fieldMatches, func(i, j int) bool {
+			return fieldMatches[i].Offset < fieldMatches[j].Offset
+		})
+	}
+	return matches
+}
+
+// isWordBoundary reports whether value[start:end] starts and ends with a
+// word character and is delimited by non-word characters or the start or end
+// of value, like the \m and \M regular expression constraints of
+// PostgreSQL.
+func isWordBoundary(value []rune, start, end int) bool {
+	if start == end || !isWordRune(value[start]) || !isWordRune(value[end-1]) {
+		return false
+	}
+	return (start == 0 || !isWordRune(value[start-1])) && (end == len(value) || !isWordRune(value[end]))
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// escapeLikePattern escapes the characters of s that have a special meaning
+// in LIKE patterns.
+func escapeLikePattern(s string) string {
+	return likePatternEscaper.Replace(s)
+}
+
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetNamespaceCampaignStats returns the number of Campaigns in the namespace
+// of the given user or organization, broken down by state and publication
+// status. All counts are zero if the namespace has no Campaigns.
+//
+// A Campaign is a draft if it has a CampaignPlan for which not all
+// ChangesetJobs have been created yet, i.e. if it has no PublishedAt date.
+// Campaign templates are not counted.
+func (s *Store) GetNamespaceCampaignStats(ctx context.Context, namespaceUserID, namespaceOrgID int32) (*campaigns.CampaignStats, error) {
+	q := getNamespaceCampaignStatsQuery(namespaceUserID, namespaceOrgID)
+
+	var stats campaigns.CampaignStats
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(
+			&stats.Total,
+			&stats.Open,
+			&stats.Closed,
+			&stats.Draft,
+			&stats.Published,
+		)
+		return 0, 1, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+var getNamespaceCampaignStatsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetNamespaceCampaignStats
+SELECT
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE closed_at IS NULL) AS open,
+  COUNT(*) FILTER (WHERE closed_at IS NOT NULL) AS closed,
+  COUNT(*) FILTER (WHERE NOT published) AS draft,
+  COUNT(*) FILTER (WHERE published) AS published
+FROM (
+  SELECT
+    closed_at,
+    campaign_plan_id IS NULL OR (
+      EXISTS (
+        SELECT 1 FROM changeset_jobs
+        WHERE changeset_jobs.campaign_id = campaigns.id
+      ) AND NOT EXISTS (
+        SELECT 1 FROM campaign_jobs
+        LEFT JOIN changeset_jobs
+          ON changeset_jobs.campaign_job_id = campaign_jobs.id
+          AND changeset_jobs.campaign_id = campaigns.id
+        WHERE campaign_jobs.campaign_plan_id = campaigns.campaign_plan_id
+          AND changeset_jobs.id IS NULL
+      )
+    ) AS published
+  FROM campaigns
+  WHERE %s
+  AND NOT is_template
+) AS namespace_campaigns
+`
+
+func getNamespaceCampaignStatsQuery(namespaceUserID, namespaceOrgID int32) *sqlf.Query {
+	var pred *sqlf.Query
+	if namespaceUserID != 0 {
+		pred = sqlf.Sprintf("namespace_user_id = %s", namespaceUserID)
+	} else {
+		pred = sqlf.Sprintf("namespace_org_id = %s", namespaceOrgID)
+	}
+
+	return sqlf.Sprintf(getNamespaceCampaignStatsQueryFmtstr, pred)
+}
+
+// SubscribeToCampaign subscribes the user with the given ID to updates of
+// the Campaign with the given ID. Subscribing a user that is already
+// subscribed is a no-op.
+func (s *Store) SubscribeToCampaign(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(subscribeToCampaignQueryFmtstr, campaignID, userID, s.now())
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+var subscribeToCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:SubscribeToCampaign
+INSERT INTO campaign_subscribers (campaign_id, user_id, created_at)
+VALUES (%s, %s, %s)
+ON CONFLICT ON CONSTRAINT campaign_subscribers_campaign_id_user_id_unique
+DO NOTHING
+`
+
+// UnsubscribeFromCampaign removes the subscription of the user with the
+// given ID to the Campaign with the given ID, if any.
+func (s *Store) UnsubscribeFromCampaign(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(unsubscribeFromCampaignQueryFmtstr, campaignID, userID)
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+var unsubscribeFromCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UnsubscribeFromCampaign
+DELETE FROM campaign_subscribers WHERE campaign_id = %s AND user_id = %s
+`
+
+// ListCampaignSubscribers returns the IDs of the users subscribed to the
+// Campaign with the given ID, ordered by user ID.
+func (s *Store) ListCampaignSubscribers(ctx context.Context, campaignID int64) ([]int32, error) {
+	q := sqlf.Sprintf(listCampaignSubscribersQueryFmtstr, campaignID)
+
+	userIDs := []int32{}
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		var id int32
+		if err = sc.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+		userIDs = append(userIDs, id)
+		return int64(id), 1, nil
+	})
+
+	return userIDs, err
+}
+
+var listCampaignSubscribersQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaignSubscribers
+SELECT user_id
+FROM campaign_subscribers
+WHERE campaign_id = %s
+ORDER BY user_id ASC
+`
+
+// AddCollaborator adds the user with the given ID as a collaborator on the
+// Campaign with the given ID. Adding a user that is already a collaborator is
+// a no-op.
+func (s *Store) AddCollaborator(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(addCollaboratorQueryFmtstr, campaignID, userID, s.now())
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+var addCollaboratorQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:AddCollaborator
+INSERT INTO campaign_collaborators (campaign_id, user_id, created_at)
+VALUES (%s, %s, %s)
+ON CONFLICT ON CONSTRAINT campaign_collaborators_campaign_id_user_id_unique
+DO NOTHING
+`
+
+// RemoveCollaborator removes the user with the given ID from the
+// collaborators of the Campaign with the given ID, if they are one. The
+// author of a Campaign can't be removed, since they are implicitly a
+// collaborator.
+func (s *Store) RemoveCollaborator(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(removeCollaboratorQueryFmtstr, campaignID, userID)
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+var removeCollaboratorQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:RemoveCollaborator
+DELETE FROM campaign_collaborators WHERE campaign_id = %s AND user_id = %s
+`
+
+// ListCollaborators returns the IDs of the collaborators on the Campaign with
+// the given ID, ordered by user ID. The author of the Campaign is always
+// included.
+func (s *Store) ListCollaborators(ctx context.Context, campaignID int64) ([]int32, error) {
+	q := sqlf.Sprintf(listCollaboratorsQueryFmtstr, campaignID, campaignID)
+
+	userIDs := []int32{}
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		var id int32
+		if err = sc.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+		userIDs = append(userIDs, id)
+		return int64(id), 1, nil
+	})
+
+	return userIDs, err
+}
+
+var listCollaboratorsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCollaborators
+SELECT author_id AS user_id FROM campaigns WHERE id = %s
+UNION
+SELECT user_id FROM campaign_collaborators WHERE campaign_id = %s
+ORDER BY user_id ASC
+`
+
+// ErrCampaignSelfDependency is returned by AddDependency if a Campaign would
+// depend on itself.
+var ErrCampaignSelfDependency = invalidInputError("a campaign can't depend on itself")
+
+// ErrCampaignDependencyCycle is returned by AddDependency if the dependency
+// would create a cycle.
+var ErrCampaignDependencyCycle = conflictError("the campaign dependency would create a cycle")
+
+// AddDependency records that the Campaign with the given campaignID depends
+// on (i.e. is blocked by) the Campaign with the given dependsOnID. Adding an
+// existing dependency is a no-op.
+//
+// Before inserting the dependency, it checks whether the Campaign with the
+// given dependsOnID already depends on the other one, directly or
+// transitively, and returns ErrCampaignDependencyCycle if so. The check and
+// the insert run in one transaction that holds an advisory lock on the
+// dependency graph, so that two concurrent calls can't each pass the check
+// and together create a cycle.
+func (s *Store) AddDependency(ctx context.Context, campaignID, dependsOnID int64) error {
+	if campaignID == dependsOnID {
+		return ErrCampaignSelfDependency
+	}
+
+	return s.transact(ctx, func(tx *Store) error {
+		q := sqlf.Sprintf(lockCampaignDependenciesQueryFmtstr, lockNamespace, campaignDependenciesLockID)
+		rows, err := tx.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		if err != nil {
+			return err
+		}
+		if err = rows.Close(); err != nil {
+			return err
+		}
+
+		q = sqlf.Sprintf(campaignDependsOnQueryFmtstr, dependsOnID, campaignID)
+		var cyclic bool
+		err = tx.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+			return 0, 0, sc.Scan(&cyclic)
+		})
+		if err != nil {
+			return err
+		}
+		if cyclic {
+			return ErrCampaignDependencyCycle
+		}
+
+		q = sqlf.Sprintf(addDependencyQueryFmtstr, campaignID, dependsOnID, tx.now())
+
+		rows, err = tx.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		if err != nil {
+			return err
+		}
+		return rows.Close()
+	})
+}
+
+// campaignDependenciesLockID is the advisory lock ID, within lockNamespace,
+// that serializes writes to the campaign dependency graph.
+var campaignDependenciesLockID = int32(fnv1.HashString32("campaign_dependencies"))
+
+var lockCampaignDependenciesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:AddDependency
+SELECT pg_advisory_xact_lock(%s, %s)
+`
+
+var campaignDependsOnQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:AddDependency
+WITH RECURSIVE dependencies(id) AS (
+  SELECT depends_on_id FROM campaign_dependencies WHERE campaign_id = %s
+  UNION
+  SELECT campaign_dependencies.depends_on_id
+  FROM campaign_dependencies
+  JOIN dependencies ON campaign_dependencies.campaign_id = dependencies.id
+)
+SELECT EXISTS (SELECT 1 FROM dependencies WHERE id = %s)
+`
+
+var addDependencyQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:AddDependency
+INSERT INTO campaign_dependencies (campaign_id, depends_on_id, created_at)
+VALUES (%s, %s, %s)
+ON CONFLICT ON CONSTRAINT campaign_dependencies_campaign_id_depends_on_id_unique
+DO NOTHING
+`
+
+// RemoveDependency removes the dependency of the Campaign with the given
+// campaignID on the Campaign with the given dependsOnID, if any.
+func (s *Store) RemoveDependency(ctx context.Context, campaignID, dependsOnID int64) error {
+	q := sqlf.Sprintf(removeDependencyQueryFmtstr, campaignID, dependsOnID)
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+var removeDependencyQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:RemoveDependency
+DELETE FROM campaign_dependencies WHERE campaign_id = %s AND depends_on_id = %s
+`
+
+// ListDependencies returns the IDs of the Campaigns that the Campaign with
+// the given ID directly depends on, ordered by ID.
+func (s *Store) ListDependencies(ctx context.Context, campaignID int64) ([]int64, error) {
+	q := sqlf.Sprintf(listDependenciesQueryFmtstr, campaignID)
+
+	ids := []int64{}
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		var id int64
+		if err = sc.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+		ids = append(ids, id)
+		return id, 1, nil
+	})
+
+	return ids, err
+}
+
+var listDependenciesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListDependencies
+SELECT depends_on_id
+FROM campaign_dependencies
+WHERE campaign_id = %s
+ORDER BY depends_on_id ASC
+`
+
+// campaignSortKeyGap is the distance between the sort keys of consecutive
+// Campaigns after they are created or renumbered. It must match the default
+// of the campaigns.sort_key column.
+const campaignSortKeyGap = 1 << 16
+
+// Reorder moves the Campaign with the given ID in the manual order
+// (see CampaignOrderByManual) to right after the Campaign with the given
+// afterID, or to the top if afterID is nil. It returns ErrNoResults if either
+// Campaign doesn't exist.
+//
+// Only the sort key of the moved Campaign changes: it is set halfway between
+// the sort keys of its new neighbours. All Campaigns are renumbered only when
+// there is no gap left between the neighbours.
+//
+// It runs in a transaction holding a lock on the campaigns table that
+// conflicts with itself and with concurrent writes to the table, so that
+// concurrent calls can't compute the same sort key or interleave with a
+// renumbering.
+func (s *Store) Reorder(ctx context.Context, id int64, afterID *int64) error {
+	if afterID != nil && *afterID == id {
+		return ErrCampaignReorderSelf
+	}
+
+	return s.transact(ctx, func(tx *Store) error {
+		q := sqlf.Sprintf(lockCampaignsForReorderQueryFmtstr)
+		rows, err := tx.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		if err != nil {
+			return err
+		}
+		if err = rows.Close(); err != nil {
+			return err
+		}
+		return tx.reorder(ctx, id, afterID)
+	})
+}
+
+var lockCampaignsForReorderQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:Reorder
+LOCK TABLE campaigns IN SHARE ROW EXCLUSIVE MODE
+`
+
+// reorder implements Reorder. The campaigns table must be locked.
+func (s *Store) reorder(ctx context.Context, id int64, afterID *int64) error {
+	if afterID == nil {
+		q := sqlf.Sprintf(moveCampaignToTopQueryFmtstr, id, campaignSortKeyGap, id)
+		_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			err = sc.Scan(&last)
+			return last, 1, err
+		})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrNoResults
+		}
+		return nil
+	}
+
+	for renumbered := false; ; renumbered = true {
+		q := sqlf.Sprintf(moveCampaignAfterQueryFmtstr, *afterID, id, campaignSortKeyGap, id)
+		_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			err = sc.Scan(&last)
+			return last, 1, err
+		})
+		if err != nil {
+			return err
+		}
+		if count != 0 {
+			return nil
+		}
+
+		// Nothing was updated, either because a Campaign doesn't exist or
+		// because the sort keys of the neighbours are adjacent.
+		q = sqlf.Sprintf(countCampaignsByIDQueryFmtstr, id, *afterID)
+		_, count, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+			err = sc.Scan(&last)
+			return last, 1, err
+		})
+		if err != nil {
+			return err
+		}
+		if count < 2 {
+			return ErrNoResults
+		}
+		if renumbered {
+			return errors.New("no gap between campaign sort keys after renumbering")
+		}
+		if err = s.renumberCampaigns(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// ErrCampaignReorderSelf is returned by Reorder if a Campaign would
+// be moved after itself.
+var ErrCampaignReorderSelf = invalidInputError("a campaign can't be moved after itself")
+
+var moveCampaignToTopQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:Reorder
+UPDATE campaigns
+SET sort_key = COALESCE((SELECT MIN(sort_key) FROM campaigns WHERE id <> %s) - %s, sort_key)
+WHERE id = %s
+RETURNING id
+`
+
+// moveCampaignAfterQueryFmtstr moves a Campaign halfway between the Campaign
+// it's moved after and the next one. If there is no next one, it's moved to
+// the end like a new Campaign would be, so that Campaigns created later are
+// still ordered after it.
+var moveCampaignAfterQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:Reorder
+WITH after AS (
+  SELECT sort_key FROM campaigns WHERE id = %s
+),
+next AS (
+  SELECT MIN(campaigns.sort_key) AS sort_key
+  FROM campaigns, after
+  WHERE campaigns.sort_key > after.sort_key AND campaigns.id <> %s
+)
+UPDATE campaigns
+SET sort_key = CASE
+  WHEN next.sort_key IS NULL THEN nextval('campaigns_sort_key_seq') * %s
+  ELSE after.sort_key + (next.sort_key - after.sort_key) / 2
+END
+FROM after, next
+WHERE campaigns.id = %s
+AND (next.sort_key IS NULL OR next.sort_key - after.sort_key > 1)
+RETURNING campaigns.id
+`
+
+var countCampaignsByIDQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:Reorder
+SELECT id FROM campaigns WHERE id IN (%s, %s)
+`
+
+// renumberCampaigns spaces the sort keys of all Campaigns campaignSortKeyGap
+// apart again, keeping their order. The campaigns table must be locked (see
+// Reorder).
+func (s *Store) renumberCampaigns(ctx context.Context) error {
+	for _, q := range []*sqlf.Query{
+		sqlf.Sprintf(renumberCampaignsQueryFmtstr, campaignSortKeyGap),
+		sqlf.Sprintf(resetCampaignSortKeySequenceQueryFmtstr),
+	} {
+		rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		if err != nil {
+			return err
+		}
+		if err = rows.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var renumberCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:renumberCampaigns
+WITH ranked AS (
+  SELECT id, row_number() OVER (ORDER BY sort_key ASC, id ASC) AS rank
+  FROM campaigns
+)
+UPDATE campaigns
+SET sort_key = ranked.rank * %s
+FROM ranked
+WHERE campaigns.id = ranked.id
+`
+
+var resetCampaignSortKeySequenceQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:renumberCampaigns
+SELECT setval('campaigns_sort_key_seq', (SELECT COUNT(*) FROM campaigns) + 1, false)
+`
+
+// PinCampaign pins the Campaign with the given ID for the user with the
+// given ID. Pins are per user. Pinning an already pinned Campaign is a no-op.
+func (s *Store) PinCampaign(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(pinCampaignQueryFmtstr, campaignID, userID, s.now())
 
 	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
 	if err != nil {
@@ -1179,196 +3248,362 @@ func (s *Store) DeleteCampaign(ctx context.Context, id int64) error {
 	return rows.Close()
 }
 
-var deleteCampaignQueryFmtstr = `
--- source: enterprise/internal/campaigns/store.go:DeleteCampaign
-DELETE FROM campaigns WHERE id = %s
+var pinCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:PinCampaign
+INSERT INTO campaign_pins (campaign_id, user_id, created_at)
+VALUES (%s, %s, %s)
+ON CONFLICT ON CONSTRAINT campaign_pins_campaign_id_user_id_unique
+DO NOTHING
 `
 
-// CountCampaignsOpts captures the query options needed for
-// counting campaigns.
-type CountCampaignsOpts struct {
-	ChangesetID int64
-	State       campaigns.CampaignState
+// UnpinCampaign removes the pin of the Campaign with the given ID for the
+// user with the given ID, if any.
+func (s *Store) UnpinCampaign(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(unpinCampaignQueryFmtstr, campaignID, userID)
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
 }
 
-// CountCampaigns returns the number of campaigns in the database.
-func (s *Store) CountCampaigns(ctx context.Context, opts CountCampaignsOpts) (count int64, _ error) {
-	q := countCampaignsQuery(&opts)
-	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
-		err = sc.Scan(&count)
-		return 0, count, err
+var unpinCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UnpinCampaign
+DELETE FROM campaign_pins WHERE campaign_id = %s AND user_id = %s
+`
+
+// ListPinnedCampaigns lists the Campaigns pinned by the user with the given
+// ID, in the order they were pinned. Campaign templates are omitted.
+//
+// Unlike ListCampaigns, it doesn't paginate, since the results are ordered by
+// pin time rather than by ID.
+func (s *Store) ListPinnedCampaigns(ctx context.Context, userID int32) ([]*campaigns.Campaign, error) {
+	q := sqlf.Sprintf(listPinnedCampaignsQueryFmtstr, userID)
+
+	cs := []*campaigns.Campaign{}
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var c campaigns.Campaign
+		if err = scanCampaign(&c, sc); err != nil {
+			return 0, 0, err
+		}
+		cs = append(cs, &c)
+		return c.ID, 1, err
 	})
+
+	return cs, err
 }
 
-var countCampaignsQueryFmtstr = `
--- source: enterprise/internal/campaigns/store.go:CountCampaigns
-SELECT COUNT(id)
+var listPinnedCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListPinnedCampaigns
+SELECT
+  campaigns.id,
+  campaigns.name,
+  campaigns.description,
+  campaigns.branch,
+  campaigns.author_id,
+  campaigns.namespace_user_id,
+  campaigns.namespace_org_id,
+  campaigns.created_at,
+  campaigns.updated_at,
+  campaigns.changeset_ids,
+  campaigns.campaign_plan_id,
+  campaigns.closed_at,
+  campaigns.last_updated_by,
+  campaigns.idempotency_key,
+  campaigns.is_template,
+  campaigns.is_locked
 FROM campaigns
-WHERE %s
+JOIN campaign_pins ON campaign_pins.campaign_id = campaigns.id
+WHERE campaign_pins.user_id = %s
+AND NOT campaigns.is_template
+ORDER BY campaign_pins.created_at ASC, campaigns.id ASC
 `
 
-func countCampaignsQuery(opts *CountCampaignsOpts) *sqlf.Query {
-	var preds []*sqlf.Query
-	if opts.ChangesetID != 0 {
-		preds = append(preds, sqlf.Sprintf("changeset_ids ? %s", opts.ChangesetID))
-	}
+// RecordCampaignView records that the user with the given ID viewed the
+// Campaign with the given ID now. Only the most recent view of a Campaign is
+// kept per user, so viewing it again moves it to the top of the user's
+// recently viewed Campaigns.
+func (s *Store) RecordCampaignView(ctx context.Context, campaignID int64, userID int32) error {
+	q := sqlf.Sprintf(recordCampaignViewQueryFmtstr, campaignID, userID, s.now())
 
-	switch opts.State {
-	case campaigns.CampaignStateOpen:
-		preds = append(preds, sqlf.Sprintf("closed_at IS NULL"))
-	case campaigns.CampaignStateClosed:
-		preds = append(preds, sqlf.Sprintf("closed_at IS NOT NULL"))
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
 	}
+	return rows.Close()
+}
 
-	if len(preds) == 0 {
-		preds = append(preds, sqlf.Sprintf("TRUE"))
+var recordCampaignViewQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:RecordCampaignView
+INSERT INTO campaign_views (campaign_id, user_id, viewed_at)
+VALUES (%s, %s, %s)
+ON CONFLICT ON CONSTRAINT campaign_views_campaign_id_user_id_unique
+DO UPDATE SET viewed_at = GREATEST(campaign_views.viewed_at, EXCLUDED.viewed_at)
+`
+
+// ListRecentlyViewedCampaigns lists at most limit Campaigns viewed by the
+// user with the given ID, most recently viewed first. Campaign templates are
+// omitted.
+func (s *Store) ListRecentlyViewedCampaigns(ctx context.Context, userID int32, limit int) ([]*campaigns.Campaign, error) {
+	if limit <= 0 {
+		return []*campaigns.Campaign{}, nil
 	}
 
-	return sqlf.Sprintf(countCampaignsQueryFmtstr, sqlf.Join(preds, "\n AND "))
-}
+	q := sqlf.Sprintf(listRecentlyViewedCampaignsQueryFmtstr, userID, limit)
 
-// GetCampaignOpts captures the query options needed for getting a Campaign
-type GetCampaignOpts struct {
-	ID             int64
-	CampaignPlanID int64
+	cs := make([]*campaigns.Campaign, 0, limit)
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var c campaigns.Campaign
+		if err = scanCampaign(&c, sc); err != nil {
+			return 0, 0, err
+		}
+		cs = append(cs, &c)
+		return c.ID, 1, err
+	})
+
+	return cs, err
 }
 
-// GetCampaign gets a campaign matching the given options.
-func (s *Store) GetCampaign(ctx context.Context, opts GetCampaignOpts) (*campaigns.Campaign, error) {
-	q := getCampaignQuery(&opts)
+var listRecentlyViewedCampaignsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListRecentlyViewedCampaigns
+SELECT
+  campaigns.id,
+  campaigns.name,
+  campaigns.description,
+  campaigns.branch,
+  campaigns.author_id,
+  campaigns.namespace_user_id,
+  campaigns.namespace_org_id,
+  campaigns.created_at,
+  campaigns.updated_at,
+  campaigns.changeset_ids,
+  campaigns.campaign_plan_id,
+  campaigns.closed_at,
+  campaigns.last_updated_by,
+  campaigns.idempotency_key,
+  campaigns.is_template,
+  campaigns.is_locked
+FROM campaigns
+JOIN campaign_views ON campaign_views.campaign_id = campaigns.id
+WHERE campaign_views.user_id = %s
+AND NOT campaigns.is_template
+ORDER BY campaign_views.viewed_at DESC, campaigns.id DESC
+LIMIT %s
+`
 
-	var c campaigns.Campaign
-	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
-		return 0, 0, scanCampaign(&c, sc)
+// CreateCampaignEvent appends the given CampaignEvent to the activity
+// timeline of its Campaign.
+func (s *Store) CreateCampaignEvent(ctx context.Context, e *campaigns.CampaignEvent) error {
+	q, err := s.createCampaignEventQuery(e)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignEvent(e, sc)
+		return e.ID, 1, err
 	})
+}
+
+var createCampaignEventQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateCampaignEvent
+INSERT INTO campaign_events (
+  campaign_id,
+  actor_id,
+  kind,
+  payload,
+  created_at
+)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING
+  id,
+  campaign_id,
+  actor_id,
+  kind,
+  payload,
+  created_at
+`
+
+func (s *Store) createCampaignEventQuery(e *campaigns.CampaignEvent) (*sqlf.Query, error) {
+	changes := e.Changes
+	if changes == nil {
+		changes = map[string]campaigns.CampaignEventChange{}
+	}
+
+	payload, err := json.Marshal(changes)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.ID == 0 {
-		return nil, ErrNoResults
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
 	}
 
-	return &c, nil
+	return sqlf.Sprintf(
+		createCampaignEventQueryFmtstr,
+		e.CampaignID,
+		nullInt32Column(e.ActorID),
+		e.Kind,
+		payload,
+		e.CreatedAt,
+	), nil
 }
 
-var getCampaignsQueryFmtstr = `
--- source: enterprise/internal/campaigns/store.go:GetCampaign
+// ListCampaignEvents lists the activity timeline of the Campaign with the
+// given ID, oldest events first.
+func (s *Store) ListCampaignEvents(ctx context.Context, campaignID int64) ([]*campaigns.CampaignEvent, error) {
+	q := sqlf.Sprintf(listCampaignEventsQueryFmtstr, campaignID)
+
+	es := []*campaigns.CampaignEvent{}
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var e campaigns.CampaignEvent
+		if err = scanCampaignEvent(&e, sc); err != nil {
+			return 0, 0, err
+		}
+		es = append(es, &e)
+		return e.ID, 1, err
+	})
+
+	return es, err
+}
+
+var listCampaignEventsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaignEvents
 SELECT
   id,
-  name,
-  description,
-  branch,
-  author_id,
-  namespace_user_id,
-  namespace_org_id,
-  created_at,
-  updated_at,
-  changeset_ids,
-  campaign_plan_id,
-  closed_at
-FROM campaigns
-WHERE %s
-LIMIT 1
+  campaign_id,
+  actor_id,
+  kind,
+  payload,
+  created_at
+FROM campaign_events
+WHERE campaign_id = %s
+ORDER BY created_at ASC, id ASC
 `
 
-func getCampaignQuery(opts *GetCampaignOpts) *sqlf.Query {
-	var preds []*sqlf.Query
-	if opts.ID != 0 {
-		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
-	}
-
-	if opts.CampaignPlanID != 0 {
-		preds = append(preds, sqlf.Sprintf("campaign_plan_id = %s", opts.CampaignPlanID))
+// CreateCampaignReminder creates the given CampaignReminder.
+func (s *Store) CreateCampaignReminder(ctx context.Context, r *campaigns.CampaignReminder) error {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = s.now()
 	}
 
-	if len(preds) == 0 {
-		preds = append(preds, sqlf.Sprintf("TRUE"))
-	}
+	q := sqlf.Sprintf(
+		createCampaignReminderQueryFmtstr,
+		r.CampaignID,
+		r.UserID,
+		r.RemindAt,
+		nullTimeColumn(r.SentAt),
+		r.CreatedAt,
+	)
 
-	return sqlf.Sprintf(getCampaignsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignReminder(r, sc)
+		return r.ID, 1, err
+	})
 }
 
-// ListCampaignsOpts captures the query options needed for
-// listing campaigns.
-type ListCampaignsOpts struct {
-	ChangesetID int64
-	Cursor      int64
-	Limit       int
-	State       campaigns.CampaignState
-}
+var createCampaignReminderQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateCampaignReminder
+INSERT INTO campaign_reminders (
+  campaign_id,
+  user_id,
+  remind_at,
+  sent_at,
+  created_at
+)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING
+  id,
+  campaign_id,
+  user_id,
+  remind_at,
+  sent_at,
+  created_at
+`
 
-// ListCampaigns lists Campaigns with the given filters.
-func (s *Store) ListCampaigns(ctx context.Context, opts ListCampaignsOpts) (cs []*campaigns.Campaign, next int64, err error) {
-	q := listCampaignsQuery(&opts)
+// ListDueCampaignReminders lists the CampaignReminders that are due as of the
+// given time and haven't been sent yet, the longest due first.
+func (s *Store) ListDueCampaignReminders(ctx context.Context, asOf time.Time) ([]*campaigns.CampaignReminder, error) {
+	q := sqlf.Sprintf(listDueCampaignRemindersQueryFmtstr, asOf)
 
-	cs = make([]*campaigns.Campaign, 0, opts.Limit)
-	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
-		var c campaigns.Campaign
-		if err = scanCampaign(&c, sc); err != nil {
+	rs := []*campaigns.CampaignReminder{}
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var r campaigns.CampaignReminder
+		if err = scanCampaignReminder(&r, sc); err != nil {
 			return 0, 0, err
 		}
-		cs = append(cs, &c)
-		return c.ID, 1, err
+		rs = append(rs, &r)
+		return r.ID, 1, err
 	})
 
-	if opts.Limit != 0 && len(cs) == opts.Limit {
-		next = cs[len(cs)-1].ID
-		cs = cs[:len(cs)-1]
-	}
-
-	return cs, next, err
+	return rs, err
 }
 
-var listCampaignsQueryFmtstr = `
--- source: enterprise/internal/campaigns/store.go:ListCampaigns
+var listDueCampaignRemindersQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListDueCampaignReminders
 SELECT
   id,
-  name,
-  description,
-  branch,
-  author_id,
-  namespace_user_id,
-  namespace_org_id,
-  created_at,
-  updated_at,
-  changeset_ids,
-  campaign_plan_id,
-  closed_at
-FROM campaigns
-WHERE %s
-ORDER BY id ASC
-LIMIT %s
+  campaign_id,
+  user_id,
+  remind_at,
+  sent_at,
+  created_at
+FROM campaign_reminders
+WHERE remind_at <= %s AND sent_at IS NULL
+ORDER BY remind_at ASC, id ASC
 `
 
-func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
-	if opts.Limit == 0 {
-		opts.Limit = defaultListLimit
-	}
-	opts.Limit++
+// MarkCampaignReminderSent marks the CampaignReminder with the given ID as
+// sent now, so that it is no longer listed by ListDueCampaignReminders. It
+// returns ErrNoResults if the reminder doesn't exist or was already sent.
+func (s *Store) MarkCampaignReminderSent(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(markCampaignReminderSentQueryFmtstr, s.now(), id)
 
-	preds := []*sqlf.Query{
-		sqlf.Sprintf("id >= %s", opts.Cursor),
+	_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = sc.Scan(&last)
+		return last, 1, err
+	})
+	if err != nil {
+		return err
 	}
-
-	if opts.ChangesetID != 0 {
-		preds = append(preds, sqlf.Sprintf("changeset_ids ? %s", opts.ChangesetID))
+	if count == 0 {
+		return ErrNoResults
 	}
+	return nil
+}
 
-	switch opts.State {
-	case campaigns.CampaignStateOpen:
-		preds = append(preds, sqlf.Sprintf("closed_at IS NULL"))
-	case campaigns.CampaignStateClosed:
-		preds = append(preds, sqlf.Sprintf("closed_at IS NOT NULL"))
-	}
+var markCampaignReminderSentQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:MarkCampaignReminderSent
+UPDATE campaign_reminders
+SET sent_at = %s
+WHERE id = %s AND sent_at IS NULL
+RETURNING id
+`
 
-	return sqlf.Sprintf(
-		listCampaignsQueryFmtstr,
-		sqlf.Join(preds, "\n AND "),
-		opts.Limit,
-	)
+// DeleteCampaignReminder deletes the CampaignReminder with the given ID. It
+// returns ErrNoResults if the reminder doesn't exist.
+func (s *Store) DeleteCampaignReminder(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(deleteCampaignReminderQueryFmtstr, id)
+
+	_, count, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = sc.Scan(&last)
+		return last, 1, err
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNoResults
+	}
+	return nil
 }
 
+var deleteCampaignReminderQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteCampaignReminder
+DELETE FROM campaign_reminders WHERE id = %s RETURNING id
+`
+
 // CreateCampaignPlan creates the given CampaignPlan.
 func (s *Store) CreateCampaignPlan(ctx context.Context, c *campaigns.CampaignPlan) error {
 	q, err := s.createCampaignPlanQuery(c)
@@ -2559,6 +4794,18 @@ type scanner interface {
 	Scan(dst ...interface{}) error
 }
 
+// extraColumnsScanner is a scanner for rows with more columns than the
+// scanning function expects. The additional columns at the end of the row
+// are scanned into dst.
+type extraColumnsScanner struct {
+	scanner
+	dst []interface{}
+}
+
+func (s extraColumnsScanner) Scan(dst ...interface{}) error {
+	return s.scanner.Scan(append(dst, s.dst...)...)
+}
+
 // a scanFunc scans one or more rows from a scanner, returning
 // the last id column scanned and the count of scanned rows.
 type scanFunc func(scanner) (last, count int64, err error)
@@ -2661,7 +4908,44 @@ func scanCampaign(c *campaigns.Campaign, s scanner) error {
 		&dbutil.JSONInt64Set{Set: &c.ChangesetIDs},
 		&dbutil.NullInt64{N: &c.CampaignPlanID},
 		&dbutil.NullTime{Time: &c.ClosedAt},
+		&dbutil.NullInt32{N: &c.LastUpdatedBy},
+		&dbutil.NullString{S: &c.IdempotencyKey},
+		&c.IsTemplate,
+		&c.IsLocked,
+	)
+}
+
+func scanCampaignReminder(r *campaigns.CampaignReminder, s scanner) error {
+	return s.Scan(
+		&r.ID,
+		&r.CampaignID,
+		&r.UserID,
+		&r.RemindAt,
+		&dbutil.NullTime{Time: &r.SentAt},
+		&r.CreatedAt,
+	)
+}
+
+func scanCampaignEvent(e *campaigns.CampaignEvent, s scanner) error {
+	var payload json.RawMessage
+
+	err := s.Scan(
+		&e.ID,
+		&e.CampaignID,
+		&dbutil.NullInt32{N: &e.ActorID},
+		&e.Kind,
+		&payload,
+		&e.CreatedAt,
 	)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(payload, &e.Changes); err != nil {
+		return errors.Wrapf(err, "scanCampaignEvent: failed to unmarshal %q payload", e.Kind)
+	}
+
+	return nil
 }
 
 func scanCampaignPlan(c *campaigns.CampaignPlan, s scanner) error {
@@ -4,20 +4,27 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 // Ran in integration_test.go
@@ -47,6 +54,7 @@ func testStore(db *sql.DB) func(*testing.T) {
 						ChangesetIDs:   []int64{int64(i) + 1},
 						CampaignPlanID: 42 + int64(i),
 						ClosedAt:       now,
+						IdempotencyKey: fmt.Sprintf("create-%d", i),
 					}
 					if i == 0 {
 						// Don't close the first one
@@ -173,6 +181,36 @@ func testStore(db *sql.DB) func(*testing.T) {
 					}
 				}
 
+				t.Run("ListCampaigns MaxLimit", func(t *testing.T) {
+					conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+						CampaignsMaxListLimit: 2,
+					}})
+					defer conf.Mock(nil)
+
+					for _, tc := range []struct {
+						limit    int
+						want     []*cmpgn.Campaign
+						wantNext int64
+					}{
+						{limit: 100, want: campaigns[:2], wantNext: campaigns[2].ID},
+						{limit: 0, want: campaigns[:2], wantNext: campaigns[2].ID},
+						{limit: 1, want: campaigns[:1], wantNext: campaigns[1].ID},
+					} {
+						have, next, err := s.ListCampaigns(ctx, ListCampaignsOpts{Limit: tc.limit})
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						if next != tc.wantNext {
+							t.Fatalf("limit: %d: have next %v, want %v", tc.limit, next, tc.wantNext)
+						}
+
+						if diff := cmp.Diff(have, tc.want); diff != "" {
+							t.Fatalf("limit: %d: %s", tc.limit, diff)
+						}
+					}
+				})
+
 				filterTests := []struct {
 					name  string
 					state cmpgn.CampaignState
@@ -206,135 +244,2293 @@ func testStore(db *sql.DB) func(*testing.T) {
 						}
 					})
 				}
+
+				queryTests := []struct {
+					name  string
+					query string
+					want  []*cmpgn.Campaign
+				}{
+					{
+						name:  "Include",
+						query: "es-lint",
+						want:  campaigns,
+					},
+					{
+						name:  "Exclude",
+						query: "-1",
+						want:  []*cmpgn.Campaign{campaigns[0], campaigns[2]},
+					},
+					{
+						name:  "IncludeAndExclude",
+						query: "upgrade -0 -2",
+						want:  campaigns[1:2],
+					},
+					{
+						name:  "ExcludeDescription",
+						query: "upgrade -javascripts",
+						want:  []*cmpgn.Campaign{},
+					},
+					{
+						name:  "EscapedHyphen",
+						query: `\-lint`,
+						want:  campaigns,
+					},
+					{
+						name:  "EscapedHyphenNoMatch",
+						query: `\-1`,
+						want:  []*cmpgn.Campaign{},
+					},
+					{
+						name:  "Wildcard",
+						query: "es%lint",
+						want:  []*cmpgn.Campaign{},
+					},
+				}
+
+				for _, tc := range queryTests {
+					t.Run("ListCampaigns Query "+tc.name, func(t *testing.T) {
+						have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: tc.query})
+						if err != nil {
+							t.Fatal(err)
+						}
+						if diff := cmp.Diff(have, tc.want); diff != "" {
+							t.Fatal(diff)
+						}
+
+						count, err := s.CountCampaigns(ctx, CountCampaignsOpts{Query: tc.query})
+						if err != nil {
+							t.Fatal(err)
+						}
+						if have, want := count, int64(len(tc.want)); have != want {
+							t.Fatalf("have count: %d, want: %d", have, want)
+						}
+					})
+				}
+			})
+
+			t.Run("Update", func(t *testing.T) {
+				for _, c := range campaigns {
+					c.Name += "-updated"
+					c.Description += "-updated"
+					c.AuthorID++
+					c.ClosedAt = c.ClosedAt.Add(5 * time.Second)
+					c.LastUpdatedBy = 99
+
+					if c.NamespaceUserID != 0 {
+						c.NamespaceUserID++
+					}
+
+					if c.NamespaceOrgID != 0 {
+						c.NamespaceOrgID++
+					}
+
+					now = now.Add(time.Second)
+					want := c
+					want.UpdatedAt = now
+
+					have := c.Clone()
+					if err := s.UpdateCampaign(ctx, have); err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					// Test that duplicates are not introduced.
+					have.ChangesetIDs = append(have.ChangesetIDs, have.ChangesetIDs...)
+					if err := s.UpdateCampaign(ctx, have); err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					// Test we can add to the set.
+					have.ChangesetIDs = append(have.ChangesetIDs, 42)
+					want.ChangesetIDs = append(want.ChangesetIDs, 42)
+
+					if err := s.UpdateCampaign(ctx, have); err != nil {
+						t.Fatal(err)
+					}
+
+					sort.Slice(have.ChangesetIDs, func(a, b int) bool {
+						return have.ChangesetIDs[a] < have.ChangesetIDs[b]
+					})
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					// Test we can remove from the set.
+					have.ChangesetIDs = have.ChangesetIDs[:0]
+					want.ChangesetIDs = want.ChangesetIDs[:0]
+
+					if err := s.UpdateCampaign(ctx, have); err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+				}
+			})
+
+			t.Run("Get", func(t *testing.T) {
+				t.Run("ByID", func(t *testing.T) {
+					want := campaigns[0]
+					opts := GetCampaignOpts{ID: want.ID}
+
+					have, err := s.GetCampaign(ctx, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+				})
+
+				t.Run("ByCampaignPlanID", func(t *testing.T) {
+					want := campaigns[0]
+					opts := GetCampaignOpts{CampaignPlanID: want.CampaignPlanID}
+
+					have, err := s.GetCampaign(ctx, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+				})
+
+				t.Run("ByIdempotencyKey", func(t *testing.T) {
+					want := campaigns[0]
+					opts := GetCampaignOpts{
+						IdempotencyKey: want.IdempotencyKey,
+						NamespaceOrgID: want.NamespaceOrgID,
+					}
+
+					have, err := s.GetCampaign(ctx, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					// Keys are scoped per namespace.
+					opts.NamespaceOrgID++
+					if _, err := s.GetCampaign(ctx, opts); err != ErrNoResults {
+						t.Fatalf("have err %v, want %v", err, ErrNoResults)
+					}
+				})
+
+				t.Run("NoResults", func(t *testing.T) {
+					opts := GetCampaignOpts{ID: 0xdeadbeef}
+
+					_, have := s.GetCampaign(ctx, opts)
+					want := ErrNoResults
+
+					if have != want {
+						t.Fatalf("have err %v, want %v", have, want)
+					}
+				})
+			})
+
+			t.Run("Delete", func(t *testing.T) {
+				for i := range campaigns {
+					err := s.DeleteCampaign(ctx, campaigns[i].ID)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					count, err := s.CountCampaigns(ctx, CountCampaignsOpts{})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if have, want := count, int64(len(campaigns)-(i+1)); have != want {
+						t.Fatalf("have count: %d, want: %d", have, want)
+					}
+				}
+			})
+
+		})
+
+		t.Run("CampaignErrors", func(t *testing.T) {
+			t.Run("UpdateMissing", func(t *testing.T) {
+				err := s.UpdateCampaign(ctx, &cmpgn.Campaign{ID: 99999, Name: "missing", AuthorID: 23, NamespaceUserID: 23})
+				if !IsNotFound(err) {
+					t.Fatalf("have err %v, want not found", err)
+				}
+			})
+
+			t.Run("DeleteMissing", func(t *testing.T) {
+				if err := s.DeleteCampaign(ctx, 99999); !IsNotFound(err) {
+					t.Fatalf("have err %v, want not found", err)
+				}
+			})
+		})
+
+		t.Run("CampaignLocks", func(t *testing.T) {
+			c := &cmpgn.Campaign{
+				Name:            "Locked campaign",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("EditBlockedWhileLocked", func(t *testing.T) {
+				for i := 0; i < 2; i++ {
+					// Locking a locked campaign is a no-op.
+					if err := s.LockCampaign(ctx, c.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				update := c.Clone()
+				update.Description = "Edited while locked"
+				if err := s.UpdateCampaign(ctx, update); err != ErrCampaignLocked {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignLocked)
+				}
+				if err := s.DeleteCampaign(ctx, c.ID); err != ErrCampaignLocked {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignLocked)
+				}
+
+				have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !have.IsLocked || have.Description != c.Description {
+					t.Fatalf("have campaign %+v, want unchanged and locked", have)
+				}
+			})
+
+			t.Run("Override", func(t *testing.T) {
+				update := c.Clone()
+				update.Description = "Edited by a site admin"
+				if err := s.UpdateCampaign(WithCampaignLockOverride(ctx), update); err != nil {
+					t.Fatal(err)
+				}
+				if !update.IsLocked || update.Description != "Edited by a site admin" {
+					t.Fatalf("have campaign %+v, want edited and locked", update)
+				}
+			})
+
+			t.Run("EditAllowedAfterUnlock", func(t *testing.T) {
+				if err := s.UnlockCampaign(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+
+				update := c.Clone()
+				update.Description = "Edited after unlock"
+				if err := s.UpdateCampaign(ctx, update); err != nil {
+					t.Fatal(err)
+				}
+				if update.IsLocked || update.Description != "Edited after unlock" {
+					t.Fatalf("have campaign %+v, want edited and unlocked", update)
+				}
+
+				if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			t.Run("LockMissing", func(t *testing.T) {
+				if err := s.LockCampaign(ctx, c.ID); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+				if err := s.UnlockCampaign(ctx, c.ID); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+			})
+		})
+
+		t.Run("CampaignLocksBulk", func(t *testing.T) {
+			const org, otherOrg, author, newAuthor = 9301, 9302, 9401, 9402
+
+			unlocked := &cmpgn.Campaign{Name: "Unlocked bulk campaign", AuthorID: author, NamespaceOrgID: org}
+			locked := &cmpgn.Campaign{Name: "Locked bulk campaign", AuthorID: author, NamespaceOrgID: org}
+			for _, c := range []*cmpgn.Campaign{unlocked, locked} {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.LockCampaign(ctx, locked.ID); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				overrideCtx := WithCampaignLockOverride(ctx)
+				for _, c := range []*cmpgn.Campaign{unlocked, locked} {
+					if err := s.DeleteCampaign(overrideCtx, c.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			// Neither Campaign is changed if one of them is locked.
+			assertUnchanged := func(t *testing.T) {
+				t.Helper()
+				for _, c := range []*cmpgn.Campaign{unlocked, locked} {
+					have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if have.Description != "" || have.AuthorID != author || have.NamespaceOrgID != org {
+						t.Fatalf("have campaign %+v, want it unchanged", have)
+					}
+				}
+			}
+
+			description := "Edited in bulk"
+			ids := []int64{unlocked.ID, locked.ID}
+
+			t.Run("UpdateCampaignsByID", func(t *testing.T) {
+				_, err := s.UpdateCampaignsByID(ctx, ids, CampaignsUpdate{Description: &description})
+				if err != ErrCampaignLocked {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignLocked)
+				}
+				assertUnchanged(t)
+			})
+
+			t.Run("ReassignAuthorOnLeave", func(t *testing.T) {
+				_, err := s.ReassignAuthorOnLeave(ctx, org, author, newAuthor)
+				if err != ErrCampaignLocked {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignLocked)
+				}
+				assertUnchanged(t)
+			})
+
+			t.Run("ReassignNamespaceOrg", func(t *testing.T) {
+				_, err := s.ReassignNamespaceOrg(ctx, org, otherOrg, OnConflictFail)
+				if err != ErrCampaignLocked {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignLocked)
+				}
+				assertUnchanged(t)
+			})
+
+			t.Run("Override", func(t *testing.T) {
+				overrideCtx := WithCampaignLockOverride(ctx)
+
+				count, err := s.UpdateCampaignsByID(overrideCtx, ids, CampaignsUpdate{Description: &description})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != len(ids) {
+					t.Fatalf("have count %d, want %d", count, len(ids))
+				}
+
+				count, err = s.ReassignAuthorOnLeave(overrideCtx, org, author, newAuthor)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != len(ids) {
+					t.Fatalf("have count %d, want %d", count, len(ids))
+				}
+
+				rs, err := s.ReassignNamespaceOrg(overrideCtx, org, otherOrg, OnConflictFail)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rs) != len(ids) {
+					t.Fatalf("have %d reassignments, want %d", len(rs), len(ids))
+				}
+			})
+		})
+
+		t.Run("CampaignActors", func(t *testing.T) {
+			const explicitUserID, contextUserID, updaterUserID = 23, 4242, 99
+			actorCtx := actor.WithActor(ctx, actor.FromUser(contextUserID))
+
+			for _, tc := range []struct {
+				name string
+				ctx  context.Context
+				want int32
+			}{
+				{name: "Explicit", ctx: ctx, want: explicitUserID},
+				{name: "InternalActor", ctx: actor.WithActor(ctx, &actor.Actor{Internal: true}), want: explicitUserID},
+				{name: "Context", ctx: actorCtx, want: contextUserID},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					c := &cmpgn.Campaign{
+						Name:            "Actor campaign",
+						AuthorID:        explicitUserID,
+						NamespaceUserID: explicitUserID,
+					}
+					if err := s.CreateCampaign(tc.ctx, c); err != nil {
+						t.Fatal(err)
+					}
+					defer func() {
+						if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+							t.Fatal(err)
+						}
+					}()
+
+					if have, want := c.AuthorID, tc.want; have != want {
+						t.Fatalf("have AuthorID %d, want %d", have, want)
+					}
+
+					// UpdateCampaign records the given LastUpdatedBy regardless
+					// of the actor in ctx, but clears it for the author.
+					for _, u := range []struct{ updatedBy, want int32 }{
+						{updatedBy: updaterUserID, want: updaterUserID},
+						{updatedBy: c.AuthorID, want: 0},
+					} {
+						c.LastUpdatedBy = u.updatedBy
+						if err := s.UpdateCampaign(tc.ctx, c); err != nil {
+							t.Fatal(err)
+						}
+
+						if have, want := c.LastUpdatedBy, u.want; have != want {
+							t.Fatalf("have LastUpdatedBy %d, want %d", have, want)
+						}
+
+						have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+						if err != nil {
+							t.Fatal(err)
+						}
+						if have.LastUpdatedBy != u.want {
+							t.Fatalf("have persisted LastUpdatedBy %d, want %d", have.LastUpdatedBy, u.want)
+						}
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateCampaignsByID", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 4)
+			ids := make([]int64, 0, cap(campaigns))
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{
+					Name:            fmt.Sprintf("Batch campaign %d", i),
+					Description:     "Old description",
+					AuthorID:        23,
+					NamespaceUserID: 23,
+				}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+				ids = append(ids, c.ID)
+			}
+			defer func() {
+				for _, id := range ids {
+					if err := s.DeleteCampaign(ctx, id); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			closed, description := true, "New description"
+			actorCtx := actor.WithActor(ctx, actor.FromUser(4242))
+			// The last ID doesn't belong to any Campaign.
+			updateIDs := append(ids[:3:3], ids[len(ids)-1]+1000)
+
+			count, err := s.UpdateCampaignsByID(actorCtx, updateIDs, CampaignsUpdate{
+				Description: &description,
+				Closed:      &closed,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := count, 3; have != want {
+				t.Fatalf("have count %d, want %d", have, want)
+			}
+
+			for i, c := range campaigns {
+				have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := c.Clone()
+				if i < 3 {
+					want.Description = description
+					want.ClosedAt = now
+					want.LastUpdatedBy = 4242
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				if i >= 3 {
+					assertCampaignEvents(ctx, t, s, c.ID)
+					continue
+				}
+				assertCampaignEvents(ctx, t, s, c.ID, &cmpgn.CampaignEvent{
+					ActorID: 4242,
+					Kind:    cmpgn.CampaignEventKindUpdated,
+					Changes: map[string]cmpgn.CampaignEventChange{
+						"description": {Old: "Old description", New: description},
+						"closed":      {Old: "false", New: "true"},
+					},
+				})
+			}
+
+			t.Run("ClearClosed", func(t *testing.T) {
+				open := false
+				count, err := s.UpdateCampaignsByID(ctx, ids, CampaignsUpdate{Closed: &open})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have, want := count, len(ids); have != want {
+					t.Fatalf("have count %d, want %d", have, want)
+				}
+
+				n, err := s.CountCampaigns(ctx, CountCampaignsOpts{
+					State: cmpgn.CampaignStateClosed,
+					Query: "Batch campaign",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != 0 {
+					t.Fatalf("have %d closed campaigns, want none", n)
+				}
+			})
+
+			t.Run("UpdatedByAuthor", func(t *testing.T) {
+				description := "Updated by author"
+				_, err := s.UpdateCampaignsByID(actorCtx, ids[:1], CampaignsUpdate{
+					Description: &description,
+					UpdatedBy:   campaigns[0].AuthorID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: ids[0]})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have.LastUpdatedBy != 0 {
+					t.Fatalf("have LastUpdatedBy %d, want none", have.LastUpdatedBy)
+				}
+			})
+
+			t.Run("NoChanges", func(t *testing.T) {
+				count, err := s.UpdateCampaignsByID(ctx, ids, CampaignsUpdate{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != 0 {
+					t.Fatalf("have count %d, want 0", count)
+				}
+			})
+		})
+
+		t.Run("ReassignNamespaceOrg", func(t *testing.T) {
+			const fromOrg, toOrg, otherOrg = 9001, 9002, 9003
+
+			var reassigned []*cmpgn.Campaign
+			for _, c := range []*cmpgn.Campaign{
+				{Name: "Reassigned campaign 0", AuthorID: 23, NamespaceOrgID: fromOrg},
+				{Name: "Reassigned campaign 1", AuthorID: 23, NamespaceOrgID: fromOrg},
+				// Collides with the first campaign once that is moved.
+				{Name: "Reassigned campaign 0", AuthorID: 23, NamespaceOrgID: otherOrg},
+				{Name: "Reassigned campaign 2", AuthorID: 23, NamespaceOrgID: otherOrg},
+			} {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				reassigned = append(reassigned, c)
+			}
+			defer func() {
+				for _, c := range reassigned {
+					if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			assertOrgs := func(t *testing.T, want ...int32) {
+				t.Helper()
+				for i, c := range reassigned {
+					have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if have.NamespaceOrgID != want[i] {
+						t.Fatalf("campaign %d: have NamespaceOrgID %d, want %d", c.ID, have.NamespaceOrgID, want[i])
+					}
+				}
+			}
+
+			t.Run("Clean", func(t *testing.T) {
+				actorCtx := actor.WithActor(ctx, actor.FromUser(4242))
+				have, err := s.ReassignNamespaceOrg(actorCtx, fromOrg, toOrg, OnConflictFail)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := []*CampaignReassignment{
+					{CampaignID: reassigned[0].ID, Name: "Reassigned campaign 0", Outcome: CampaignReassignmentMoved},
+					{CampaignID: reassigned[1].ID, Name: "Reassigned campaign 1", Outcome: CampaignReassignmentMoved},
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				assertOrgs(t, toOrg, toOrg, otherOrg, otherOrg)
+
+				c, err := s.GetCampaign(ctx, GetCampaignOpts{ID: reassigned[0].ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if c.LastUpdatedBy != 4242 || !c.UpdatedAt.Equal(now) {
+					t.Fatalf("have LastUpdatedBy %d and UpdatedAt %s", c.LastUpdatedBy, c.UpdatedAt)
+				}
+
+				for _, c := range reassigned[:2] {
+					assertCampaignEvents(ctx, t, s, c.ID, &cmpgn.CampaignEvent{
+						ActorID: 4242,
+						Kind:    cmpgn.CampaignEventKindUpdated,
+						Changes: map[string]cmpgn.CampaignEventChange{
+							"namespace": {Old: "9001", New: "9002"},
+						},
+					})
+				}
+			})
+
+			t.Run("OnConflictFail", func(t *testing.T) {
+				_, err := s.ReassignNamespaceOrg(ctx, otherOrg, toOrg, OnConflictFail)
+				collision, ok := err.(*CampaignNameCollisionError)
+				if !ok {
+					t.Fatalf("have err %v, want *CampaignNameCollisionError", err)
+				}
+				if diff := cmp.Diff(collision.Names, []string{"Reassigned campaign 0"}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				// Nothing was moved.
+				assertOrgs(t, toOrg, toOrg, otherOrg, otherOrg)
+				for _, c := range reassigned[2:] {
+					assertCampaignEvents(ctx, t, s, c.ID)
+				}
+			})
+
+			t.Run("OnConflictSkip", func(t *testing.T) {
+				have, err := s.ReassignNamespaceOrg(ctx, otherOrg, toOrg, OnConflictSkip)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := []*CampaignReassignment{
+					{CampaignID: reassigned[2].ID, Name: "Reassigned campaign 0", Outcome: CampaignReassignmentSkipped},
+					{CampaignID: reassigned[3].ID, Name: "Reassigned campaign 2", Outcome: CampaignReassignmentMoved},
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				assertOrgs(t, toOrg, toOrg, otherOrg, toOrg)
+			})
+
+			t.Run("OnConflictSuffix", func(t *testing.T) {
+				have, err := s.ReassignNamespaceOrg(ctx, otherOrg, toOrg, OnConflictSuffix)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := []*CampaignReassignment{
+					{CampaignID: reassigned[2].ID, Name: "Reassigned campaign 0 (1)", Outcome: CampaignReassignmentRenamed},
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				assertOrgs(t, toOrg, toOrg, toOrg, toOrg)
+
+				c, err := s.GetCampaign(ctx, GetCampaignOpts{ID: reassigned[2].ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if c.Name != "Reassigned campaign 0 (1)" {
+					t.Fatalf("have name %q, want it to be suffixed", c.Name)
+				}
+
+				// The Campaign skipped before was moved only once.
+				assertCampaignEvents(ctx, t, s, c.ID, &cmpgn.CampaignEvent{
+					Kind: cmpgn.CampaignEventKindUpdated,
+					Changes: map[string]cmpgn.CampaignEventChange{
+						"namespace": {Old: "9003", New: "9002"},
+						"name":      {Old: "Reassigned campaign 0", New: "Reassigned campaign 0 (1)"},
+					},
+				})
+			})
+		})
+
+		t.Run("ReassignNamespaceOrg IdempotencyKeyCollision", func(t *testing.T) {
+			const fromOrg, toOrg = 9011, 9012
+
+			fixtures := []*cmpgn.Campaign{
+				{Name: "Moving campaign 1", AuthorID: 23, NamespaceOrgID: fromOrg},
+				{Name: "Moving campaign 2", AuthorID: 23, NamespaceOrgID: fromOrg, IdempotencyKey: "reassign-key"},
+				{Name: "Existing campaign", AuthorID: 23, NamespaceOrgID: toOrg, IdempotencyKey: "reassign-key"},
+			}
+			for _, c := range fixtures {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			defer func() {
+				for _, c := range fixtures {
+					if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			_, err := s.ReassignNamespaceOrg(ctx, fromOrg, toOrg, OnConflictFail)
+			if err != ErrCampaignIdempotencyKeyExists {
+				t.Fatalf("have err %v, want %v", err, ErrCampaignIdempotencyKeyExists)
+			}
+
+			// Nothing was moved.
+			for _, c := range fixtures[:2] {
+				have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: c.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have.NamespaceOrgID != fromOrg {
+					t.Fatalf("campaign %d: have NamespaceOrgID %d, want %d", c.ID, have.NamespaceOrgID, fromOrg)
+				}
+				assertCampaignEvents(ctx, t, s, c.ID)
+			}
+		})
+
+		t.Run("ReassignAuthorOnLeave", func(t *testing.T) {
+			const org, otherOrg = 9101, 9102
+			const leaving, stays, admin = 9201, 9202, 9203
+
+			fixtures := []*cmpgn.Campaign{
+				{Name: "Leaving user's org campaign 1", AuthorID: leaving, NamespaceOrgID: org},
+				{Name: "Leaving user's org campaign 2", AuthorID: leaving, NamespaceOrgID: org},
+				{Name: "Other user's org campaign", AuthorID: stays, NamespaceOrgID: org},
+				{Name: "Leaving user's other org campaign", AuthorID: leaving, NamespaceOrgID: otherOrg},
+				{Name: "Leaving user's own campaign", AuthorID: leaving, NamespaceUserID: leaving},
+			}
+			for _, c := range fixtures {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			defer func() {
+				for _, c := range fixtures {
+					if err := s.DeleteCampaign(ctx, c.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}()
+
+			count, err := s.ReassignAuthorOnLeave(ctx, org, leaving, admin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := count, 2; have != want {
+				t.Fatalf("have count %d, want %d", have, want)
+			}
+
+			for i, want := range []int32{admin, admin, stays, leaving, leaving} {
+				have, err := s.GetCampaign(ctx, GetCampaignOpts{ID: fixtures[i].ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have.AuthorID != want {
+					t.Errorf("%q: have AuthorID %d, want %d", have.Name, have.AuthorID, want)
+				}
+			}
+
+			// Running it again is a no-op.
+			count, err = s.ReassignAuthorOnLeave(ctx, org, leaving, admin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 0 {
+				t.Fatalf("have count %d, want 0", count)
+			}
+
+			for i, c := range fixtures {
+				if i >= 2 {
+					assertCampaignEvents(ctx, t, s, c.ID)
+					continue
+				}
+				assertCampaignEvents(ctx, t, s, c.ID, &cmpgn.CampaignEvent{
+					Kind: cmpgn.CampaignEventKindUpdated,
+					Changes: map[string]cmpgn.CampaignEventChange{
+						"author": {Old: "9201", New: "9203"},
+					},
+				})
+			}
+		})
+
+		t.Run("CampaignSubscribers", func(t *testing.T) {
+			c := &cmpgn.Campaign{
+				Name:            "Subscribed campaign",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			listSubscribers := func(t *testing.T) []int32 {
+				t.Helper()
+				have, err := s.ListCampaignSubscribers(ctx, c.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("Subscribe", func(t *testing.T) {
+				for _, userID := range []int32{42, 23} {
+					if err := s.SubscribeToCampaign(ctx, c.ID, userID); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if diff := cmp.Diff(listSubscribers(t), []int32{23, 42}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("DuplicateSubscribe", func(t *testing.T) {
+				if err := s.SubscribeToCampaign(ctx, c.ID, 42); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(listSubscribers(t), []int32{23, 42}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Unsubscribe", func(t *testing.T) {
+				if err := s.UnsubscribeFromCampaign(ctx, c.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(listSubscribers(t), []int32{42}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				// Unsubscribing a user that isn't subscribed is a no-op.
+				if err := s.UnsubscribeFromCampaign(ctx, c.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			t.Run("ListNoSubscribers", func(t *testing.T) {
+				have, err := s.ListCampaignSubscribers(ctx, c.ID+1)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(have) != 0 {
+					t.Fatalf("have subscribers %v, want none", have)
+				}
+			})
+		})
+
+		t.Run("CampaignCollaborators", func(t *testing.T) {
+			authored := &cmpgn.Campaign{
+				Name:            "Collaboratedcampaign authored",
+				AuthorID:        42,
+				NamespaceUserID: 42,
+			}
+			joined := &cmpgn.Campaign{
+				Name:            "Collaboratedcampaign joined",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			other := &cmpgn.Campaign{
+				Name:            "Collaboratedcampaign other",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			for _, c := range []*cmpgn.Campaign{authored, joined, other} {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			listCollaborators := func(t *testing.T, c *cmpgn.Campaign) []int32 {
+				t.Helper()
+				have, err := s.ListCollaborators(ctx, c.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("AuthorIsCollaborator", func(t *testing.T) {
+				if diff := cmp.Diff(listCollaborators(t, joined), []int32{23}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Add", func(t *testing.T) {
+				for i := 0; i < 2; i++ {
+					// Adding an existing collaborator is a no-op.
+					if err := s.AddCollaborator(ctx, joined.ID, 42); err != nil {
+						t.Fatal(err)
+					}
+				}
+				// Adding the author doesn't list them twice.
+				if err := s.AddCollaborator(ctx, joined.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(listCollaborators(t, joined), []int32{23, 42}); diff != "" {
+					t.Fatal(diff)
+				}
+				if diff := cmp.Diff(listCollaborators(t, other), []int32{23}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("ListByUser", func(t *testing.T) {
+				// The author of authored also added themselves as a
+				// collaborator, so it is both authored and collaborated on.
+				if err := s.AddCollaborator(ctx, authored.ID, 42); err != nil {
+					t.Fatal(err)
+				}
+
+				for _, tc := range []struct {
+					name string
+					opts ListCampaignsOpts
+					want []*cmpgn.Campaign
+				}{
+					{name: "Author", opts: ListCampaignsOpts{AuthorID: 42}, want: []*cmpgn.Campaign{authored}},
+					{name: "Collaborator", opts: ListCampaignsOpts{CollaboratorUserID: 42}, want: []*cmpgn.Campaign{joined}},
+					{name: "RelatedToUser", opts: ListCampaignsOpts{RelatedToUserID: 42}, want: []*cmpgn.Campaign{authored, joined}},
+					{name: "AuthorOnly", opts: ListCampaignsOpts{AuthorID: 23}, want: []*cmpgn.Campaign{joined, other}},
+					{name: "CollaboratorOnly", opts: ListCampaignsOpts{CollaboratorUserID: 23}, want: []*cmpgn.Campaign{}},
+					{name: "RelatedToAuthorOnly", opts: ListCampaignsOpts{RelatedToUserID: 23}, want: []*cmpgn.Campaign{joined, other}},
+					{name: "RelatedToUserAndCollaborator", opts: ListCampaignsOpts{RelatedToUserID: 42, CollaboratorUserID: 42}, want: []*cmpgn.Campaign{joined}},
+				} {
+					t.Run(tc.name, func(t *testing.T) {
+						tc.opts.Query = "Collaboratedcampaign"
+						have, _, err := s.ListCampaigns(ctx, tc.opts)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if diff := cmp.Diff(have, tc.want); diff != "" {
+							t.Fatal(diff)
+						}
+
+						count, err := s.CountCampaigns(ctx, *tc.opts.CountOpts())
+						if err != nil {
+							t.Fatal(err)
+						}
+						if count != int64(len(tc.want)) {
+							t.Fatalf("have count %d, want %d", count, len(tc.want))
+						}
+					})
+				}
+			})
+
+			t.Run("ListByHasCollaborators", func(t *testing.T) {
+				yes, no := true, false
+				for _, tc := range []struct {
+					name string
+					opts ListCampaignsOpts
+					want []*cmpgn.Campaign
+				}{
+					// The author of joined added themselves as a collaborator,
+					// which doesn't count.
+					{name: "With", opts: ListCampaignsOpts{HasCollaborators: &yes}, want: []*cmpgn.Campaign{joined}},
+					{name: "Without", opts: ListCampaignsOpts{HasCollaborators: &no}, want: []*cmpgn.Campaign{authored, other}},
+					{name: "WithoutByRelatedToUser", opts: ListCampaignsOpts{HasCollaborators: &no, RelatedToUserID: 42}, want: []*cmpgn.Campaign{authored}},
+				} {
+					t.Run(tc.name, func(t *testing.T) {
+						tc.opts.Query = "Collaboratedcampaign"
+						have, _, err := s.ListCampaigns(ctx, tc.opts)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if diff := cmp.Diff(have, tc.want); diff != "" {
+							t.Fatal(diff)
+						}
+
+						count, err := s.CountCampaigns(ctx, *tc.opts.CountOpts())
+						if err != nil {
+							t.Fatal(err)
+						}
+						if count != int64(len(tc.want)) {
+							t.Fatalf("have count %d, want %d", count, len(tc.want))
+						}
+					})
+				}
+			})
+
+			t.Run("Remove", func(t *testing.T) {
+				if err := s.RemoveCollaborator(ctx, joined.ID, 42); err != nil {
+					t.Fatal(err)
+				}
+				// The author stays a collaborator.
+				if err := s.RemoveCollaborator(ctx, joined.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(listCollaborators(t, joined), []int32{23}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "Collaboratedcampaign", RelatedToUserID: 42})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, []*cmpgn.Campaign{authored}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		})
+
+		t.Run("CampaignDependencies", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 4)
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{
+					Name:            fmt.Sprintf("Dependent campaign %d", i),
+					AuthorID:        23,
+					NamespaceUserID: 23,
+				}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+			}
+			a, b, c, d := campaigns[0].ID, campaigns[1].ID, campaigns[2].ID, campaigns[3].ID
+
+			listDependencies := func(t *testing.T, id int64) []int64 {
+				t.Helper()
+				have, err := s.ListDependencies(ctx, id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("Chain", func(t *testing.T) {
+				// a -> b -> c, a -> d
+				for _, dep := range [][2]int64{{a, b}, {b, c}, {a, d}, {a, b}} {
+					if err := s.AddDependency(ctx, dep[0], dep[1]); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				for id, want := range map[int64][]int64{a: {b, d}, b: {c}, c: {}, d: {}} {
+					if diff := cmp.Diff(listDependencies(t, id), want); diff != "" {
+						t.Fatalf("campaign %d: %s", id, diff)
+					}
+				}
+			})
+
+			t.Run("SelfDependency", func(t *testing.T) {
+				if err := s.AddDependency(ctx, a, a); err != ErrCampaignSelfDependency {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignSelfDependency)
+				}
+			})
+
+			t.Run("Cycle", func(t *testing.T) {
+				for _, dep := range [][2]int64{{b, a}, {c, a}} {
+					if err := s.AddDependency(ctx, dep[0], dep[1]); err != ErrCampaignDependencyCycle {
+						t.Fatalf("add %d -> %d: have err %v, want %v", dep[0], dep[1], err, ErrCampaignDependencyCycle)
+					}
+				}
+				if diff := cmp.Diff(listDependencies(t, c), []int64{}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				// Depending on a sibling doesn't create a cycle.
+				if err := s.AddDependency(ctx, d, c); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			t.Run("Remove", func(t *testing.T) {
+				if err := s.RemoveDependency(ctx, b, c); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(listDependencies(t, b), []int64{}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				// Without b -> c, c may depend on b.
+				if err := s.AddDependency(ctx, c, b); err != nil {
+					t.Fatal(err)
+				}
+			})
+		})
+
+		t.Run("Reorder", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 3)
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{
+					Name:            fmt.Sprintf("Reorderedcampaign %d", i),
+					AuthorID:        23,
+					NamespaceUserID: 23,
+				}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+			}
+			a, b, c := campaigns[0].ID, campaigns[1].ID, campaigns[2].ID
+			var d int64
+
+			listOrder := func(t *testing.T, opts ListCampaignsOpts) []int64 {
+				t.Helper()
+				opts.Query = "Reorderedcampaign"
+				opts.OrderBy = CampaignOrderByManual
+				have, _, err := s.ListCampaigns(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids := make([]int64, 0, len(have))
+				for _, c := range have {
+					ids = append(ids, c.ID)
+				}
+				return ids
+			}
+
+			reorder := func(t *testing.T, id int64, afterID *int64, want ...int64) {
+				t.Helper()
+				if err := s.Reorder(ctx, id, afterID); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(listOrder(t, ListCampaignsOpts{}), want); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			t.Run("Created", func(t *testing.T) {
+				if diff := cmp.Diff(listOrder(t, ListCampaignsOpts{}), []int64{a, b, c}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("MoveToTop", func(t *testing.T) {
+				reorder(t, c, nil, c, a, b)
+				reorder(t, c, nil, c, a, b)
+			})
+
+			t.Run("MoveAfter", func(t *testing.T) {
+				reorder(t, c, &a, a, c, b)
+				reorder(t, a, &b, c, b, a)
+				reorder(t, b, &c, c, b, a)
+			})
+
+			t.Run("NewCampaignLast", func(t *testing.T) {
+				campaign := &cmpgn.Campaign{Name: "Reorderedcampaign 3", AuthorID: 23, NamespaceUserID: 23}
+				if err := s.CreateCampaign(ctx, campaign); err != nil {
+					t.Fatal(err)
+				}
+				d = campaign.ID
+				if diff := cmp.Diff(listOrder(t, ListCampaignsOpts{}), []int64{c, b, a, d}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				reorder(t, d, nil, d, c, b, a)
+			})
+
+			t.Run("Renumber", func(t *testing.T) {
+				// Moving campaigns between the same two neighbours halves
+				// the gap every time, until the campaigns are renumbered.
+				for i := 0; i < 40; i++ {
+					id := b
+					if i%2 == 1 {
+						id = a
+					}
+					if err := s.Reorder(ctx, id, &c); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if diff := cmp.Diff(listOrder(t, ListCampaignsOpts{}), []int64{d, c, a, b}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("RenumberAdjacent", func(t *testing.T) {
+				sortKey := func(t *testing.T, id int64) (key int64) {
+					t.Helper()
+					if err := tx.QueryRowContext(ctx, "SELECT sort_key FROM campaigns WHERE id = $1", id).Scan(&key); err != nil {
+						t.Fatal(err)
+					}
+					return key
+				}
+
+				// Leave no gap between c and a, so that moving b between
+				// them has to renumber all campaigns first.
+				_, err := tx.ExecContext(ctx, "UPDATE campaigns SET sort_key = $1 WHERE id = $2", sortKey(t, c)+1, a)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reorder(t, b, &c, d, c, b, a)
+
+				if have, want := sortKey(t, a)-sortKey(t, c), int64(campaignSortKeyGap); have != want {
+					t.Fatalf("have gap %d between c and a, want %d after renumbering", have, want)
+				}
+				if have, want := sortKey(t, b)-sortKey(t, c), int64(campaignSortKeyGap/2); have != want {
+					t.Fatalf("have gap %d between c and b, want %d", have, want)
+				}
+			})
+
+			t.Run("Paginated", func(t *testing.T) {
+				all := listOrder(t, ListCampaignsOpts{})
+				var have []int64
+				var cursor int64
+				for i := 0; i < len(all); i++ {
+					page, next, err := s.ListCampaigns(ctx, ListCampaignsOpts{
+						Query:   "Reorderedcampaign",
+						OrderBy: CampaignOrderByManual,
+						Cursor:  cursor,
+						Limit:   1,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, c := range page {
+						have = append(have, c.ID)
+					}
+					if next == 0 {
+						break
+					}
+					cursor = next
+				}
+				if diff := cmp.Diff(have, all); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("NotFound", func(t *testing.T) {
+				missing := int64(1000000)
+				if err := s.Reorder(ctx, missing, nil); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+				if err := s.Reorder(ctx, a, &missing); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+				if err := s.Reorder(ctx, a, &a); err != ErrCampaignReorderSelf {
+					t.Fatalf("have err %v, want %v", err, ErrCampaignReorderSelf)
+				}
+			})
+		})
+
+		t.Run("CampaignPins", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 3)
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{
+					Name:            fmt.Sprintf("Pinned campaign %d", i),
+					AuthorID:        23,
+					NamespaceUserID: 23,
+				}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+			}
+
+			// Use a clock that advances on every call, so that pins are
+			// ordered by the time they were created.
+			pinTime := now
+			ps := NewStoreWithClock(tx, func() time.Time {
+				pinTime = pinTime.Add(time.Second)
+				return pinTime
+			})
+
+			listPinned := func(t *testing.T, userID int32) []*cmpgn.Campaign {
+				t.Helper()
+				have, err := ps.ListPinnedCampaigns(ctx, userID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("Pin", func(t *testing.T) {
+				for _, c := range []*cmpgn.Campaign{campaigns[2], campaigns[0]} {
+					if err := ps.PinCampaign(ctx, c.ID, 23); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := ps.PinCampaign(ctx, campaigns[1].ID, 42); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[2], campaigns[0]}
+				if diff := cmp.Diff(listPinned(t, 23), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				want = []*cmpgn.Campaign{campaigns[1]}
+				if diff := cmp.Diff(listPinned(t, 42), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("DuplicatePin", func(t *testing.T) {
+				if err := ps.PinCampaign(ctx, campaigns[2].ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[2], campaigns[0]}
+				if diff := cmp.Diff(listPinned(t, 23), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Unpin", func(t *testing.T) {
+				if err := ps.UnpinCampaign(ctx, campaigns[2].ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[0]}
+				if diff := cmp.Diff(listPinned(t, 23), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				// Unpinning doesn't affect the pins of other users.
+				want = []*cmpgn.Campaign{campaigns[1]}
+				if diff := cmp.Diff(listPinned(t, 42), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("TemplatesOmitted", func(t *testing.T) {
+				template := &cmpgn.Campaign{
+					Name:            "Pinned template",
+					AuthorID:        23,
+					NamespaceUserID: 23,
+					IsTemplate:      true,
+				}
+				if err := s.CreateCampaign(ctx, template); err != nil {
+					t.Fatal(err)
+				}
+				if err := ps.PinCampaign(ctx, template.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[0]}
+				if diff := cmp.Diff(listPinned(t, 23), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("ListNoPins", func(t *testing.T) {
+				if have := listPinned(t, 4444); len(have) != 0 {
+					t.Fatalf("have pinned campaigns %v, want none", have)
+				}
+			})
+		})
+
+		t.Run("CampaignViews", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 3)
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{
+					Name:            fmt.Sprintf("Viewed campaign %d", i),
+					AuthorID:        23,
+					NamespaceUserID: 23,
+				}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+			}
+
+			// Use a clock that advances on every call, so that views are
+			// ordered by the time they were recorded.
+			viewTime := now
+			vs := NewStoreWithClock(tx, func() time.Time {
+				viewTime = viewTime.Add(time.Second)
+				return viewTime
+			})
+
+			listViewed := func(t *testing.T, userID int32, limit int) []*cmpgn.Campaign {
+				t.Helper()
+				have, err := vs.ListRecentlyViewedCampaigns(ctx, userID, limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("RecordView", func(t *testing.T) {
+				for _, c := range campaigns {
+					if err := vs.RecordCampaignView(ctx, c.ID, 23); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := vs.RecordCampaignView(ctx, campaigns[0].ID, 42); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[2], campaigns[1], campaigns[0]}
+				if diff := cmp.Diff(listViewed(t, 23, 10), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				want = []*cmpgn.Campaign{campaigns[0]}
+				if diff := cmp.Diff(listViewed(t, 42, 10), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("ViewAgainBumps", func(t *testing.T) {
+				if err := vs.RecordCampaignView(ctx, campaigns[0].ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[0], campaigns[2], campaigns[1]}
+				if diff := cmp.Diff(listViewed(t, 23, 10), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Limit", func(t *testing.T) {
+				want := []*cmpgn.Campaign{campaigns[0], campaigns[2]}
+				if diff := cmp.Diff(listViewed(t, 23, 2), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				if have := listViewed(t, 23, 0); len(have) != 0 {
+					t.Fatalf("have viewed campaigns %v, want none", have)
+				}
+			})
+
+			t.Run("TemplatesOmitted", func(t *testing.T) {
+				template := &cmpgn.Campaign{
+					Name:            "Viewed template",
+					AuthorID:        23,
+					NamespaceUserID: 23,
+					IsTemplate:      true,
+				}
+				if err := s.CreateCampaign(ctx, template); err != nil {
+					t.Fatal(err)
+				}
+				if err := vs.RecordCampaignView(ctx, template.ID, 23); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.Campaign{campaigns[0], campaigns[2], campaigns[1]}
+				if diff := cmp.Diff(listViewed(t, 23, 10), want); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("ListNoViews", func(t *testing.T) {
+				if have := listViewed(t, 4444, 10); len(have) != 0 {
+					t.Fatalf("have viewed campaigns %v, want none", have)
+				}
+			})
+		})
+
+		t.Run("CampaignReminders", func(t *testing.T) {
+			c := &cmpgn.Campaign{
+				Name:            "Campaign with reminders",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			reminders := []*cmpgn.CampaignReminder{
+				{CampaignID: c.ID, UserID: 23, RemindAt: now.Add(-time.Hour)},
+				{CampaignID: c.ID, UserID: 23, RemindAt: now.Add(time.Hour)},
+				{CampaignID: c.ID, UserID: 42, RemindAt: now.Add(-2 * time.Hour)},
+			}
+
+			listDue := func(t *testing.T, asOf time.Time) []*cmpgn.CampaignReminder {
+				t.Helper()
+				have, err := s.ListDueCampaignReminders(ctx, asOf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return have
+			}
+
+			t.Run("Create", func(t *testing.T) {
+				for _, r := range reminders {
+					if err := s.CreateCampaignReminder(ctx, r); err != nil {
+						t.Fatal(err)
+					}
+
+					if r.ID == 0 {
+						t.Fatal("id should not be zero")
+					}
+
+					if have, want := r.CreatedAt, clock(); !have.Equal(want) {
+						t.Fatalf("have created_at %v, want %v", have, want)
+					}
+				}
+			})
+
+			t.Run("ListDue", func(t *testing.T) {
+				want := []*cmpgn.CampaignReminder{reminders[2], reminders[0]}
+				if diff := cmp.Diff(listDue(t, now), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				want = []*cmpgn.CampaignReminder{reminders[2], reminders[0], reminders[1]}
+				if diff := cmp.Diff(listDue(t, now.Add(2*time.Hour)), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				if have := listDue(t, now.Add(-3*time.Hour)); len(have) != 0 {
+					t.Fatalf("have due reminders %v, want none", have)
+				}
+			})
+
+			t.Run("MarkSent", func(t *testing.T) {
+				if err := s.MarkCampaignReminderSent(ctx, reminders[2].ID); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.CampaignReminder{reminders[0]}
+				if diff := cmp.Diff(listDue(t, now), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				if err := s.MarkCampaignReminderSent(ctx, reminders[2].ID); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+			})
+
+			t.Run("Delete", func(t *testing.T) {
+				if err := s.DeleteCampaignReminder(ctx, reminders[0].ID); err != nil {
+					t.Fatal(err)
+				}
+
+				want := []*cmpgn.CampaignReminder{reminders[1]}
+				if diff := cmp.Diff(listDue(t, now.Add(2*time.Hour)), want); diff != "" {
+					t.Fatal(diff)
+				}
+
+				if err := s.DeleteCampaignReminder(ctx, reminders[0].ID); err != ErrNoResults {
+					t.Fatalf("have err %v, want %v", err, ErrNoResults)
+				}
+			})
+		})
+
+		t.Run("CampaignEvents", func(t *testing.T) {
+			c := &cmpgn.Campaign{
+				Name:            "Campaign with events",
+				AuthorID:        23,
+				NamespaceUserID: 23,
+			}
+			if err := s.CreateCampaign(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			events := []*cmpgn.CampaignEvent{
+				{
+					CampaignID: c.ID,
+					ActorID:    23,
+					Kind:       cmpgn.CampaignEventKindCreated,
+					Changes: map[string]cmpgn.CampaignEventChange{
+						"name": {New: c.Name},
+					},
+				},
+				{
+					CampaignID: c.ID,
+					Kind:       cmpgn.CampaignEventKindClosed,
+				},
+			}
+
+			t.Run("Create", func(t *testing.T) {
+				for _, e := range events {
+					if err := s.CreateCampaignEvent(ctx, e); err != nil {
+						t.Fatal(err)
+					}
+
+					if e.ID == 0 {
+						t.Fatal("id should not be zero")
+					}
+
+					if have, want := e.CreatedAt, clock(); !have.Equal(want) {
+						t.Fatalf("have created_at %v, want %v", have, want)
+					}
+				}
+			})
+
+			t.Run("List", func(t *testing.T) {
+				have, err := s.ListCampaignEvents(ctx, c.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Events without changes are read back with an empty map.
+				events[1].Changes = map[string]cmpgn.CampaignEventChange{}
+
+				if diff := cmp.Diff(have, events); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("ListNoEvents", func(t *testing.T) {
+				have, err := s.ListCampaignEvents(ctx, c.ID+1000)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(have) != 0 {
+					t.Fatalf("have events %v, want none", have)
+				}
+			})
+		})
+
+		t.Run("ListCampaignsByAuthor", func(t *testing.T) {
+			var viewerID, memberOrgID, otherOrgID int32
+			err := tx.QueryRowContext(ctx, "INSERT INTO users (username) VALUES ('campaigns-viewer') RETURNING id").Scan(&viewerID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tx.QueryRowContext(ctx, "INSERT INTO orgs (name) VALUES ('campaigns-member-org') RETURNING id").Scan(&memberOrgID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tx.QueryRowContext(ctx, "INSERT INTO orgs (name) VALUES ('campaigns-other-org') RETURNING id").Scan(&otherOrgID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO org_members (org_id, user_id) VALUES ($1, $2)", memberOrgID, viewerID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			const authorID = 4242
+			campaigns := []*cmpgn.Campaign{
+				{Name: "Viewer namespace", AuthorID: authorID, NamespaceUserID: viewerID},
+				{Name: "Member org namespace", AuthorID: authorID, NamespaceOrgID: memberOrgID},
+				{Name: "Other org namespace", AuthorID: authorID, NamespaceOrgID: otherOrgID},
+				{Name: "Other user namespace", AuthorID: authorID, NamespaceUserID: authorID},
+				{Name: "Other author", AuthorID: authorID + 1, NamespaceOrgID: memberOrgID},
+				{Name: "Template", AuthorID: authorID, NamespaceUserID: viewerID, IsTemplate: true},
+			}
+			for _, c := range campaigns {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			t.Run("Accessible", func(t *testing.T) {
+				// Templates are excluded.
+				have, err := s.ListCampaignsByAuthor(ctx, authorID, viewerID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, campaigns[:2]); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Author", func(t *testing.T) {
+				have, err := s.ListCampaignsByAuthor(ctx, authorID, authorID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, campaigns[3:4]); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+
+			t.Run("Inaccessible", func(t *testing.T) {
+				have, err := s.ListCampaignsByAuthor(ctx, authorID, viewerID+1)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(have) != 0 {
+					t.Fatalf("have campaigns %v, want none", have)
+				}
+			})
+
+			t.Run("Anonymous", func(t *testing.T) {
+				have, err := s.ListCampaignsByAuthor(ctx, authorID, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(have) != 0 {
+					t.Fatalf("have campaigns %v, want none", have)
+				}
+			})
+
+			t.Run("Paginated", func(t *testing.T) {
+				// All campaigns are listed, even if there are more than fit
+				// on a page.
+				conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{CampaignsMaxListLimit: 1}})
+				defer conf.Mock(nil)
+
+				have, err := s.ListCampaignsByAuthor(ctx, authorID, viewerID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, campaigns[:2]); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		})
+
+		t.Run("ListCampaigns AccessibleToUserID", func(t *testing.T) {
+			var userID, firstOrgID, secondOrgID, otherOrgID int32
+			err := tx.QueryRowContext(ctx, "INSERT INTO users (username) VALUES ('campaigns-accessible-user') RETURNING id").Scan(&userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, id := range map[string]*int32{
+				"campaigns-accessible-first-org":  &firstOrgID,
+				"campaigns-accessible-second-org": &secondOrgID,
+				"campaigns-accessible-other-org":  &otherOrgID,
+			} {
+				err = tx.QueryRowContext(ctx, "INSERT INTO orgs (name) VALUES ($1) RETURNING id", name).Scan(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, orgID := range []int32{firstOrgID, secondOrgID} {
+				_, err = tx.ExecContext(ctx, "INSERT INTO org_members (org_id, user_id) VALUES ($1, $2)", orgID, userID)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			campaigns := []*cmpgn.Campaign{
+				{Name: "Personal rollout", AuthorID: userID, NamespaceUserID: userID},
+				{Name: "First org rollout", AuthorID: 23, NamespaceOrgID: firstOrgID},
+				{Name: "Second org cleanup", AuthorID: 23, NamespaceOrgID: secondOrgID},
+				{Name: "Other org rollout", AuthorID: 23, NamespaceOrgID: otherOrgID},
+				{Name: "Other user rollout", AuthorID: 23, NamespaceUserID: userID + 1},
+			}
+			for _, c := range campaigns {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, tc := range []struct {
+				name  string
+				query string
+				want  []*cmpgn.Campaign
+			}{
+				{name: "All", want: campaigns[:3]},
+				{name: "WithQuery", query: "rollout", want: campaigns[:2]},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{
+						AccessibleToUserID: userID,
+						Query:              tc.query,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if diff := cmp.Diff(have, tc.want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					count, err := s.CountCampaigns(ctx, CountCampaignsOpts{
+						AccessibleToUserID: userID,
+						Query:              tc.query,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if have, want := count, int64(len(tc.want)); have != want {
+						t.Fatalf("have count: %d, want: %d", have, want)
+					}
+				})
+			}
+		})
+
+		t.Run("ListCampaigns WithNamespaceName", func(t *testing.T) {
+			var userID, plainUserID, orgID, deletedOrgID int32
+			for _, row := range []struct {
+				query string
+				id    *int32
+			}{
+				{"INSERT INTO users (username, display_name) VALUES ('campaigns-namespace-user', 'Namespace User') RETURNING id", &userID},
+				{"INSERT INTO users (username) VALUES ('campaigns-namespace-plain-user') RETURNING id", &plainUserID},
+				{"INSERT INTO orgs (name, display_name) VALUES ('campaigns-namespace-org', 'Namespace Org') RETURNING id", &orgID},
+				{"INSERT INTO orgs (name, deleted_at) VALUES ('campaigns-namespace-deleted-org', now()) RETURNING id", &deletedOrgID},
+			} {
+				if err := tx.QueryRowContext(ctx, row.query).Scan(row.id); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			campaigns := []*cmpgn.Campaign{
+				{Name: "Namespacednamecampaign user", AuthorID: 23, NamespaceUserID: userID},
+				{Name: "Namespacednamecampaign plain user", AuthorID: 23, NamespaceUserID: plainUserID},
+				{Name: "Namespacednamecampaign org", AuthorID: 23, NamespaceOrgID: orgID},
+				{Name: "Namespacednamecampaign deleted org", AuthorID: 23, NamespaceOrgID: deletedOrgID},
+			}
+			for _, c := range campaigns {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "Namespacednamecampaign", WithNamespaceName: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := make([]*cmpgn.Campaign, len(campaigns))
+			for i, name := range []string{"Namespace User", "campaigns-namespace-plain-user", "Namespace Org", DeletedNamespaceName} {
+				want[i] = campaigns[i].Clone()
+				want[i].NamespaceName = name
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			t.Run("Paginated", func(t *testing.T) {
+				have, next, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "Namespacednamecampaign", WithNamespaceName: true, Limit: 2})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, want[:2]); diff != "" {
+					t.Fatal(diff)
+				}
+				if next != want[2].ID {
+					t.Fatalf("have next %d, want %d", next, want[2].ID)
+				}
+			})
+
+			t.Run("WithoutNamespaceName", func(t *testing.T) {
+				have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "Namespacednamecampaign"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, campaigns); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		})
+
+		t.Run("ListCampaigns WholeWord", func(t *testing.T) {
+			precision := &cmpgn.Campaign{Name: "Wholewordcampaign precision", Description: "Improve the precision of metrics", AuthorID: 23, NamespaceUserID: 23}
+			pipeline := &cmpgn.Campaign{Name: "Wholewordcampaign pipeline", Description: "Speed up the CI pipeline", AuthorID: 23, NamespaceUserID: 23}
+			for _, c := range []*cmpgn.Campaign{precision, pipeline} {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, tc := range []struct {
+				name string
+				opts ListCampaignsOpts
+				want []*cmpgn.Campaign
+			}{
+				{name: "Substring", opts: ListCampaignsOpts{Query: "Wholewordcampaign ci"}, want: []*cmpgn.Campaign{precision, pipeline}},
+				{name: "WholeWord", opts: ListCampaignsOpts{Query: "Wholewordcampaign ci", WholeWord: true}, want: []*cmpgn.Campaign{pipeline}},
+				{name: "WholeWordExclude", opts: ListCampaignsOpts{Query: "Wholewordcampaign -ci", WholeWord: true}, want: []*cmpgn.Campaign{precision}},
+				{name: "WholeWordPhrase", opts: ListCampaignsOpts{Query: "Wholewordcampaign CI pipeline", WholeWord: true}, want: []*cmpgn.Campaign{pipeline}},
+				{name: "WholeWordPartial", opts: ListCampaignsOpts{Query: "Wholewordcampaign pipe", WholeWord: true}, want: []*cmpgn.Campaign{}},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					have, _, err := s.ListCampaigns(ctx, tc.opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(have, tc.want); diff != "" {
+						t.Fatal(diff)
+					}
+
+					count, err := s.CountCampaigns(ctx, *tc.opts.CountOpts())
+					if err != nil {
+						t.Fatal(err)
+					}
+					if count != int64(len(tc.want)) {
+						t.Fatalf("have count %d, want %d", count, len(tc.want))
+					}
+				})
+			}
+		})
+
+		t.Run("ListCampaigns DuplicateNames", func(t *testing.T) {
+			campaigns := make([]*cmpgn.Campaign, 0, 3)
+			for i := 0; i < cap(campaigns); i++ {
+				c := &cmpgn.Campaign{Name: "Duplicatename", AuthorID: 23, NamespaceUserID: 23}
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+				campaigns = append(campaigns, c)
+			}
+
+			// Paging through Campaigns with the same name must return each of
+			// them exactly once, in the same order every time.
+			for i := 0; i < 2; i++ {
+				var have []*cmpgn.Campaign
+				var cursor int64
+				for {
+					page, next, err := s.ListCampaigns(ctx, ListCampaignsOpts{
+						Query:  "Duplicatename",
+						Cursor: cursor,
+						Limit:  1,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					have = append(have, page...)
+					if next == 0 {
+						break
+					}
+					cursor = next
+				}
+
+				if diff := cmp.Diff(have, campaigns); diff != "" {
+					t.Fatalf("call %d: %s", i, diff)
+				}
+			}
+		})
+
+		t.Run("CampaignTemplates", func(t *testing.T) {
+			template := &cmpgn.Campaign{
+				Name:           "Templatecampaign",
+				Description:    "Bump the Go version",
+				Branch:         "bump-go",
+				AuthorID:       23,
+				NamespaceOrgID: 23,
+				ClosedAt:       now,
+				IsTemplate:     true,
+				IdempotencyKey: "template",
+				CampaignPlanID: 4711,
+				ChangesetIDs:   []int64{4711},
+			}
+			if err := s.CreateCampaign(ctx, template); err != nil {
+				t.Fatal(err)
+			}
+			regular := &cmpgn.Campaign{Name: "Templatecampaign", AuthorID: 23, NamespaceOrgID: 23}
+			if err := s.CreateCampaign(ctx, regular); err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("ListExcludesTemplates", func(t *testing.T) {
+				have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{Query: "Templatecampaign"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, []*cmpgn.Campaign{regular}); diff != "" {
+					t.Fatal(diff)
+				}
+
+				count, err := s.CountCampaigns(ctx, CountCampaignsOpts{Query: "Templatecampaign"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != 1 {
+					t.Fatalf("have count %d, want 1", count)
+				}
 			})
 
-			t.Run("Update", func(t *testing.T) {
-				for _, c := range campaigns {
-					c.Name += "-updated"
-					c.Description += "-updated"
-					c.AuthorID++
-					c.ClosedAt = c.ClosedAt.Add(5 * time.Second)
+			t.Run("ListTemplateOnly", func(t *testing.T) {
+				have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{
+					Query:        "Templatecampaign",
+					TemplateOnly: true,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(have, []*cmpgn.Campaign{template}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
 
-					if c.NamespaceUserID != 0 {
-						c.NamespaceUserID++
+			t.Run("ListMatchesCount", func(t *testing.T) {
+				for _, opts := range []ListCampaignsOpts{
+					{Query: "Templatecampaign"},
+					{Query: "Templatecampaign", TemplateOnly: true},
+					{Query: "Templatecampaign", State: cmpgn.CampaignStateOpen},
+					{Query: "Templatecampaign", State: cmpgn.CampaignStateClosed},
+					{Query: "Templatecampaign", State: cmpgn.CampaignStateClosed, TemplateOnly: true},
+					{ChangesetID: 4711, TemplateOnly: true},
+					{AccessibleToUserID: 23},
+					{AuthorID: 42},
+					{CollaboratorUserID: 42},
+					{RelatedToUserID: 42},
+				} {
+					have, _, err := s.ListCampaigns(ctx, opts)
+					if err != nil {
+						t.Fatal(err)
 					}
 
-					if c.NamespaceOrgID != 0 {
-						c.NamespaceOrgID++
+					count, err := s.CountCampaigns(ctx, *opts.CountOpts())
+					if err != nil {
+						t.Fatal(err)
 					}
+					if int64(len(have)) != count {
+						t.Fatalf("opts %+v: listed %d campaigns, counted %d", opts, len(have), count)
+					}
+				}
+			})
 
-					now = now.Add(time.Second)
-					want := c
-					want.UpdatedAt = now
+			t.Run("Instantiate", func(t *testing.T) {
+				have, err := s.InstantiateCampaignTemplate(ctx, template.ID, "Instantiated", 42, 42, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
 
-					have := c.Clone()
-					if err := s.UpdateCampaign(ctx, have); err != nil {
-						t.Fatal(err)
-					}
+				want := &cmpgn.Campaign{
+					ID:              have.ID,
+					Name:            "Instantiated",
+					Description:     template.Description,
+					Branch:          template.Branch,
+					AuthorID:        42,
+					NamespaceUserID: 42,
+					CreatedAt:       now,
+					UpdatedAt:       now,
+					ChangesetIDs:    []int64{},
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
 
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
-					}
+				changes := map[string]cmpgn.CampaignEventChange{
+					"name":     {New: "Instantiated"},
+					"template": {New: strconv.FormatInt(template.ID, 10)},
+				}
+				if template.Description != "" {
+					changes["description"] = cmpgn.CampaignEventChange{New: template.Description}
+				}
+				if template.Branch != "" {
+					changes["branch"] = cmpgn.CampaignEventChange{New: template.Branch}
+				}
+				assertCampaignEvents(ctx, t, s, have.ID, &cmpgn.CampaignEvent{
+					ActorID: 42,
+					Kind:    cmpgn.CampaignEventKindCreated,
+					Changes: changes,
+				})
+			})
 
-					// Test that duplicates are not introduced.
-					have.ChangesetIDs = append(have.ChangesetIDs, have.ChangesetIDs...)
-					if err := s.UpdateCampaign(ctx, have); err != nil {
-						t.Fatal(err)
-					}
+			t.Run("InstantiateNoTemplate", func(t *testing.T) {
+				_, err := s.InstantiateCampaignTemplate(ctx, regular.ID, "Instantiated", 42, 42, 0)
+				if err != ErrNoCampaignTemplate {
+					t.Fatalf("have err %v, want %v", err, ErrNoCampaignTemplate)
+				}
+			})
+		})
 
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
-					}
+		t.Run("CampaignsSearchPlan", func(t *testing.T) {
+			// With only a few campaigns the planner prefers sequential scans,
+			// so disable them to see which indexes the search can use.
+			if _, err := tx.ExecContext(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if _, err := tx.ExecContext(ctx, "RESET enable_seqscan"); err != nil {
+					t.Fatal(err)
+				}
+			}()
 
-					// Test we can add to the set.
-					have.ChangesetIDs = append(have.ChangesetIDs, 42)
-					want.ChangesetIDs = append(want.ChangesetIDs, 42)
+			q := listCampaignsQuery(&ListCampaignsOpts{Query: "bump golang", Limit: 10})
+			rows, err := tx.QueryContext(ctx, "EXPLAIN "+q.Query(sqlf.PostgresBindVar), q.Args()...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
 
-					if err := s.UpdateCampaign(ctx, have); err != nil {
-						t.Fatal(err)
-					}
+			var plan strings.Builder
+			for rows.Next() {
+				var line string
+				if err := rows.Scan(&line); err != nil {
+					t.Fatal(err)
+				}
+				plan.WriteString(line + "\n")
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
 
-					sort.Slice(have.ChangesetIDs, func(a, b int) bool {
-						return have.ChangesetIDs[a] < have.ChangesetIDs[b]
-					})
+			for _, index := range []string{"campaigns_name_trgm", "campaigns_description_trgm"} {
+				if !strings.Contains(plan.String(), index) {
+					t.Errorf("plan doesn't use index %s:\n%s", index, plan.String())
+				}
+			}
+		})
 
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
-					}
+		t.Run("FindSimilarCampaigns", func(t *testing.T) {
+			const namespaceOrgID = 4343
+			campaigns := []*cmpgn.Campaign{
+				{Name: "Migrate logging to zap", AuthorID: 23, NamespaceOrgID: namespaceOrgID},
+				{Name: "Remove deprecated APIs", AuthorID: 23, NamespaceOrgID: namespaceOrgID},
+				{Name: "Migrate logging to zap", AuthorID: 23, NamespaceOrgID: namespaceOrgID + 1},
+			}
+			for _, c := range campaigns {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
 
-					// Test we can remove from the set.
-					have.ChangesetIDs = have.ChangesetIDs[:0]
-					want.ChangesetIDs = want.ChangesetIDs[:0]
+			t.Run("TypoVariant", func(t *testing.T) {
+				have, err := s.FindSimilarCampaigns(ctx, FindSimilarCampaignsOpts{
+					Name:           "Migrate loging to zap",
+					NamespaceOrgID: namespaceOrgID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
 
-					if err := s.UpdateCampaign(ctx, have); err != nil {
-						t.Fatal(err)
-					}
+				if diff := cmp.Diff(have, campaigns[:1]); diff != "" {
+					t.Fatal(diff)
+				}
+			})
 
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
-					}
+			t.Run("NothingSimilar", func(t *testing.T) {
+				have, err := s.FindSimilarCampaigns(ctx, FindSimilarCampaignsOpts{
+					Name:           "Bump the Go version",
+					NamespaceOrgID: namespaceOrgID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(have) != 0 {
+					t.Fatalf("have campaigns %v, want none", have)
 				}
 			})
+		})
 
-			t.Run("Get", func(t *testing.T) {
-				t.Run("ByID", func(t *testing.T) {
-					want := campaigns[0]
-					opts := GetCampaignOpts{ID: want.ID}
+		t.Run("CountDistinctCampaignAuthorsAndNamespaces", func(t *testing.T) {
+			// Authors 61 and 62 both have Campaigns in org 6161, and author
+			// 63 only has a template.
+			for _, c := range []*cmpgn.Campaign{
+				{Name: "Distinctcount personal", AuthorID: 61, NamespaceUserID: 61},
+				{Name: "Distinctcount shared 1", AuthorID: 61, NamespaceOrgID: 6161},
+				{Name: "Distinctcount shared 2", AuthorID: 62, NamespaceOrgID: 6161},
+				{Name: "Distinctcount other", AuthorID: 62, NamespaceOrgID: 6162, ClosedAt: now},
+				{Name: "Distinctcount template", AuthorID: 63, NamespaceOrgID: 6163, IsTemplate: true},
+			} {
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+			}
 
-					have, err := s.GetCampaign(ctx, opts)
+			for _, tc := range []struct {
+				name                        string
+				opts                        CountCampaignsOpts
+				wantAuthors, wantNamespaces int64
+			}{
+				{name: "All", opts: CountCampaignsOpts{Query: "Distinctcount"}, wantAuthors: 2, wantNamespaces: 3},
+				{name: "Open", opts: CountCampaignsOpts{Query: "Distinctcount", State: cmpgn.CampaignStateOpen}, wantAuthors: 2, wantNamespaces: 2},
+				{name: "Shared", opts: CountCampaignsOpts{Query: "Distinctcount shared"}, wantAuthors: 2, wantNamespaces: 1},
+				{name: "TemplateOnly", opts: CountCampaignsOpts{Query: "Distinctcount", TemplateOnly: true}, wantAuthors: 1, wantNamespaces: 1},
+				{name: "NoMatches", opts: CountCampaignsOpts{Query: "Distinctcount nothing"}},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					authors, err := s.CountDistinctCampaignAuthors(ctx, tc.opts)
 					if err != nil {
 						t.Fatal(err)
 					}
-
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
+					if authors != tc.wantAuthors {
+						t.Errorf("have %d authors, want %d", authors, tc.wantAuthors)
 					}
-				})
-
-				t.Run("ByCampaignPlanID", func(t *testing.T) {
-					want := campaigns[0]
-					opts := GetCampaignOpts{CampaignPlanID: want.CampaignPlanID}
 
-					have, err := s.GetCampaign(ctx, opts)
+					namespaces, err := s.CountDistinctCampaignNamespaces(ctx, tc.opts)
 					if err != nil {
 						t.Fatal(err)
 					}
-
-					if diff := cmp.Diff(have, want); diff != "" {
-						t.Fatal(diff)
+					if namespaces != tc.wantNamespaces {
+						t.Errorf("have %d namespaces, want %d", namespaces, tc.wantNamespaces)
 					}
 				})
+			}
+		})
 
-				t.Run("NoResults", func(t *testing.T) {
-					opts := GetCampaignOpts{ID: 0xdeadbeef}
-
-					_, have := s.GetCampaign(ctx, opts)
-					want := ErrNoResults
+		t.Run("GetNamespaceCampaignStats", func(t *testing.T) {
+			const namespaceUserID = 9191
 
-					if have != want {
-						t.Fatalf("have err %v, want %v", have, want)
+			// The CampaignJobs and ChangesetJobs created here are deleted
+			// again so they don't show up in the tests below.
+			var campaignJobIDs, changesetJobIDs []int64
+			defer func() {
+				for _, id := range changesetJobIDs {
+					if err := s.DeleteChangesetJob(ctx, id); err != nil {
+						t.Fatal(err)
 					}
-				})
-			})
-
-			t.Run("Delete", func(t *testing.T) {
-				for i := range campaigns {
-					err := s.DeleteCampaign(ctx, campaigns[i].ID)
-					if err != nil {
+				}
+				for _, id := range campaignJobIDs {
+					if err := s.DeleteCampaignJob(ctx, id); err != nil {
 						t.Fatal(err)
 					}
+				}
+			}()
 
-					count, err := s.CountCampaigns(ctx, CountCampaignsOpts{})
-					if err != nil {
+			create := func(c *cmpgn.Campaign, jobsWithChangesetJob, jobsWithoutChangesetJob int) {
+				t.Helper()
+
+				c.AuthorID = 23
+				c.NamespaceUserID = namespaceUserID
+				if err := s.CreateCampaign(ctx, c); err != nil {
+					t.Fatal(err)
+				}
+
+				for i := 0; i < jobsWithChangesetJob+jobsWithoutChangesetJob; i++ {
+					job := &cmpgn.CampaignJob{CampaignPlanID: c.CampaignPlanID, RepoID: 1, BaseRef: "master"}
+					if err := s.CreateCampaignJob(ctx, job); err != nil {
 						t.Fatal(err)
 					}
-
-					if have, want := count, int64(len(campaigns)-(i+1)); have != want {
-						t.Fatalf("have count: %d, want: %d", have, want)
+					campaignJobIDs = append(campaignJobIDs, job.ID)
+					if i >= jobsWithChangesetJob {
+						continue
+					}
+					changesetJob := &cmpgn.ChangesetJob{CampaignID: c.ID, CampaignJobID: job.ID}
+					if err := s.CreateChangesetJob(ctx, changesetJob); err != nil {
+						t.Fatal(err)
 					}
+					changesetJobIDs = append(changesetJobIDs, changesetJob.ID)
+				}
+			}
+
+			// Manual campaigns are published on creation.
+			create(&cmpgn.Campaign{Name: "manual"}, 0, 0)
+			create(&cmpgn.Campaign{Name: "manual closed", ClosedAt: now}, 0, 0)
+			// Campaigns created from a plan are drafts until all of their
+			// ChangesetJobs have been created.
+			create(&cmpgn.Campaign{Name: "draft", CampaignPlanID: 9901}, 0, 2)
+			create(&cmpgn.Campaign{Name: "partially published", CampaignPlanID: 9902}, 1, 1)
+			create(&cmpgn.Campaign{Name: "published", CampaignPlanID: 9903}, 2, 0)
+			// Campaigns in other namespaces are not counted.
+			if err := s.CreateCampaign(ctx, &cmpgn.Campaign{Name: "other", AuthorID: 23, NamespaceUserID: namespaceUserID + 1}); err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("Namespace", func(t *testing.T) {
+				have, err := s.GetNamespaceCampaignStats(ctx, namespaceUserID, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := &cmpgn.CampaignStats{
+					Total:     5,
+					Open:      4,
+					Closed:    1,
+					Draft:     2,
+					Published: 3,
+				}
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
 				}
 			})
 
+			t.Run("EmptyNamespace", func(t *testing.T) {
+				have, err := s.GetNamespaceCampaignStats(ctx, 0, 9292)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, &cmpgn.CampaignStats{}); diff != "" {
+					t.Fatal(diff)
+				}
+			})
 		})
 
 		t.Run("Changesets", func(t *testing.T) {
@@ -2526,6 +4722,30 @@ func testStore(db *sql.DB) func(*testing.T) {
 	}
 }
 
+// assertCampaignEvents fails the test if the events of the Campaign with the
+// given ID don't match want, ignoring their IDs and creation times.
+func assertCampaignEvents(ctx context.Context, t *testing.T, s *Store, campaignID int64, want ...*cmpgn.CampaignEvent) {
+	t.Helper()
+
+	have, err := s.ListCampaignEvents(ctx, campaignID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range have {
+		e.ID, e.CreatedAt = 0, time.Time{}
+	}
+	for _, e := range want {
+		e.CampaignID = campaignID
+	}
+	if want == nil {
+		want = []*cmpgn.CampaignEvent{}
+	}
+
+	if diff := cmp.Diff(have, want); diff != "" {
+		t.Fatalf("campaign %d events: %s", campaignID, diff)
+	}
+}
+
 func testProcessCampaignJob(db *sql.DB) func(*testing.T) {
 	return func(t *testing.T) {
 		now := time.Now().UTC().Truncate(time.Microsecond)
@@ -2718,3 +4938,242 @@ func testStoreLocking(db *sql.DB) func(*testing.T) {
 		}
 	}
 }
+
+func TestParseCampaignsSearchQuery(t *testing.T) {
+	tests := []struct {
+		query       string
+		wantInclude []string
+		wantExclude []string
+	}{
+		{query: ""},
+		{query: "infra", wantInclude: []string{"infra"}},
+		{query: "infra -deprecated", wantInclude: []string{"infra"}, wantExclude: []string{"deprecated"}},
+		{query: "-a -b", wantExclude: []string{"a", "b"}},
+		{query: `\-deprecated`, wantInclude: []string{"-deprecated"}},
+		{query: "infra -", wantInclude: []string{"infra"}},
+	}
+
+	for _, tc := range tests {
+		include, exclude := parseCampaignsSearchQuery(tc.query)
+		if diff := cmp.Diff(include, tc.wantInclude); diff != "" {
+			t.Errorf("query %q: include: %s", tc.query, diff)
+		}
+		if diff := cmp.Diff(exclude, tc.wantExclude); diff != "" {
+			t.Errorf("query %q: exclude: %s", tc.query, diff)
+		}
+	}
+}
+
+func TestPlanCampaignReassignments(t *testing.T) {
+	moving := []*cmpgn.Campaign{
+		{ID: 1, Name: "B"},
+		{ID: 2, Name: "A"},
+		{ID: 3, Name: "A"},
+		{ID: 4, Name: "A (2)"},
+	}
+	taken := []string{"A", "A (1)", "B"}
+
+	t.Run("Fail", func(t *testing.T) {
+		_, err := planCampaignReassignments(moving, taken, OnConflictFail)
+		collision, ok := err.(*CampaignNameCollisionError)
+		if !ok {
+			t.Fatalf("have err %v, want *CampaignNameCollisionError", err)
+		}
+		if diff := cmp.Diff(collision.Names, []string{"A", "B"}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		have, err := planCampaignReassignments(moving, taken, OnConflictSkip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*CampaignReassignment{
+			{CampaignID: 1, Name: "B", Outcome: CampaignReassignmentSkipped},
+			{CampaignID: 2, Name: "A", Outcome: CampaignReassignmentSkipped},
+			{CampaignID: 3, Name: "A", Outcome: CampaignReassignmentSkipped},
+			{CampaignID: 4, Name: "A (2)", Outcome: CampaignReassignmentMoved},
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Suffix", func(t *testing.T) {
+		have, err := planCampaignReassignments(moving, taken, OnConflictSuffix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Suffixes skip the names that are taken in the target namespace and
+		// the names of the other moved campaigns.
+		want := []*CampaignReassignment{
+			{CampaignID: 1, Name: "B (1)", Outcome: CampaignReassignmentRenamed},
+			{CampaignID: 2, Name: "A (3)", Outcome: CampaignReassignmentRenamed},
+			{CampaignID: 3, Name: "A (4)", Outcome: CampaignReassignmentRenamed},
+			{CampaignID: 4, Name: "A (2)", Outcome: CampaignReassignmentMoved},
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestCampaignSearchMatches(t *testing.T) {
+	c := &cmpgn.Campaign{
+		Name:        "Update Go to 1.14",
+		Description: "Über-campaign: go.mod files that still use an old Go version",
+	}
+
+	tests := []struct {
+		query     string
+		wholeWord bool
+		want      []CampaignSearchMatch
+	}{
+		{query: ""},
+		{query: "rust"},
+		{
+			query: "update",
+			want:  []CampaignSearchMatch{{Field: CampaignSearchFieldName, Offset: 0, Length: 6}},
+		},
+		{
+			query: "GO",
+			want: []CampaignSearchMatch{
+				{Field: CampaignSearchFieldName, Offset: 7, Length: 2},
+				{Field: CampaignSearchFieldDescription, Offset: 15, Length: 2},
+				{Field: CampaignSearchFieldDescription, Offset: 50, Length: 2},
+			},
+		},
+		{
+			// Offsets are measured in characters, so the multi-byte "Ü"
+			// counts as one.
+			query: "über version",
+			want: []CampaignSearchMatch{
+				{Field: CampaignSearchFieldDescription, Offset: 0, Length: 4},
+				{Field: CampaignSearchFieldDescription, Offset: 53, Length: 7},
+			},
+		},
+		{
+			query: "1.14 -deprecated",
+			want:  []CampaignSearchMatch{{Field: CampaignSearchFieldName, Offset: 13, Length: 4}},
+		},
+		{
+			query: "old",
+			want: []CampaignSearchMatch{
+				{Field: CampaignSearchFieldDescription, Offset: 46, Length: 3},
+			},
+		},
+		{
+			// "ill" only occurs within "still".
+			query:     "ill mod",
+			wholeWord: true,
+			want:      []CampaignSearchMatch{{Field: CampaignSearchFieldDescription, Offset: 18, Length: 3}},
+		},
+		{
+			query: "ill",
+			want:  []CampaignSearchMatch{{Field: CampaignSearchFieldDescription, Offset: 35, Length: 3}},
+		},
+	}
+
+	for _, tc := range tests {
+		have := CampaignSearchMatches(c, tc.query, tc.wholeWord)
+		if diff := cmp.Diff(have, tc.want); diff != "" {
+			t.Errorf("query %q: %s", tc.query, diff)
+		}
+	}
+}
+
+// testStoreCampaignWriteErrors runs each test in its own transaction, since
+// the constraint violations they provoke abort the transaction.
+func testStoreCampaignWriteErrors(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+
+		for _, tc := range []struct {
+			name     string
+			existing *cmpgn.Campaign
+			campaign *cmpgn.Campaign
+			want     error
+			is       func(error) bool
+		}{
+			{
+				name:     "BlankName",
+				campaign: &cmpgn.Campaign{AuthorID: 23, NamespaceUserID: 23},
+				want:     ErrCampaignNameBlank,
+				is:       IsInvalidInput,
+			},
+			{
+				name:     "WithoutNamespace",
+				campaign: &cmpgn.Campaign{Name: "no namespace", AuthorID: 23},
+				want:     ErrCampaignNamespace,
+				is:       IsInvalidInput,
+			},
+			{
+				name:     "DuplicateIdempotencyKey",
+				existing: &cmpgn.Campaign{Name: "idempotent", AuthorID: 23, NamespaceUserID: 23, IdempotencyKey: "errors"},
+				campaign: &cmpgn.Campaign{Name: "idempotent", AuthorID: 23, NamespaceUserID: 23, IdempotencyKey: "errors"},
+				want:     ErrCampaignIdempotencyKeyExists,
+				is:       IsConflict,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				tx, done := dbtest.NewTx(t, db)
+				defer done()
+				s := NewStore(tx)
+
+				if tc.existing != nil {
+					if err := s.CreateCampaign(ctx, tc.existing); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				err := s.CreateCampaign(ctx, tc.campaign)
+				if err != tc.want || !tc.is(err) {
+					t.Fatalf("have err %v, want %v", err, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestListCampaignsOptsCountOpts(t *testing.T) {
+	// Every filter of CountCampaignsOpts must be set from the ListCampaignsOpts
+	// field of the same name, so that counts match listings.
+	var list ListCampaignsOpts
+	want := &CountCampaignsOpts{}
+	lv, wv := reflect.ValueOf(&list).Elem(), reflect.ValueOf(want).Elem()
+	for i := 0; i < wv.NumField(); i++ {
+		name := wv.Type().Field(i).Name
+		f := lv.FieldByName(name)
+		if !f.IsValid() {
+			t.Fatalf("ListCampaignsOpts has no field %s", name)
+		}
+		v := nonZeroValue(t, f.Type())
+		f.Set(v)
+		wv.Field(i).Set(v)
+	}
+
+	if diff := cmp.Diff(list.CountOpts(), want); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+// nonZeroValue returns a value of the given type that isn't its zero value.
+func nonZeroValue(t *testing.T, typ reflect.Type) reflect.Value {
+	t.Helper()
+	v := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Ptr:
+		v.Set(reflect.New(typ.Elem()))
+		v.Elem().Set(nonZeroValue(t, typ.Elem()))
+	default:
+		t.Fatalf("unsupported type %s", typ)
+	}
+	return v
+}
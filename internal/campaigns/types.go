@@ -100,6 +100,20 @@ type Campaign struct {
 	ChangesetIDs    []int64
 	CampaignPlanID  int64
 	ClosedAt        time.Time
+	LastUpdatedBy   int32
+	// IdempotencyKey optionally identifies the request that created the
+	// Campaign. It is unique per namespace.
+	IdempotencyKey string
+	// IsTemplate is true if the Campaign is a template from which other
+	// Campaigns can be instantiated.
+	IsTemplate bool
+	// IsLocked is true if the Campaign is read-only, e.g. during an audit.
+	// Only LockCampaign and UnlockCampaign change it.
+	IsLocked bool
+	// NamespaceName is the display name of the Campaign's namespace. It isn't
+	// stored with the Campaign and only set when listing Campaigns with their
+	// namespace names.
+	NamespaceName string
 }
 
 // Clone returns a clone of a Campaign.
@@ -109,6 +123,45 @@ func (c *Campaign) Clone() *Campaign {
 	return &cc
 }
 
+// CampaignNamespaceType is the type of the namespace a Campaign belongs to.
+type CampaignNamespaceType string
+
+// Valid CampaignNamespaceTypes.
+const (
+	CampaignNamespaceTypeUser CampaignNamespaceType = "user"
+	CampaignNamespaceTypeOrg  CampaignNamespaceType = "org"
+)
+
+// ErrInvalidCampaignNamespace is returned by Campaign.NamespaceType and
+// Campaign.NamespaceID if the Campaign doesn't belong to exactly one of a
+// user or an organization namespace.
+var ErrInvalidCampaignNamespace = errors.New("campaign must belong to exactly one of a user or an organization namespace")
+
+// NamespaceType returns the type of the namespace the Campaign belongs to.
+func (c *Campaign) NamespaceType() (CampaignNamespaceType, error) {
+	switch {
+	case c.NamespaceUserID != 0 && c.NamespaceOrgID == 0:
+		return CampaignNamespaceTypeUser, nil
+	case c.NamespaceOrgID != 0 && c.NamespaceUserID == 0:
+		return CampaignNamespaceTypeOrg, nil
+	default:
+		return "", ErrInvalidCampaignNamespace
+	}
+}
+
+// NamespaceID returns the ID of the user or organization the Campaign
+// belongs to, depending on its NamespaceType.
+func (c *Campaign) NamespaceID() (int32, error) {
+	typ, err := c.NamespaceType()
+	if err != nil {
+		return 0, err
+	}
+	if typ == CampaignNamespaceTypeUser {
+		return c.NamespaceUserID, nil
+	}
+	return c.NamespaceOrgID, nil
+}
+
 // RemoveChangesetID removes the given id from the Campaigns ChangesetIDs slice.
 // If the id is not in ChangesetIDs calling this method doesn't have an effect.
 func (c *Campaign) RemoveChangesetID(id int64) {
@@ -119,6 +172,51 @@ func (c *Campaign) RemoveChangesetID(id int64) {
 	}
 }
 
+// CampaignEventKind defines the kind of a CampaignEvent.
+type CampaignEventKind string
+
+// Valid CampaignEvent kinds
+const (
+	CampaignEventKindCreated CampaignEventKind = "CREATED"
+	CampaignEventKindUpdated CampaignEventKind = "UPDATED"
+	CampaignEventKindClosed  CampaignEventKind = "CLOSED"
+	// CampaignEventKindDeleted events record the reason for the deletion
+	// as the new value of the "reason" attribute.
+	CampaignEventKindDeleted CampaignEventKind = "DELETED"
+)
+
+// A CampaignEvent is an entry in the activity timeline of a Campaign,
+// recording a mutation of the Campaign and the user who performed it.
+type CampaignEvent struct {
+	ID         int64
+	CampaignID int64
+	ActorID    int32
+	Kind       CampaignEventKind
+	// Changes maps the names of the Campaign attributes changed by the
+	// event to their old and new values.
+	Changes   map[string]CampaignEventChange
+	CreatedAt time.Time
+}
+
+// CampaignEventChange is the change of a single Campaign attribute recorded
+// in a CampaignEvent.
+type CampaignEventChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// A CampaignReminder reminds a user of a Campaign at a chosen time.
+type CampaignReminder struct {
+	ID         int64
+	CampaignID int64
+	UserID     int32
+	RemindAt   time.Time
+	// SentAt is the time the reminder was sent, or zero if it hasn't been
+	// sent yet.
+	SentAt    time.Time
+	CreatedAt time.Time
+}
+
 // ChangesetState defines the possible states of a Changeset.
 type ChangesetState string
 
@@ -159,6 +257,15 @@ const (
 	CampaignStateClosed CampaignState = "CLOSED"
 )
 
+// CampaignStats holds the number of Campaigns in a namespace.
+type CampaignStats struct {
+	Total     int64
+	Open      int64
+	Closed    int64
+	Draft     int64
+	Published int64
+}
+
 // BackgroundProcessStatus defines the status of a background process.
 type BackgroundProcessStatus struct {
 	Canceled      bool
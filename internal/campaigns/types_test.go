@@ -12,6 +12,58 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
+func TestCampaignNamespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		campaign Campaign
+		wantType CampaignNamespaceType
+		wantID   int32
+		wantErr  error
+	}{
+		{
+			name:     "user",
+			campaign: Campaign{NamespaceUserID: 23},
+			wantType: CampaignNamespaceTypeUser,
+			wantID:   23,
+		},
+		{
+			name:     "org",
+			campaign: Campaign{NamespaceOrgID: 42},
+			wantType: CampaignNamespaceTypeOrg,
+			wantID:   42,
+		},
+		{
+			name:     "both set",
+			campaign: Campaign{NamespaceUserID: 23, NamespaceOrgID: 42},
+			wantErr:  ErrInvalidCampaignNamespace,
+		},
+		{
+			name:    "neither set",
+			wantErr: ErrInvalidCampaignNamespace,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			typ, err := tc.campaign.NamespaceType()
+			if err != tc.wantErr {
+				t.Fatalf("NamespaceType: have error %v, want %v", err, tc.wantErr)
+			}
+			if typ != tc.wantType {
+				t.Errorf("NamespaceType: have %q, want %q", typ, tc.wantType)
+			}
+
+			id, err := tc.campaign.NamespaceID()
+			if err != tc.wantErr {
+				t.Fatalf("NamespaceID: have error %v, want %v", err, tc.wantErr)
+			}
+			if id != tc.wantID {
+				t.Errorf("NamespaceID: have %d, want %d", id, tc.wantID)
+			}
+		})
+	}
+}
+
 func TestChangesetMetadata(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Microsecond)
 
@@ -224,6 +224,54 @@ func CampaignsReadAccessEnabled() bool {
 	return false
 }
 
+// CampaignsCreationRateLimit returns the number of campaigns that can be created per hour in a
+// single namespace, and the number that can be created in quick succession.
+func CampaignsCreationRateLimit() (perHour float64, burst int) {
+	perHour, burst = 60, 10
+	if l := Get().CampaignsCreationRateLimit; l != nil {
+		if l.PerHour > 0 {
+			perHour = l.PerHour
+		}
+		if l.Burst > 0 {
+			burst = l.Burst
+		}
+	}
+	return perHour, burst
+}
+
+// CampaignsDescriptionLength returns the length in characters above which
+// campaign descriptions are logged (warn) and rejected (max).
+func CampaignsDescriptionLength() (warn, max int) {
+	warn, max = 64*1024, 1024*1024
+	if l := Get().CampaignsDescriptionLength; l != nil {
+		if l.Warn > 0 {
+			warn = l.Warn
+		}
+		if l.Max > 0 {
+			max = l.Max
+		}
+	}
+	return warn, max
+}
+
+// CampaignsMaxListLimit returns the maximum number of campaigns returned by a
+// single list request.
+func CampaignsMaxListLimit() int {
+	if v := Get().CampaignsMaxListLimit; v > 0 {
+		return v
+	}
+	return 1000
+}
+
+// GitMaxRawDiffBytes returns the maximum size of the raw diff text of a commit returned by the
+// GraphQL API.
+func GitMaxRawDiffBytes() int {
+	if v := Get().GitMaxRawDiffBytes; v > 0 {
+		return v
+	}
+	return 1 << 20
+}
+
 func UsingExternalURL() bool {
 	url := Get().ExternalURL
 	return !(url == "" || strings.HasPrefix(url, "http://localhost") || strings.HasPrefix(url, "https://localhost") || strings.HasPrefix(url, "http://127.0.0.1") || strings.HasPrefix(url, "https://127.0.0.1")) // CI:LOCALHOST_OK
@@ -57,6 +57,46 @@ func NewFileReader(ctx context.Context, repo gitserver.Repo, commit api.CommitID
 	return br, nil
 }
 
+// ReadBlob returns the first maxBytes of the content of the blob with the given object ID, which
+// must be a full 40-character SHA. If maxBytes <= 0, the entire blob is read. If there is no blob
+// with the ID, the error satisfies os.IsNotExist.
+func ReadBlob(ctx context.Context, repo gitserver.Repo, oid string, maxBytes int64) ([]byte, error) {
+	if Mocks.ReadBlob != nil {
+		return Mocks.ReadBlob(oid)
+	}
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: ReadBlob")
+	span.SetTag("OID", oid)
+	defer span.Finish()
+
+	if !IsAbsoluteRevision(oid) {
+		return nil, fmt.Errorf("invalid blob object ID %q: must be a 40-character SHA", oid)
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "cat-file", "blob", oid)
+	cmd.Repo = repo
+	rc, err := gitserver.StdoutReader(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	r := io.Reader(rc)
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		// `git cat-file blob` fails with "Not a valid object name" for unknown objects and with
+		// "bad file" for objects that aren't blobs.
+		if strings.Contains(err.Error(), "Not a valid object name") || strings.Contains(err.Error(), "bad file") {
+			return nil, &os.PathError{Op: "cat-file", Path: oid, Err: os.ErrNotExist}
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed", cmd.Args))
+	}
+	return data, nil
+}
+
 func readFileBytes(ctx context.Context, repo gitserver.Repo, commit api.CommitID, name string, maxBytes int64) ([]byte, error) {
 	br, err := newBlobReader(ctx, repo, commit, name)
 	if err != nil {
@@ -76,3 +76,49 @@ func TestRead(t *testing.T) {
 		}
 	})
 }
+
+func TestReadBlob(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"echo abcd > file1",
+		"git add file1",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m commit1 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+	const (
+		// `echo abcd | git hash-object --stdin`
+		blobOID = "acbe86c7c89586e0912a0a851bacf309c595c308"
+		// The tree of the commit, which is not a blob.
+		treeOID = "cb62c2578cf077c60b51cc74c77a4af80ef94866"
+	)
+
+	ctx := context.Background()
+
+	data, err := ReadBlob(ctx, repo, blobOID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "abcd\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	data, err = ReadBlob(ctx, repo, blobOID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ab"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	if _, err := ReadBlob(ctx, repo, "0000000000000000000000000000000000000000", 0); !os.IsNotExist(err) {
+		t.Errorf("nonexistent blob: got err %v, want os.IsNotExist", err)
+	}
+
+	if _, err := ReadBlob(ctx, repo, treeOID, 0); !os.IsNotExist(err) {
+		t.Errorf("tree: got err %v, want os.IsNotExist", err)
+	}
+
+	if _, err := ReadBlob(ctx, repo, "HEAD:file1", 0); err == nil || os.IsNotExist(err) {
+		t.Errorf("malformed OID: got err %v, want invalid object ID error", err)
+	}
+}
@@ -44,6 +44,10 @@ type CommitsOptions struct {
 
 	Path string // only commits modifying the given path are selected (optional)
 
+	// Follow continues listing the history of Path beyond renames. It is only used when Path is
+	// set, and it is ignored by CommitCount.
+	Follow bool
+
 	// RemoteURLFunc is called to get the Git remote URL if it's not set in
 	// repo and if it is needed. The Git remote URL is only required if the
 	// gitserver doesn't already contain a clone of the repository or if the
@@ -104,6 +108,25 @@ func Commits(ctx context.Context, repo gitserver.Repo, opt CommitsOptions) ([]*C
 	return commitLog(ctx, repo, opt)
 }
 
+// LastCommitForPath returns the most recent commit at or before the given commit that modified
+// the given path. It returns nil if the path has no history at that commit.
+func LastCommitForPath(ctx context.Context, repo gitserver.Repo, commit api.CommitID, path string) (*Commit, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: LastCommitForPath")
+	span.SetTag("Commit", commit)
+	span.SetTag("Path", path)
+	defer span.Finish()
+
+	commits, err := Commits(ctx, repo, CommitsOptions{
+		Range: string(commit),
+		N:     1,
+		Path:  path,
+	})
+	if err != nil || len(commits) == 0 {
+		return nil, err
+	}
+	return commits[0], nil
+}
+
 // HasCommitAfter indicates the staleness of a repository. It returns a boolean indicating if a repository
 // contains a commit past a specified date.
 func HasCommitAfter(ctx context.Context, repo gitserver.Repo, date string, revspec string) (bool, error) {
@@ -141,7 +164,11 @@ func isInvalidRevisionRangeError(output, obj string) bool {
 //
 // The caller is responsible for doing checkSpecArgSafety on opt.Head and opt.Base.
 func commitLog(ctx context.Context, repo gitserver.Repo, opt CommitsOptions) (commits []*Commit, err error) {
-	args, err := commitLogArgs([]string{"log", logFormatWithoutRefs}, opt)
+	initialArgs := []string{"log", logFormatWithoutRefs}
+	if opt.Follow && opt.Path != "" {
+		initialArgs = append(initialArgs, "--follow")
+	}
+	args, err := commitLogArgs(initialArgs, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -376,3 +377,137 @@ func TestRepository_Commits_options_path(t *testing.T) {
 		}
 	}
 }
+
+func TestRepository_Commits_options_follow(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"printf 'line1\\nline2\\nline3\\nline4\\n' > old",
+		"git add old",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m create --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag create",
+		"echo line5 >> old",
+		"git add old",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:06Z git commit -m change --author='a <a@a.com>' --date 2006-01-02T15:04:06Z",
+		"git tag change",
+		"git mv old new",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:07Z git commit -m rename --author='a <a@a.com>' --date 2006-01-02T15:04:07Z",
+		"git tag rename",
+		"echo line6 >> new",
+		"git add new",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:08Z git commit -m change2 --author='a <a@a.com>' --date 2006-01-02T15:04:08Z",
+		"git tag change2",
+	)
+
+	tests := map[string]struct {
+		opt  CommitsOptions
+		want []string // revspecs of the expected commits
+	}{
+		"without follow": {
+			opt:  CommitsOptions{Range: "master", Path: "new"},
+			want: []string{"change2", "rename"},
+		},
+		"with follow": {
+			opt:  CommitsOptions{Range: "master", Path: "new", Follow: true},
+			want: []string{"change2", "rename", "change", "create"},
+		},
+		"with follow and limit": {
+			opt:  CommitsOptions{Range: "master", Path: "new", Follow: true, N: 3},
+			want: []string{"change2", "rename", "change"},
+		},
+		"with follow before rename": {
+			opt:  CommitsOptions{Range: "change", Path: "old", Follow: true},
+			want: []string{"change", "create"},
+		},
+	}
+
+	for label, test := range tests {
+		commits, err := Commits(ctx, repo, test.opt)
+		if err != nil {
+			t.Errorf("%s: Commits(): %s", label, err)
+			continue
+		}
+
+		var want []api.CommitID
+		for _, rev := range test.want {
+			id, err := ResolveRevision(ctx, repo, nil, rev, nil)
+			if err != nil {
+				t.Fatalf("%s: ResolveRevision(%q): %s", label, rev, err)
+			}
+			want = append(want, id)
+		}
+
+		var got []api.CommitID
+		for _, c := range commits {
+			got = append(got, c.ID)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got commits %v, want %v", label, got, want)
+		}
+	}
+}
+
+func TestLastCommitForPath(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"echo a > unchanged",
+		"echo b > changed",
+		"git add unchanged changed",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m create --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag create",
+		"echo c > other",
+		"git add other",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m other --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"echo d >> changed",
+		"git add changed",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m change --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag change",
+		"echo e > other",
+		"git add other",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m other2 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	tests := map[string]struct {
+		rev, path string
+		want      string // revspec of the expected commit; empty if none is expected
+	}{
+		"recently changed file": {rev: "master", path: "changed", want: "change"},
+		"unchanged file":        {rev: "master", path: "unchanged", want: "create"},
+		"before change":         {rev: "create", path: "changed", want: "create"},
+		"no history":            {rev: "create", path: "other"},
+		"nonexistent path":      {rev: "master", path: "doesnt-exist"},
+	}
+
+	for label, test := range tests {
+		commitID, err := ResolveRevision(ctx, repo, nil, test.rev, nil)
+		if err != nil {
+			t.Errorf("%s: ResolveRevision(%q): %s", label, test.rev, err)
+			continue
+		}
+
+		commit, err := LastCommitForPath(ctx, repo, commitID, test.path)
+		if err != nil {
+			t.Errorf("%s: LastCommitForPath(%s, %q): %s", label, commitID, test.path, err)
+			continue
+		}
+
+		if test.want == "" {
+			if commit != nil {
+				t.Errorf("%s: got commit %s, want none", label, commit.ID)
+			}
+			continue
+		}
+
+		want, err := ResolveRevision(ctx, repo, nil, test.want, nil)
+		if err != nil {
+			t.Errorf("%s: ResolveRevision(%q): %s", label, test.want, err)
+			continue
+		}
+
+		if commit == nil || commit.ID != want {
+			t.Errorf("%s: got commit %v, want %s", label, commit, want)
+		}
+	}
+}
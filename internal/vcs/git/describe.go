@@ -0,0 +1,34 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// Describe returns a human-readable name for the commit based on the nearest annotated tag it is
+// reachable from, as `git describe` does: the tag name if the commit is tagged ("v1.2.0"), or the
+// tag name followed by the number of commits since the tag and the abbreviated commit ID
+// ("v1.2.0-5-gabcdef1"). If no annotated tag is reachable from the commit, the abbreviated commit
+// ID is returned.
+func Describe(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: Describe")
+	span.SetTag("Commit", commit)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return "", err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "describe", "--always", string(commit))
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return "", fmt.Errorf("exec `git describe` failed: %s. Output was:\n\n%s", err, out)
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
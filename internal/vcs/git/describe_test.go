@@ -0,0 +1,44 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	const commit = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	const tag = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git tag -a -m foo"
+	repo := MakeGitRepository(t,
+		commit,
+		"git tag lightweight",
+		commit,
+		tag+" v1.2.0",
+		commit,
+		commit,
+	)
+
+	// Except for a tagged commit, the abbreviated commit ID is appended to the wanted value.
+	tests := map[string]string{
+		"master~3": "",           // no annotated tag (lightweight tags are ignored)
+		"master~2": "v1.2.0",     // on a tag
+		"master":   "v1.2.0-2-g", // after a tag
+	}
+	for rev, want := range tests {
+		commitID, err := ResolveRevision(ctx, repo, nil, rev, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want != "v1.2.0" {
+			want += string(commitID[:7])
+		}
+		got, err := Describe(ctx, repo, commitID)
+		if err != nil {
+			t.Errorf("%s: Describe: %s", rev, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %q, want %q", rev, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// DiffStat summarizes the differences between two trees.
+type DiffStat struct {
+	FilesChanged int32
+	Insertions   int32
+	Deletions    int32
+}
+
+// GetDiffStat returns the number of changed files and inserted and deleted lines between the trees of
+// base and head (both Git revspecs or tree IDs). Changed binary files are counted as changed files
+// without insertions or deletions. Renames are not detected.
+func GetDiffStat(ctx context.Context, repo gitserver.Repo, base, head string) (*DiffStat, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: GetDiffStat")
+	span.SetTag("Base", base)
+	span.SetTag("Head", head)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(base); err != nil {
+		return nil, err
+	}
+	if err := checkSpecArgSafety(head); err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "diff-tree", "-r", "--numstat", "-z", base, head)
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("exec `git diff-tree` failed: %s. Output was:\n\n%s", err, out)
+	}
+	return parseNumstat(out)
+}
+
+// parseNumstat parses the output of `git diff-tree --numstat -z`, which consists of a
+// "<insertions>\t<deletions>\t<path>\x00" record per changed file. Binary files have "-" as
+// insertions and deletions.
+func parseNumstat(out []byte) (*DiffStat, error) {
+	var stat DiffStat
+	for _, record := range bytes.Split(out, []byte{0}) {
+		if len(record) == 0 {
+			continue
+		}
+		fields := bytes.SplitN(record, []byte{'\t'}, 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid `git diff-tree --numstat` output: %q", record)
+		}
+
+		stat.FilesChanged++
+		if bytes.Equal(fields[0], []byte("-")) && bytes.Equal(fields[1], []byte("-")) {
+			continue
+		}
+		insertions, err := strconv.ParseInt(string(fields[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `git diff-tree --numstat` output: %q", record)
+		}
+		deletions, err := strconv.ParseInt(string(fields[1]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `git diff-tree --numstat` output: %q", record)
+		}
+		stat.Insertions += int32(insertions)
+		stat.Deletions += int32(deletions)
+	}
+	return &stat, nil
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDiffStat(t *testing.T) {
+	t.Parallel()
+
+	const commit = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	repo := MakeGitRepository(t,
+		`printf 'a\nb\nc\n' > f`,
+		`printf 'x\000y' > bin`,
+		"git add f bin",
+		commit,
+		"git tag root",
+		`printf 'a\nB\nc\nd\n' > f`,
+		`printf 'z\000y' > bin`,
+		"echo new > g",
+		"git add f bin g",
+		commit,
+	)
+
+	// `git hash-object -t tree /dev/null`
+	const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+	tests := map[string]struct {
+		base, head string
+		want       *DiffStat
+	}{
+		"root commit": {
+			base: emptyTree,
+			head: "root",
+			want: &DiffStat{FilesChanged: 2, Insertions: 3},
+		},
+		"normal commit": {
+			base: "master~1",
+			head: "master",
+			want: &DiffStat{FilesChanged: 3, Insertions: 3, Deletions: 1},
+		},
+		"no changes": {
+			base: "master",
+			head: "master",
+			want: &DiffStat{},
+		},
+	}
+	for label, test := range tests {
+		stat, err := GetDiffStat(ctx, repo, test.base, test.head)
+		if err != nil {
+			t.Errorf("%s: GetDiffStat: %s", label, err)
+			continue
+		}
+		if !reflect.DeepEqual(stat, test.want) {
+			t.Errorf("%s: got %+v, want %+v", label, stat, test.want)
+		}
+	}
+}
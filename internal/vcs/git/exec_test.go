@@ -76,3 +76,23 @@ func TestExecSafe(t *testing.T) {
 		})
 	}
 }
+
+func TestIsWhitelistedGitCmd(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		// The args used by the GraphQL GitCommit.hasConflictMarkers resolver.
+		{args: []string{"diff", "--no-color", "--unified=0", "ea167fe3d76b1e5fd3ed8ca44cbd2fe3897684f8", "3c0ef0fa6a3bb4d8e3c0a1ac3dd9a4ab4d4d2d3a", "--"}, want: true},
+		{args: []string{"diff", "--no-color", "--unified=0", "--output=/tmp/x", "HEAD", "--"}},
+		{args: []string{"diff", "--ext-diff", "HEAD"}},
+		{args: []string{"log", "-Sfoo"}, want: true},
+		{args: []string{"diff", "-Xfoo"}},
+		{args: []string{"checkout", "HEAD"}},
+	}
+	for _, test := range tests {
+		if got := isWhitelistedGitCmd(test.args); got != test.want {
+			t.Errorf("%v: got %v, want %v", test.args, got, test.want)
+		}
+	}
+}
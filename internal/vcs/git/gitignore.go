@@ -0,0 +1,161 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/gobwas/glob"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+// IsIgnored reports whether the named path is ignored by the .gitignore files in the tree at
+// commit. The path does not need to exist at commit.
+//
+// As in git, the .gitignore files from the root down to the path's parent directory are
+// evaluated, with patterns in deeper files taking precedence, and a path is ignored if any of its
+// parent directories is ignored. Ignore rules from other sources (such as .git/info/exclude and
+// core.excludesFile) are not considered.
+func IsIgnored(ctx context.Context, repo gitserver.Repo, commit api.CommitID, path string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: IsIgnored")
+	span.SetTag("Commit", commit)
+	span.SetTag("Path", path)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return false, err
+	}
+
+	path = strings.Trim(util.Rel(path), "/")
+	if path == "" || path == "." {
+		return false, nil
+	}
+
+	var isDir bool
+	fi, err := Stat(ctx, repo, commit, path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if err == nil {
+		isDir = fi.Mode().IsDir()
+	}
+
+	components := strings.Split(path, "/")
+	var patterns []gitignorePattern
+	for i := range components {
+		dirPatterns, err := readGitignore(ctx, repo, commit, strings.Join(components[:i], "/"))
+		if err != nil {
+			return false, err
+		}
+		patterns = append(patterns, dirPatterns...)
+
+		// Everything below an ignored directory is ignored, even if a pattern in a deeper
+		// .gitignore file re-includes it.
+		last := i == len(components)-1
+		if matchGitignorePatterns(patterns, strings.Join(components[:i+1], "/"), !last || isDir) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readGitignore reads and parses the .gitignore file in the named directory at commit. If there
+// is no such file, no patterns are returned.
+func readGitignore(ctx context.Context, repo gitserver.Repo, commit api.CommitID, dir string) ([]gitignorePattern, error) {
+	name := ".gitignore"
+	if dir != "" {
+		name = dir + "/" + name
+	}
+	data, err := ReadFile(ctx, repo, commit, name, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseGitignore(dir, data), nil
+}
+
+type gitignorePattern struct {
+	pattern glob.Glob // matches paths relative to the repository root
+	negate  bool      // true if the pattern re-includes matching paths
+	dirOnly bool      // true if the pattern only matches directories
+}
+
+// gitignoreGlobEscaper escapes characters that are special in glob patterns but not in
+// .gitignore patterns.
+var gitignoreGlobEscaper = strings.NewReplacer("{", `\{`, "}", `\}`, ",", `\,`)
+
+// parseGitignore parses the contents of the .gitignore file in the named directory (see the
+// gitignore(5) manual page). Invalid patterns are skipped, as in git.
+func parseGitignore(dir string, data []byte) []gitignorePattern {
+	var base string
+	if dir != "" {
+		base = dir + "/"
+	}
+
+	var patterns []gitignorePattern
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), " \r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p gitignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		// A pattern containing a slash is relative to the directory of the .gitignore file.
+		// Otherwise it matches at any level below that directory.
+		anchored := strings.Contains(line, "/")
+		line = gitignoreGlobEscaper.Replace(strings.TrimPrefix(line, "/"))
+
+		// A "**/" matches zero or more directories.
+		line = strings.Replace(line, "/**/", "/{,**/}", -1)
+		if strings.HasPrefix(line, "**/") {
+			line = "{,**/}" + line[len("**/"):]
+		}
+		if !anchored {
+			line = "{,**/}" + line
+		}
+
+		var err error
+		if p.pattern, err = glob.Compile(base+line, '/'); err != nil {
+			continue
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns
+}
+
+// matchGitignorePatterns reports whether path is ignored by the given patterns, in which later
+// patterns take precedence.
+func matchGitignorePatterns(patterns []gitignorePattern, path string, isDir bool) bool {
+	var ignored bool
+	for _, p := range patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.pattern.Match(path) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
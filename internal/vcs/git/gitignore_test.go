@@ -0,0 +1,54 @@
+package git
+
+import "testing"
+
+func TestIsIgnored(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		`printf '*.log\n!keep.log\nbuild/\n/root-only.txt\ndocs/**/draft.md\n' > .gitignore`,
+		"mkdir sub docs",
+		`printf 'local.txt\n!debug.log\n' > sub/.gitignore`,
+		"echo x > sub/main.go",
+		"echo x > docs/index.md",
+		"git add .gitignore sub docs",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	commitID, err := ResolveRevision(ctx, repo, nil, "master", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]bool{
+		"main.go":             false,
+		"debug.log":           true,
+		"nested/debug.log":    true,
+		"keep.log":            false, // negated
+		"sub/keep.log":        false, // negated
+		"sub/debug.log":       false, // negated in nested .gitignore
+		"sub/local.txt":       true,  // nested .gitignore
+		"local.txt":           false, // nested .gitignore doesn't apply to parent
+		"sub/main.go":         false,
+		"build/out.go":        true,
+		"build/keep.log":      true, // can't re-include file in ignored directory
+		"sub/build/out.go":    true,
+		"root-only.txt":       true,
+		"sub/root-only.txt":   false, // anchored pattern
+		"docs/draft.md":       true,
+		"docs/a/b/draft.md":   true,
+		"docs/index.md":       false,
+		"other/docs/draft.md": false,
+		"/debug.log":          true,
+	}
+	for path, want := range tests {
+		ignored, err := IsIgnored(ctx, repo, commitID, path)
+		if err != nil {
+			t.Errorf("%s: IsIgnored: %s", path, err)
+			continue
+		}
+		if ignored != want {
+			t.Errorf("%s: got ignored %v, want %v", path, ignored, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"path"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// LastCommitsForPaths is like LastCommitForPath, but for many paths at once: it returns a map
+// from each of the given paths to the most recent commit at or before the given commit that
+// modified the path (or, for a directory, any file below it). Paths with no history at that
+// commit are omitted from the map.
+//
+// Instead of running a command per path, it walks the history once (stopping as soon as every
+// path has been seen) and then reads all of the commits with a single command. Merge commits are
+// not considered, so a path that was last changed by resolving a merge conflict is attributed to
+// the last non-merge commit that changed it.
+func LastCommitsForPaths(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (map[string]*Commit, error) {
+	if Mocks.LastCommitsForPaths != nil {
+		return Mocks.LastCommitsForPaths(commit, paths)
+	}
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: LastCommitsForPaths")
+	span.SetTag("Commit", commit)
+	span.SetTag("Paths", len(paths))
+	defer span.Finish()
+
+	if err := ensureAbsoluteCommit(commit); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return map[string]*Commit{}, nil
+	}
+
+	args := append([]string{"log", "--format=%x1e%H", "--name-only", "-z", string(commit), "--"}, paths...)
+	cmd := gitserver.DefaultClient.Command("git", args...)
+	cmd.Repo = repo
+	rc, err := gitserver.StdoutReader(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	commitIDs, err := parseLastCommitsForPaths(rc, paths)
+	rc.Close() // stops the command early if not all of the log was read
+	if err != nil {
+		return nil, err
+	}
+
+	// Read each distinct commit once.
+	seen := map[api.CommitID]bool{}
+	logArgs := []string{"log", "--no-walk", logFormatWithoutRefs}
+	for _, id := range commitIDs {
+		if !seen[id] {
+			seen[id] = true
+			logArgs = append(logArgs, string(id))
+		}
+	}
+	if len(seen) == 0 {
+		return map[string]*Commit{}, nil
+	}
+	cmd = gitserver.DefaultClient.Command("git", logArgs...)
+	cmd.Repo = repo
+	commits, err := runCommitLog(ctx, cmd, CommitsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[api.CommitID]*Commit, len(commits))
+	for _, c := range commits {
+		byID[c.ID] = c
+	}
+
+	result := make(map[string]*Commit, len(commitIDs))
+	for p, id := range commitIDs {
+		if c, ok := byID[id]; ok {
+			result[p] = c
+		}
+	}
+	return result, nil
+}
+
+// parseLastCommitsForPaths reads the output of `git log --format=%x1e%H --name-only -z`, which
+// consists of a "\x1e<commit ID>\x00" record per commit, followed by a "<path>\x00" record per
+// changed file (the first of which is preceded by a newline). It returns the first (i.e., most
+// recent) commit that changed each of the given paths, or any file below them, and stops reading
+// as soon as all paths have been seen.
+func parseLastCommitsForPaths(r io.Reader, paths []string) (map[string]api.CommitID, error) {
+	pending := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		pending[path.Clean(p)] = true
+	}
+	commitIDs := make(map[string]api.CommitID, len(paths))
+
+	var commit api.CommitID
+	s := bufio.NewScanner(r)
+	s.Split(scanNull)
+	for len(pending) > 0 && s.Scan() {
+		field := bytes.TrimPrefix(s.Bytes(), []byte("\n"))
+		if len(field) == 0 {
+			continue
+		}
+		if field[0] == '\x1e' {
+			commit = api.CommitID(field[1:])
+			continue
+		}
+
+		// Attribute the commit to the changed file and all of its parent directories that are
+		// still pending.
+		for p := string(field); p != "." && p != "/"; p = path.Dir(p) {
+			if pending[p] {
+				delete(pending, p)
+				commitIDs[p] = commit
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return commitIDs, nil
+}
+
+// scanNull is a bufio.SplitFunc that splits on NUL bytes.
+func scanNull(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestLastCommitsForPaths(t *testing.T) {
+	t.Parallel()
+
+	const commitCmd = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	repo := MakeGitRepository(t,
+		"mkdir -p dir/sub other",
+		"echo a > a",
+		"echo b > dir/b",
+		"echo c > dir/sub/c",
+		"echo d > other/d",
+		"git add a dir other",
+		commitCmd+" -m create",
+		"git tag create",
+		"echo c2 > dir/sub/c",
+		"git add dir/sub/c",
+		commitCmd+" -m change-c",
+		"git tag change-c",
+		"echo a2 > a",
+		"git add a",
+		commitCmd+" -m change-a",
+		"git tag change-a",
+	)
+
+	commitIDs := map[string]api.CommitID{} // tag -> commit ID
+	tags := map[api.CommitID]string{}      // commit ID -> tag
+	for _, tag := range []string{"create", "change-c", "change-a"} {
+		id, err := ResolveRevision(ctx, repo, nil, tag, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitIDs[tag], tags[id] = id, tag
+	}
+
+	tests := map[string]struct {
+		rev   string
+		paths []string
+		want  map[string]string // path -> tag of the expected commit
+	}{
+		"root entries": {
+			rev:   "change-a",
+			paths: []string{"a", "dir", "other"},
+			want:  map[string]string{"a": "change-a", "dir": "change-c", "other": "create"},
+		},
+		"nested entries": {
+			rev:   "change-a",
+			paths: []string{"dir/b", "dir/sub"},
+			want:  map[string]string{"dir/b": "create", "dir/sub": "change-c"},
+		},
+		"earlier commit": {
+			rev:   "create",
+			paths: []string{"a", "dir"},
+			want:  map[string]string{"a": "create", "dir": "create"},
+		},
+		"nonexistent path": {
+			rev:   "change-a",
+			paths: []string{"a", "doesnt-exist"},
+			want:  map[string]string{"a": "change-a"},
+		},
+		"no paths": {
+			rev:  "change-a",
+			want: map[string]string{},
+		},
+	}
+
+	for label, test := range tests {
+		commits, err := LastCommitsForPaths(ctx, repo, commitIDs[test.rev], test.paths)
+		if err != nil {
+			t.Errorf("%s: LastCommitsForPaths: %s", label, err)
+			continue
+		}
+		got := make(map[string]string, len(commits))
+		for p, commit := range commits {
+			got[p] = tags[commit.ID]
+			if commit.Author.Name != "a" {
+				t.Errorf("%s: %s: got author %q, want %q", label, p, commit.Author.Name, "a")
+			}
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %v, want %v", label, got, test.want)
+			continue
+		}
+		for p, want := range test.want {
+			if got[p] != want {
+				t.Errorf("%s: %s: got commit %q, want %q", label, p, got[p], want)
+			}
+		}
+	}
+}
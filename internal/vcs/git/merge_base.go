@@ -26,3 +26,33 @@ func MergeBase(ctx context.Context, repo gitserver.Repo, a, b api.CommitID) (api
 	}
 	return api.CommitID(bytes.TrimSpace(out)), nil
 }
+
+// IsAncestor returns whether commit a is an ancestor of (or the same as)
+// commit b. Commits without common history are reported as not being
+// ancestors of each other.
+func IsAncestor(ctx context.Context, repo gitserver.Repo, a, b api.CommitID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: IsAncestor")
+	span.SetTag("A", a)
+	span.SetTag("B", b)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(string(a)); err != nil {
+		return false, err
+	}
+	if err := checkSpecArgSafety(string(b)); err != nil {
+		return false, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "merge-base", "--is-ancestor", string(a), string(b))
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		// merge-base exits with status 1 and no output if a is not an
+		// ancestor of b.
+		if cmd.ExitStatus == 1 && len(out) == 0 {
+			return false, nil
+		}
+		return false, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+	return true, nil
+}
@@ -70,3 +70,61 @@ func TestMerger_MergeBase(t *testing.T) {
 		}
 	}
 }
+
+func TestIsAncestor(t *testing.T) {
+	t.Parallel()
+
+	cmds := []string{
+		"echo line1 > f",
+		"git add f",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag mainline",
+		"git checkout -b side",
+		"echo line2 >> f",
+		"git add f",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m bar --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout master",
+		"echo line3 > h",
+		"git add h",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m qux --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout --orphan unrelated",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m baz --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout master",
+	}
+	repo := MakeGitRepository(t, cmds...)
+
+	tests := map[string]struct {
+		a, b string // can be any revspec; is resolved during the test
+		want bool
+	}{
+		"mainline commit": {a: "mainline", b: "master", want: true},
+		"same commit":     {a: "master", b: "master", want: true},
+		"side branch":     {a: "side", b: "master", want: false},
+		"descendant":      {a: "master", b: "mainline", want: false},
+		"unrelated":       {a: "unrelated", b: "master", want: false},
+	}
+
+	for label, test := range tests {
+		a, err := ResolveRevision(ctx, repo, nil, test.a, nil)
+		if err != nil {
+			t.Errorf("%s: ResolveRevision(%q) on a: %s", label, test.a, err)
+			continue
+		}
+
+		b, err := ResolveRevision(ctx, repo, nil, test.b, nil)
+		if err != nil {
+			t.Errorf("%s: ResolveRevision(%q) on b: %s", label, test.b, err)
+			continue
+		}
+
+		isAncestor, err := IsAncestor(ctx, repo, a, b)
+		if err != nil {
+			t.Errorf("%s: IsAncestor(%s, %s): %s", label, a, b, err)
+			continue
+		}
+
+		if isAncestor != test.want {
+			t.Errorf("%s: IsAncestor(%s, %s): got %v, want %v", label, a, b, isAncestor, test.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// DefaultNotesRef is the notes ref that `git notes` reads and writes by default.
+const DefaultNotesRef = "refs/notes/commits"
+
+// Notes returns the text of the note attached to the commit in the given notes ref (such as
+// DefaultNotesRef), as `git notes show` prints it. If there is no such note (or no such notes
+// ref), it returns "".
+func Notes(ctx context.Context, repo gitserver.Repo, ref string, commit api.CommitID) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: Notes")
+	span.SetTag("Ref", ref)
+	span.SetTag("Commit", commit)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(ref); err != nil {
+		return "", err
+	}
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return "", err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "notes", "--ref="+ref, "show", string(commit))
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		// git notes show exits with status 1 if the commit has no note.
+		if cmd.ExitStatus == 1 && bytes.HasPrefix(out, []byte("error: no note found")) {
+			return "", nil
+		}
+		return "", errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+	return string(out), nil
+}
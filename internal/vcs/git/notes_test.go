@@ -0,0 +1,45 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestNotes(t *testing.T) {
+	t.Parallel()
+
+	const commit = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	const notes = "GIT_AUTHOR_NAME=a GIT_AUTHOR_EMAIL=a@a.com GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git notes"
+	repo := MakeGitRepository(t,
+		commit,
+		notes+" add -m 'Reviewed-by: b' HEAD",
+		notes+" --ref=review add -m 'LGTM' HEAD",
+		commit,
+	)
+
+	tests := []struct {
+		rev, ref, want string
+	}{
+		{rev: "master~1", ref: DefaultNotesRef, want: "Reviewed-by: b\n"},
+		{rev: "master~1", ref: "refs/notes/review", want: "LGTM\n"},
+		{rev: "master", ref: DefaultNotesRef, want: ""},        // no note
+		{rev: "master~1", ref: "refs/notes/missing", want: ""}, // no notes ref
+	}
+	for _, test := range tests {
+		commitID, err := ResolveRevision(ctx, repo, nil, test.rev, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Notes(ctx, repo, test.ref, commitID)
+		if err != nil {
+			t.Errorf("%s %s: Notes: %s", test.rev, test.ref, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s %s: got %q, want %q", test.rev, test.ref, got, test.want)
+		}
+	}
+
+	if _, err := Notes(ctx, repo, "--exec=x", "master"); err == nil {
+		t.Error("got no error for a ref that is a flag, want an error")
+	}
+}
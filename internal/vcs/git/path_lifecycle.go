@@ -0,0 +1,88 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// PathLifecycleEvent is a commit that added or removed a file.
+type PathLifecycleEvent struct {
+	Commit api.CommitID
+	Added  bool // whether the commit added (true) or removed (false) the file
+}
+
+// GetPathLifecycle returns the commits that added or removed the file at filePath, oldest first. If
+// since is set, only the commits reachable from head but not from since are considered; otherwise,
+// all ancestors of head (including head) are. A file that was removed and added again has an event
+// for each addition and removal. Renames are reported as the removal of the old and the addition of
+// the new path. Merge commits are not considered.
+func GetPathLifecycle(ctx context.Context, repo gitserver.Repo, since, head, filePath string) ([]*PathLifecycleEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: GetPathLifecycle")
+	span.SetTag("Since", since)
+	span.SetTag("Head", head)
+	span.SetTag("Path", filePath)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(head); err != nil {
+		return nil, err
+	}
+	rangeSpec := head
+	if since != "" {
+		if err := checkSpecArgSafety(since); err != nil {
+			return nil, err
+		}
+		rangeSpec = since + ".." + head
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "log", "--reverse", "--format=%H", "--name-status", "-z", "--no-renames", "--diff-filter=AD", rangeSpec, "--", filePath)
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("exec `git log` failed: %s. Output was:\n\n%s", err, out)
+	}
+	return parsePathLifecycle(out, path.Clean(filePath))
+}
+
+// parsePathLifecycle parses the output of `git log --format=%H --name-status -z --no-renames`,
+// which consists of a "<commit ID>\x00" record per commit, followed by a "\n<status>\x00<path>\x00"
+// record per changed file (without the newline for all but the first file). Only the additions
+// and removals of filePath are returned.
+func parsePathLifecycle(out []byte, filePath string) ([]*PathLifecycleEvent, error) {
+	var (
+		events []*PathLifecycleEvent
+		commit api.CommitID
+	)
+	fields := bytes.Split(out, []byte{0})
+	for i := 0; i < len(fields); i++ {
+		field := bytes.TrimPrefix(fields[i], []byte("\n"))
+		if len(field) == 0 {
+			continue
+		}
+		if IsAbsoluteRevision(string(field)) {
+			commit = api.CommitID(field)
+			continue
+		}
+
+		if commit == "" || i+1 >= len(fields) {
+			return nil, fmt.Errorf("invalid `git log --name-status` output: %q", out)
+		}
+		status, changedPath := string(field), string(fields[i+1])
+		i++
+		if changedPath != filePath {
+			continue
+		}
+		switch status {
+		case "A":
+			events = append(events, &PathLifecycleEvent{Commit: commit, Added: true})
+		case "D":
+			events = append(events, &PathLifecycleEvent{Commit: commit, Added: false})
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestGetPathLifecycle(t *testing.T) {
+	t.Parallel()
+
+	const commit = "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	repo := MakeGitRepository(t,
+		"echo a > other",
+		"git add other",
+		commit,
+		"echo a > f",
+		"echo a > g",
+		"git add f g",
+		commit,
+		"git tag added",
+		"echo b > f",
+		"git add f",
+		commit,
+		"git rm f",
+		commit,
+		"git tag removed",
+		"echo c > f",
+		"git add f",
+		commit,
+		"git tag readded",
+	)
+
+	revs := map[string]api.CommitID{}
+	for _, tag := range []string{"added", "removed", "readded"} {
+		commitID, err := ResolveRevision(ctx, repo, nil, tag, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revs[tag] = commitID
+	}
+	event := func(tag string, added bool) *PathLifecycleEvent {
+		return &PathLifecycleEvent{Commit: revs[tag], Added: added}
+	}
+
+	tests := map[string]struct {
+		since, head, path string
+		want              []*PathLifecycleEvent
+	}{
+		"add only": {
+			head: "master",
+			path: "g",
+			want: []*PathLifecycleEvent{event("added", true)},
+		},
+		"add then delete": {
+			head: "removed",
+			path: "f",
+			want: []*PathLifecycleEvent{event("added", true), event("removed", false)},
+		},
+		"re-add": {
+			head: "master",
+			path: "f",
+			want: []*PathLifecycleEvent{event("added", true), event("removed", false), event("readded", true)},
+		},
+		"since": {
+			since: "added",
+			head:  "master",
+			path:  "f",
+			want:  []*PathLifecycleEvent{event("removed", false), event("readded", true)},
+		},
+		"never existed": {
+			head: "master",
+			path: "h",
+		},
+	}
+	for label, test := range tests {
+		events, err := GetPathLifecycle(ctx, repo, test.since, test.head, test.path)
+		if err != nil {
+			t.Errorf("%s: GetPathLifecycle: %s", label, err)
+			continue
+		}
+		if !reflect.DeepEqual(events, test.want) {
+			t.Errorf("%s: got %+v, want %+v", label, events, test.want)
+		}
+	}
+}
@@ -336,3 +336,28 @@ func showRef(ctx context.Context, repo gitserver.Repo, args ...string) ([]Ref, e
 	}
 	return refs, nil
 }
+
+// ListRefsPointingAt returns the full names of the branches and tags whose tips are the given
+// commit, sorted by name. Annotated tags are included if the commit is the object they tag.
+func ListRefsPointingAt(ctx context.Context, repo gitserver.Repo, commit api.CommitID) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: ListRefsPointingAt")
+	span.SetTag("Commit", commit)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "for-each-ref", "--sort=refname", "--format=%(refname)", "--points-at="+string(commit), "refs/heads/", "refs/tags/")
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+
+	out = bytes.TrimSuffix(out, []byte("\n"))
+	if len(out) == 0 {
+		return []string{}, nil
+	}
+	return strings.Split(string(out), "\n"), nil
+}
@@ -211,6 +211,45 @@ func TestRepository_Branches_BehindAheadCounts(t *testing.T) {
 	}
 }
 
+func TestGetBehindAhead(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo0 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git branch base",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo1 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo2 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout -b diverged base",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo3 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout --orphan unrelated",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo4 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git checkout master",
+	)
+
+	tests := map[string]struct {
+		left, right string
+		want        BehindAhead
+	}{
+		"identical":   {left: "master", right: "master", want: BehindAhead{Behind: 0, Ahead: 0}},
+		"ahead only":  {left: "base", right: "master", want: BehindAhead{Behind: 0, Ahead: 2}},
+		"behind only": {left: "master", right: "base", want: BehindAhead{Behind: 2, Ahead: 0}},
+		"diverged":    {left: "master", right: "diverged", want: BehindAhead{Behind: 2, Ahead: 1}},
+		"unrelated":   {left: "master", right: "unrelated", want: BehindAhead{Behind: 3, Ahead: 1}},
+	}
+
+	for label, test := range tests {
+		counts, err := GetBehindAhead(ctx, repo, test.left, test.right)
+		if err != nil {
+			t.Errorf("%s: GetBehindAhead(%q, %q): %s", label, test.left, test.right, err)
+			continue
+		}
+
+		if *counts != test.want {
+			t.Errorf("%s: GetBehindAhead(%q, %q): got %+v, want %+v", label, test.left, test.right, *counts, test.want)
+		}
+	}
+}
+
 func TestRepository_Branches_IncludeCommit(t *testing.T) {
 	t.Parallel()
 
@@ -304,3 +343,46 @@ func TestRepository_ListTags(t *testing.T) {
 		}
 	}
 }
+
+func TestListRefsPointingAt(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo0 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo1 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag v1.0.0",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git tag -a v1.0.1 -m release",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo2 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git branch feature",
+		"git branch other",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m foo3 --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	tests := map[string]struct {
+		rev  string
+		want []string
+	}{
+		"branch tips":       {rev: "master", want: []string{"refs/heads/master"}},
+		"multiple branches": {rev: "feature", want: []string{"refs/heads/feature", "refs/heads/other"}},
+		"tagged release":    {rev: "v1.0.0", want: []string{"refs/tags/v1.0.0", "refs/tags/v1.0.1"}},
+		"no refs":           {rev: "v1.0.0~1", want: []string{}},
+	}
+
+	for label, test := range tests {
+		commitID, err := ResolveRevision(ctx, repo, nil, test.rev, nil)
+		if err != nil {
+			t.Errorf("%s: ResolveRevision(%q): %s", label, test.rev, err)
+			continue
+		}
+
+		refs, err := ListRefsPointingAt(ctx, repo, commitID)
+		if err != nil {
+			t.Errorf("%s: ListRefsPointingAt(%s): %s", label, commitID, err)
+			continue
+		}
+
+		if diff := cmp.Diff(refs, test.want); diff != "" {
+			t.Errorf("%s: %s", label, diff)
+		}
+	}
+}
@@ -63,3 +63,62 @@ func TestParseShortLog(t *testing.T) {
 		})
 	}
 }
+
+func TestShortLog(t *testing.T) {
+	t.Parallel()
+
+	commit := func(author string) string {
+		return "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='" + author + "' --date 2006-01-02T15:04:05Z"
+	}
+	repo := MakeGitRepository(t,
+		"mkdir -p sub",
+		"echo 1 > a && git add a", commit("a <a@a.com>"),
+		"echo 1 > sub/b && git add sub", commit("b <b@b.com>"),
+		"echo 2 > sub/b && git add sub", commit("b <b@b.com>"),
+		"echo 2 > a && git add a", commit("b <b@b.com>"),
+		"echo 3 > sub/b && git add sub", commit("a <a@a.com>"),
+		"echo 4 > sub/b && git add sub", commit("c <c@c.com>"),
+	)
+
+	commitID, err := ResolveRevision(ctx, repo, nil, "master~1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opt  ShortLogOptions
+		want []*PersonCount
+	}{
+		"repository": {
+			opt: ShortLogOptions{Range: string(commitID)},
+			want: []*PersonCount{
+				{Name: "b", Email: "b@b.com", Count: 3},
+				{Name: "a", Email: "a@a.com", Count: 2},
+			},
+		},
+		"subtree": {
+			opt: ShortLogOptions{Range: string(commitID), Path: "sub"},
+			want: []*PersonCount{
+				{Name: "b", Email: "b@b.com", Count: 2},
+				{Name: "a", Email: "a@a.com", Count: 1},
+			},
+		},
+		"file": {
+			opt: ShortLogOptions{Range: string(commitID), Path: "a"},
+			want: []*PersonCount{
+				{Name: "a", Email: "a@a.com", Count: 1},
+				{Name: "b", Email: "b@b.com", Count: 1},
+			},
+		},
+	}
+	for label, test := range tests {
+		got, err := ShortLog(ctx, repo, test.opt)
+		if err != nil {
+			t.Errorf("%s: ShortLog: %s", label, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", label, got, test.want)
+		}
+	}
+}
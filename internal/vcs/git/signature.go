@@ -0,0 +1,60 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// CommitSignature describes the cryptographic (e.g., GPG) signature of a commit and the result of
+// verifying it.
+type CommitSignature struct {
+	Signed   bool   // whether the commit is signed
+	Verified bool   // whether the signature is good and made by a trusted key
+	Status   string // the raw verification status reported by git (%G? in git-log(1)); "N" if unsigned
+	Signer   string // the name of the signer, if available
+	Key      string // the key used to sign the commit, if available
+}
+
+// GetCommitSignature returns the signature of the given commit and the result of verifying it
+// against the keys known to the gitserver. Unsigned commits are not an error; their signature has
+// Signed set to false.
+func GetCommitSignature(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (*CommitSignature, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Git: GetCommitSignature")
+	span.SetTag("Commit", commit)
+	defer span.Finish()
+
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "log", "-n", "1", "--format=%G?%x00%GS%x00%GK", string(commit), "--")
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+	return parseCommitSignature(out)
+}
+
+// parseCommitSignature parses the output of git log with the format "%G?%x00%GS%x00%GK".
+func parseCommitSignature(out []byte) (*CommitSignature, error) {
+	parts := bytes.Split(bytes.TrimSuffix(out, []byte("\n")), []byte{'\x00'})
+	if len(parts) != 3 || len(parts[0]) != 1 {
+		return nil, fmt.Errorf("unexpected commit signature output: %q", out)
+	}
+
+	status := string(parts[0])
+	return &CommitSignature{
+		Signed:   status != "N",
+		Verified: status == "G",
+		Status:   status,
+		Signer:   string(parts[1]),
+		Key:      string(parts[2]),
+	}, nil
+}
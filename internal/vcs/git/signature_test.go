@@ -0,0 +1,86 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetCommitSignature_Unsigned(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty --no-gpg-sign -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	commitID, err := ResolveRevision(ctx, repo, nil, "master", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := GetCommitSignature(ctx, repo, commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &CommitSignature{Status: "N"}
+	if diff := cmp.Diff(sig, want); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestParseCommitSignature(t *testing.T) {
+	tests := map[string]struct {
+		out     string
+		want    *CommitSignature
+		wantErr bool
+	}{
+		"signed and verified": {
+			out: "G\x00Alice <alice@example.com>\x00ABCDEF0123456789\n",
+			want: &CommitSignature{
+				Signed:   true,
+				Verified: true,
+				Status:   "G",
+				Signer:   "Alice <alice@example.com>",
+				Key:      "ABCDEF0123456789",
+			},
+		},
+		"signed with unknown key": {
+			out: "E\x00\x00ABCDEF0123456789\n",
+			want: &CommitSignature{
+				Signed: true,
+				Status: "E",
+				Key:    "ABCDEF0123456789",
+			},
+		},
+		"bad signature": {
+			out: "B\x00Mallory <mallory@example.com>\x00ABCDEF0123456789\n",
+			want: &CommitSignature{
+				Signed: true,
+				Status: "B",
+				Signer: "Mallory <mallory@example.com>",
+				Key:    "ABCDEF0123456789",
+			},
+		},
+		"unsigned": {
+			out:  "N\x00\x00\n",
+			want: &CommitSignature{Status: "N"},
+		},
+		"malformed": {
+			out:     "fatal: bad object\n",
+			wantErr: true,
+		},
+	}
+
+	for label, test := range tests {
+		sig, err := parseCommitSignature([]byte(test.out))
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v, want error %v", label, err, test.wantErr)
+			continue
+		}
+
+		if diff := cmp.Diff(sig, test.want); diff != "" {
+			t.Errorf("%s: %s", label, diff)
+		}
+	}
+}
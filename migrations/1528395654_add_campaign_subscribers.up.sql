@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_subscribers (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_subscribers_campaign_id_user_id_unique UNIQUE (campaign_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS campaign_subscribers_user_id ON campaign_subscribers(user_id);
+
+COMMIT;
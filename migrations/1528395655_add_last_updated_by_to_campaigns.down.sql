@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaigns DROP COLUMN IF EXISTS last_updated_by;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS last_updated_by integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMIT;
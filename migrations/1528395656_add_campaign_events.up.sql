@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_events (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  actor_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  kind text NOT NULL CHECK (kind != ''),
+  payload jsonb NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(payload) = 'object'),
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_events_campaign_id ON campaign_events(campaign_id);
+
+COMMIT;
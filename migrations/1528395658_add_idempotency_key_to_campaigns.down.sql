@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_namespace_user_id_idempotency_key;
+DROP INDEX IF EXISTS campaigns_namespace_org_id_idempotency_key;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS idempotency_key;
+
+COMMIT;
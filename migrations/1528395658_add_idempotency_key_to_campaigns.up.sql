@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS idempotency_key text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS campaigns_namespace_user_id_idempotency_key
+  ON campaigns(namespace_user_id, idempotency_key)
+  WHERE namespace_user_id IS NOT NULL AND idempotency_key IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS campaigns_namespace_org_id_idempotency_key
+  ON campaigns(namespace_org_id, idempotency_key)
+  WHERE namespace_org_id IS NOT NULL AND idempotency_key IS NOT NULL;
+
+COMMIT;
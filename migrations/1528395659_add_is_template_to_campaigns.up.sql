@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS is_template boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DELETE FROM campaign_events WHERE campaign_id NOT IN (SELECT id FROM campaigns);
+
+ALTER TABLE campaign_events
+  ADD CONSTRAINT campaign_events_campaign_id_fkey
+  FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE;
+
+COMMIT;
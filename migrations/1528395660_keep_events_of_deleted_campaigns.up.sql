@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Keep the activity timeline of a campaign, including the event recording
+-- its deletion, after the campaign itself has been deleted.
+ALTER TABLE campaign_events DROP CONSTRAINT IF EXISTS campaign_events_campaign_id_fkey;
+
+COMMIT;
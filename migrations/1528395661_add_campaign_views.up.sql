@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_views (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  viewed_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_views_campaign_id_user_id_unique UNIQUE (campaign_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS campaign_views_user_id_viewed_at ON campaign_views(user_id, viewed_at DESC);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_name_trgm;
+DROP INDEX IF EXISTS campaigns_description_trgm;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Trigram indexes let the substring ILIKE conditions of the campaigns search
+-- query use bitmap index scans instead of scanning all campaigns.
+CREATE INDEX IF NOT EXISTS campaigns_name_trgm ON campaigns USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS campaigns_description_trgm ON campaigns USING gin (description gin_trgm_ops);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_reminders (
+  id bigserial PRIMARY KEY,
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  remind_at timestamptz NOT NULL,
+  sent_at timestamptz,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS campaign_reminders_remind_at ON campaign_reminders(remind_at) WHERE sent_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_collaborators (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_collaborators_campaign_id_user_id_unique UNIQUE (campaign_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS campaign_collaborators_user_id ON campaign_collaborators(user_id);
+
+COMMIT;
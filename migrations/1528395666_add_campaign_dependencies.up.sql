@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_dependencies (
+  campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  depends_on_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT campaign_dependencies_campaign_id_depends_on_id_unique UNIQUE (campaign_id, depends_on_id),
+  CONSTRAINT campaign_dependencies_not_self CHECK (campaign_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS campaign_dependencies_depends_on_id ON campaign_dependencies(depends_on_id);
+
+COMMIT;
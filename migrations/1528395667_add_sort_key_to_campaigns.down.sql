@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS campaigns_sort_key;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS sort_key;
+DROP SEQUENCE IF EXISTS campaigns_sort_key_seq;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+-- New campaigns are ordered last. Their sort keys are spaced 65536 apart, so
+-- that a campaign can be moved between two others without renumbering.
+CREATE SEQUENCE IF NOT EXISTS campaigns_sort_key_seq;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS sort_key bigint;
+UPDATE campaigns SET sort_key = id * 65536;
+SELECT setval('campaigns_sort_key_seq', COALESCE((SELECT MAX(id) FROM campaigns), 0) + 1, false);
+
+ALTER TABLE campaigns
+  ALTER COLUMN sort_key SET DEFAULT nextval('campaigns_sort_key_seq') * 65536,
+  ALTER COLUMN sort_key SET NOT NULL;
+ALTER SEQUENCE campaigns_sort_key_seq OWNED BY campaigns.sort_key;
+
+CREATE INDEX IF NOT EXISTS campaigns_sort_key ON campaigns(sort_key);
+
+COMMIT;
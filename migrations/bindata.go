@@ -74,6 +74,34 @@
 // 1528395652_add_lsif_indexer.up.sql (611B)
 // 1528395653_repo_normalize_visibility_metadata.down.sql (65B)
 // 1528395653_repo_normalize_visibility_metadata.up.sql (925B)
+// 1528395654_add_campaign_subscribers.down.sql (60B)
+// 1528395654_add_campaign_subscribers.up.sql (464B)
+// 1528395655_add_last_updated_by_to_campaigns.down.sql (78B)
+// 1528395655_add_last_updated_by_to_campaigns.up.sql (140B)
+// 1528395656_add_campaign_events.down.sql (55B)
+// 1528395656_add_campaign_events.up.sql (507B)
+// 1528395657_add_campaign_pins.down.sql (53B)
+// 1528395657_add_campaign_pins.up.sql (436B)
+// 1528395658_add_idempotency_key_to_campaigns.down.sql (209B)
+// 1528395658_add_idempotency_key_to_campaigns.up.sql (485B)
+// 1528395659_add_is_template_to_campaigns.down.sql (74B)
+// 1528395659_add_is_template_to_campaigns.up.sql (108B)
+// 1528395660_keep_events_of_deleted_campaigns.down.sql (260B)
+// 1528395660_keep_events_of_deleted_campaigns.up.sql (241B)
+// 1528395661_add_campaign_views.down.sql (54B)
+// 1528395661_add_campaign_views.up.sql (465B)
+// 1528395662_add_campaigns_trgm_indexes.down.sql (108B)
+// 1528395662_add_campaigns_trgm_indexes.up.sql (358B)
+// 1528395663_add_campaign_reminders.down.sql (58B)
+// 1528395663_add_campaign_reminders.up.sql (477B)
+// 1528395664_add_campaign_collaborators.down.sql (62B)
+// 1528395664_add_campaign_collaborators.up.sql (472B)
+// 1528395665_add_is_locked_to_campaigns.down.sql (72B)
+// 1528395665_add_is_locked_to_campaigns.up.sql (106B)
+// 1528395666_add_campaign_dependencies.down.sql (61B)
+// 1528395666_add_campaign_dependencies.up.sql (583B)
+// 1528395667_add_sort_key_to_campaigns.down.sql (160B)
+// 1528395667_add_sort_key_to_campaigns.up.sql (706B)
 
 package migrations
 
@@ -1622,6 +1650,566 @@ func _1528395653_repo_normalize_visibility_metadataUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395654_add_campaign_subscribersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x75\x62\x73\x63\x72\x69\x62\x65\x72\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x14\x27\xb5\xe8\x3c\x00\x00\x00")
+
+func _1528395654_add_campaign_subscribersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395654_add_campaign_subscribersDownSql,
+		"1528395654_add_campaign_subscribers.down.sql",
+	)
+}
+
+func _1528395654_add_campaign_subscribersDownSql() (*asset, error) {
+	bytes, err := _1528395654_add_campaign_subscribersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395654_add_campaign_subscribers.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa5, 0xe8, 0x5, 0xc1, 0x3c, 0x6a, 0xef, 0x2, 0x14, 0x42, 0xa7, 0x66, 0xda, 0x12, 0x2e, 0x64, 0xf3, 0x3, 0x37, 0xdf, 0xda, 0x7, 0x4a, 0x3d, 0xf2, 0xbe, 0x9f, 0xe4, 0xbd, 0xea, 0x61, 0x5b}}
+	return a, nil
+}
+
+var __1528395654_add_campaign_subscribersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xbd\x6e\x84\x30\x10\x84\x7b\x3f\xc5\x96\x20\xdd\x1b\x50\xf9\xcc\x12\x59\xe2\x8c\x02\x46\xba\xce\xe2\xc7\x42\x5b\xe0\x5c\x6c\xa3\x48\x79\xfa\xc8\x51\x08\x57\x50\x5c\xeb\x9d\xf9\xc6\x33\x57\x7c\x93\xaa\x60\x4c\xb4\xc8\x35\x82\xe6\xd7\x1a\x41\x56\xa0\x1a\x0d\x78\x97\x9d\xee\x60\x1a\xd6\xc7\x40\x8b\x33\x61\x1b\xc3\xe4\x69\xb4\x3e\x40\xc6\xe0\x38\xd0\x0c\x23\x2d\xe4\xe2\xaf\x4d\xf5\x75\x0d\x2d\x56\xd8\xa2\x12\x78\xf8\x43\x46\x73\x0e\x8d\x82\x12\x6b\xd4\x08\x82\x77\x82\x97\x08\x65\x92\xb6\x29\xf8\xc2\x00\xb6\x60\xbd\xa1\x19\xc8\x45\xbb\x58\x7f\x4a\x4c\x9a\xd7\x68\x93\xb7\x43\xb4\xb3\x19\x22\x44\x5a\x6d\x88\xc3\xfa\x88\xdf\x07\xb4\xc4\x8a\xf7\xb5\x06\xf7\xf1\x95\xe5\xc9\x20\x1a\xd5\xe9\x96\x4b\xa5\x4f\x7b\x9b\xff\x47\x9a\xcd\xdf\x57\xcd\xe6\xe8\x73\xb3\xd0\x2b\xf9\xde\x23\x64\x4f\x92\xcb\x5e\x27\x67\xf9\x31\xb2\x54\x25\xde\x5f\x18\x79\x0f\x48\x9b\x9d\xdd\xb3\x1d\x5e\x30\x26\x9a\xdb\x4d\xea\x82\xfd\x0c\x00\xed\x96\xbb\x25\xd0\x01\x00\x00")
+
+func _1528395654_add_campaign_subscribersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395654_add_campaign_subscribersUpSql,
+		"1528395654_add_campaign_subscribers.up.sql",
+	)
+}
+
+func _1528395654_add_campaign_subscribersUpSql() (*asset, error) {
+	bytes, err := _1528395654_add_campaign_subscribersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395654_add_campaign_subscribers.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf9, 0xbb, 0x76, 0x15, 0x7, 0x4a, 0x82, 0x1d, 0x98, 0x62, 0xa, 0x37, 0x4c, 0x8f, 0xfc, 0xa5, 0x79, 0x30, 0xfc, 0x66, 0x56, 0x49, 0xed, 0xae, 0xdb, 0x4c, 0x66, 0xb1, 0x9d, 0x53, 0xc, 0x8d}}
+	return a, nil
+}
+
+var __1528395655_add_last_updated_by_to_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4e\x00\xb1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x61\x73\x74\x5f\x75\x70\x64\x61\x74\x65\x64\x5f\x62\x79\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x45\xda\x12\xf6\x4e\x00\x00\x00")
+
+func _1528395655_add_last_updated_by_to_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395655_add_last_updated_by_to_campaignsDownSql,
+		"1528395655_add_last_updated_by_to_campaigns.down.sql",
+	)
+}
+
+func _1528395655_add_last_updated_by_to_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395655_add_last_updated_by_to_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395655_add_last_updated_by_to_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xab, 0xc0, 0x98, 0x7d, 0xca, 0x1b, 0x2d, 0x63, 0x4c, 0xc0, 0x5a, 0xd7, 0x74, 0x22, 0x10, 0xae, 0xe0, 0x90, 0x8b, 0x82, 0xb2, 0x67, 0x38, 0x0, 0xb2, 0x66, 0x2e, 0x14, 0xca, 0xa7, 0xb, 0x71}}
+	return a, nil
+}
+
+var __1528395655_add_last_updated_by_to_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x8c\x00\x73\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x61\x73\x74\x5f\x75\x70\x64\x61\x74\x65\x64\x5f\x62\x79\x20\x69\x6e\x74\x65\x67\x65\x72\x20\x52\x45\x46\x45\x52\x45\x4e\x43\x45\x53\x20\x75\x73\x65\x72\x73\x28\x69\x64\x29\x20\x4f\x4e\x20\x44\x45\x4c\x45\x54\x45\x20\x53\x45\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x45\x52\x52\x41\x42\x4c\x45\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5f\x17\x9c\x02\x8c\x00\x00\x00")
+
+func _1528395655_add_last_updated_by_to_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395655_add_last_updated_by_to_campaignsUpSql,
+		"1528395655_add_last_updated_by_to_campaigns.up.sql",
+	)
+}
+
+func _1528395655_add_last_updated_by_to_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395655_add_last_updated_by_to_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395655_add_last_updated_by_to_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xab, 0xef, 0x62, 0xc, 0x8, 0x1, 0x5c, 0x6, 0xe2, 0xa2, 0x2b, 0x39, 0xec, 0x30, 0x39, 0x75, 0x2c, 0xa8, 0xc0, 0x95, 0xff, 0x9, 0x2d, 0xe8, 0x52, 0x58, 0xc8, 0x8a, 0x7f, 0x25, 0x44, 0xec}}
+	return a, nil
+}
+
+var __1528395656_add_campaign_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x37\x00\xc8\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x65\x76\x65\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x2c\x6c\x6b\xa7\x37\x00\x00\x00")
+
+func _1528395656_add_campaign_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395656_add_campaign_eventsDownSql,
+		"1528395656_add_campaign_events.down.sql",
+	)
+}
+
+func _1528395656_add_campaign_eventsDownSql() (*asset, error) {
+	bytes, err := _1528395656_add_campaign_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395656_add_campaign_events.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaf, 0x23, 0xa1, 0x6a, 0x3a, 0x8e, 0x88, 0x13, 0x32, 0x46, 0x44, 0x6d, 0xe5, 0xdb, 0xd7, 0x99, 0xe6, 0xfd, 0x10, 0xb, 0x7a, 0xd2, 0xac, 0xeb, 0x9d, 0xcf, 0xfe, 0x60, 0x18, 0x59, 0xb8, 0x4d}}
+	return a, nil
+}
+
+var __1528395656_add_campaign_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xcd\x6e\x82\x40\x14\x85\xf7\x3c\xc5\xe9\x0a\x48\xfa\x06\xc6\x05\xc2\xb5\x25\x22\x36\x30\x26\xba\x22\x23\x33\x35\x63\x75\x20\x70\xfb\x63\x9b\xbe\x7b\x03\xda\x62\x6d\xd2\xe5\xe4\x7c\xf7\x9b\x9b\x7b\x26\x74\x17\xa7\x23\xc7\x09\x33\x0a\x04\x41\x04\x93\x84\x10\x4f\x91\x2e\x04\x68\x15\xe7\x22\x47\x29\x0f\xb5\x34\x5b\x5b\xe8\x17\x6d\xb9\x85\xe7\x00\x46\x61\x63\xb6\xad\x6e\x8c\xdc\xe3\x21\x8b\xe7\x41\xb6\xc6\x8c\xd6\xb7\x0e\x06\xfe\x04\x19\xcb\xbd\x2d\x5d\x26\x09\x32\x9a\x52\x46\x69\x48\x83\xb6\xf5\x8c\xf2\xb1\x48\x11\x51\x42\x82\x10\x06\x79\x18\x44\x84\xa8\x43\xb3\x6e\x9f\x4e\x2a\x4b\xae\x9a\xc2\x28\x18\xcb\x7a\xab\x9b\x4b\xd3\x73\xab\x9b\x6b\x4b\x4e\xe7\x1f\x7f\x6b\x9e\x8c\x55\x60\xfd\x76\xb1\x52\x78\x4f\xe1\x0c\x5e\x9f\xdc\x8c\xe1\xba\x7e\x07\xd6\xf2\xb8\xaf\xa4\xc2\xae\xad\xec\x66\x80\x23\x9a\x06\xcb\x44\xc0\xfd\xf8\x74\xbf\x27\x7b\xa4\xe0\x63\xad\xab\x47\xef\x3c\xe7\x63\x0c\xb7\xda\xec\x74\xc9\x27\x5f\xd9\x68\xc9\x5a\x15\x92\xc1\xe6\xa0\x5b\x96\x87\x9a\xdf\xff\x8a\x6d\xf5\xea\xf9\x8e\x3f\x34\x12\xa7\x11\xad\xfe\x6f\xa4\xf8\x79\x1b\xd5\x1d\xf2\x2a\xf6\x2e\xe2\x5e\xbc\x98\xcf\x63\x31\x72\xbe\x06\x00\xc8\xbc\x82\xb2\xfb\x01\x00\x00")
+
+func _1528395656_add_campaign_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395656_add_campaign_eventsUpSql,
+		"1528395656_add_campaign_events.up.sql",
+	)
+}
+
+func _1528395656_add_campaign_eventsUpSql() (*asset, error) {
+	bytes, err := _1528395656_add_campaign_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395656_add_campaign_events.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x77, 0xa4, 0x94, 0x33, 0xf3, 0x1, 0x1c, 0x91, 0x37, 0x45, 0xc2, 0xc3, 0xa2, 0xc6, 0x75, 0xfc, 0x92, 0xb, 0x3f, 0xfc, 0x2, 0xc7, 0xdd, 0x20, 0x77, 0xe7, 0xdf, 0x35, 0x8c, 0xcf, 0xce}}
+	return a, nil
+}
+
+var __1528395657_add_campaign_pinsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x35\x00\xca\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x70\x69\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xbd\x00\xca\xb0\x35\x00\x00\x00")
+
+func _1528395657_add_campaign_pinsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395657_add_campaign_pinsDownSql,
+		"1528395657_add_campaign_pins.down.sql",
+	)
+}
+
+func _1528395657_add_campaign_pinsDownSql() (*asset, error) {
+	bytes, err := _1528395657_add_campaign_pinsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395657_add_campaign_pins.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf2, 0x46, 0x58, 0xf9, 0xd8, 0x2b, 0xd9, 0x25, 0xed, 0xb9, 0x2c, 0xa7, 0x41, 0x94, 0x8d, 0xe2, 0x4c, 0xb3, 0x9f, 0x95, 0xc2, 0x5d, 0x1e, 0xc3, 0x73, 0xf0, 0x5c, 0x5e, 0x55, 0x73, 0xf0, 0x89}}
+	return a, nil
+}
+
+var __1528395657_add_campaign_pinsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\x84\x30\x14\x45\xf7\xf9\x8a\xbb\x54\x98\x3f\x98\x55\x26\x3e\x4b\xc0\x89\x54\x23\xcc\x2e\xd8\x31\xc8\x5b\x98\x5a\x8d\x14\xfa\xf5\x25\xa5\xd6\x16\x4a\xe9\x32\xc9\x79\x27\xef\xde\x0b\x3d\x68\x73\x16\x42\x35\x24\x2d\xc1\xca\x4b\x45\xd0\x25\x4c\x6d\x41\x37\xdd\xda\x16\xf7\x7e\x9a\x7b\x1e\x83\x9b\x39\xac\xc8\x04\x8e\x1b\x1e\xf0\xc4\x23\x87\xf8\xc1\x9b\xae\xaa\xd0\x50\x49\x0d\x19\x45\xc7\xe0\x9a\xf1\x90\xa3\x36\x28\xa8\x22\x4b\x50\xb2\x55\xb2\x20\x14\x09\x6d\xd2\x8f\x27\x01\x6c\xab\x5f\x1c\x0f\xe0\x10\xfd\xe8\x97\x5f\x8d\x89\xf9\x9f\xed\xbe\xf8\x3e\xfa\xc1\xf5\x11\x91\x27\xbf\xc6\x7e\x9a\xe3\xdb\x21\x2d\xa8\x94\x5d\x65\x11\x9e\x5f\xb3\x3c\x0d\xa8\xda\xb4\xb6\x91\xda\xd8\x23\x5e\x0a\xec\xbe\x4e\x3c\xb8\xcf\x1d\xdd\x16\xf8\x65\xf3\xe8\x8c\x7e\xec\x08\xd9\x37\xe4\xb4\xe7\xc8\x45\x7e\xd4\xaa\x4d\x41\xb7\xbf\x6a\xdd\xcd\xa9\xa5\x1f\x0f\xd9\xae\x3b\x0b\xa1\xea\xeb\x55\xdb\xb3\x78\x1f\x00\x29\x1f\xbd\x8f\xb4\x01\x00\x00")
+
+func _1528395657_add_campaign_pinsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395657_add_campaign_pinsUpSql,
+		"1528395657_add_campaign_pins.up.sql",
+	)
+}
+
+func _1528395657_add_campaign_pinsUpSql() (*asset, error) {
+	bytes, err := _1528395657_add_campaign_pinsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395657_add_campaign_pins.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb8, 0xf2, 0x73, 0x8c, 0x87, 0xb6, 0xf6, 0x1d, 0xbf, 0x51, 0x1d, 0xd7, 0x73, 0x47, 0x46, 0xf0, 0x36, 0x71, 0x9d, 0x1f, 0xc9, 0x71, 0xbd, 0xe7, 0xe6, 0xa3, 0xf3, 0xb6, 0x18, 0xc, 0xfa, 0x4c}}
+	return a, nil
+}
+
+var __1528395658_add_idempotency_key_to_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x2b\x8e\xcf\x4b\xcc\x4d\x2d\x2e\x48\x4c\x4e\x8d\x2f\x2d\x4e\x2d\x8a\xcf\x4c\x89\xcf\x4c\x49\xcd\x2d\xc8\x2f\x49\xcd\x4b\xae\x8c\xcf\x4e\xad\xb4\x26\xde\x84\xfc\xa2\x74\xac\x06\x38\xfa\x84\xb8\x06\x29\x84\x38\x3a\xf9\xb8\x22\xf4\x29\x80\x8d\x75\xf6\xf7\x09\xf5\xf5\x43\x32\x17\x43\x33\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x60\x00\xe6\x42\x2f\x73\xd1\x00\x00\x00")
+
+func _1528395658_add_idempotency_key_to_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395658_add_idempotency_key_to_campaignsDownSql,
+		"1528395658_add_idempotency_key_to_campaigns.down.sql",
+	)
+}
+
+func _1528395658_add_idempotency_key_to_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395658_add_idempotency_key_to_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395658_add_idempotency_key_to_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6f, 0x6a, 0x75, 0x6e, 0x83, 0x2f, 0x52, 0xe, 0x9d, 0x9e, 0x75, 0xf2, 0x1c, 0xa5, 0xc4, 0xad, 0xc2, 0xd2, 0xe0, 0x5, 0xbb, 0xca, 0xb5, 0x98, 0x56, 0xf, 0x50, 0xdb, 0x47, 0xeb, 0x5b, 0x56}}
+	return a, nil
+}
+
+var __1528395658_add_idempotency_key_to_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\xcd\x4a\xc5\x30\x10\x46\xf7\x79\x8a\x6f\xa9\xe0\x1b\x74\x95\x7b\x33\x6a\x20\x9d\x60\x9b\xe0\xdd\x85\xd0\x86\x52\xa4\x3f\xb4\x15\xec\xdb\x0b\x16\x54\xda\x8d\x75\x3d\x67\x3e\xce\xb9\xd0\x93\xe6\x4c\x08\x69\x1c\x15\x70\xf2\x62\x08\x55\xec\xc6\xd8\x36\xfd\x0c\xa9\x14\xae\xd6\xf8\x9c\xa1\x1f\xc1\xd6\x81\x6e\xba\x74\x25\xda\x3a\x75\xe3\xb0\xa4\xbe\x5a\xc3\x5b\x5a\xb1\xa4\x8f\x25\x13\xe2\x5a\x90\x74\x04\xcf\xfa\xc5\x13\x34\x2b\xba\xed\x1e\xbf\xb7\x43\x1f\xbb\x34\x8f\xb1\x4a\xe1\x7d\x4e\x53\x68\xeb\xb0\x1b\x15\x80\xe5\x1f\x99\xbb\xc3\xc3\xc3\x5e\xe3\x5e\x00\xaf\xcf\x54\x10\x0e\x2c\x74\xf9\xe5\xcf\xde\x18\x48\x56\x87\x82\x5f\xf7\x7f\x87\x0c\x53\x73\xaa\x63\xe3\xff\x94\xb1\xa1\x67\x2b\x6c\x9e\x6b\x97\x89\xcf\x01\x00\xa5\x6e\xa0\x56\xe5\x01\x00\x00")
+
+func _1528395658_add_idempotency_key_to_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395658_add_idempotency_key_to_campaignsUpSql,
+		"1528395658_add_idempotency_key_to_campaigns.up.sql",
+	)
+}
+
+func _1528395658_add_idempotency_key_to_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395658_add_idempotency_key_to_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395658_add_idempotency_key_to_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x15, 0x6d, 0xab, 0x93, 0xcc, 0x63, 0xff, 0x2f, 0x79, 0x9a, 0x56, 0xa5, 0x88, 0x97, 0xe0, 0xb1, 0xd6, 0x80, 0xe8, 0x30, 0x4f, 0x94, 0x46, 0xda, 0x2d, 0x51, 0xc4, 0x27, 0xb2, 0xb1, 0x1a, 0x3}}
+	return a, nil
+}
+
+var __1528395659_add_is_template_to_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4a\x00\xb5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5f\x74\x65\x6d\x70\x6c\x61\x74\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x76\x42\x08\x8b\x4a\x00\x00\x00")
+
+func _1528395659_add_is_template_to_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395659_add_is_template_to_campaignsDownSql,
+		"1528395659_add_is_template_to_campaigns.down.sql",
+	)
+}
+
+func _1528395659_add_is_template_to_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395659_add_is_template_to_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395659_add_is_template_to_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5b, 0x62, 0xb5, 0xec, 0x4, 0xbe, 0xbf, 0xcd, 0xc, 0x42, 0x5f, 0xee, 0xb1, 0x1e, 0x67, 0xa1, 0x5a, 0x91, 0x98, 0x59, 0x97, 0x6b, 0x69, 0xe1, 0xae, 0xe2, 0xd8, 0x24, 0x18, 0x68, 0x7f, 0x97}}
+	return a, nil
+}
+
+var __1528395659_add_is_template_to_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5f\x74\x65\x6d\x70\x6c\x61\x74\x65\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xea\x83\x7e\x5d\x6c\x00\x00\x00")
+
+func _1528395659_add_is_template_to_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395659_add_is_template_to_campaignsUpSql,
+		"1528395659_add_is_template_to_campaigns.up.sql",
+	)
+}
+
+func _1528395659_add_is_template_to_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395659_add_is_template_to_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395659_add_is_template_to_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa0, 0x53, 0xf8, 0xc5, 0x7a, 0x49, 0xce, 0x35, 0x99, 0xde, 0x94, 0x20, 0x27, 0xa, 0x5, 0x54, 0x23, 0x55, 0xf1, 0x2f, 0x59, 0x42, 0x34, 0x25, 0x61, 0x13, 0x97, 0xe3, 0xde, 0x44, 0x95, 0x7e}}
+	return a, nil
+}
+
+var __1528395660_keep_events_of_deleted_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8f\xc1\x4a\xc4\x40\x10\x44\xef\xfd\x15\x75\x4c\xbe\x21\xa7\xd9\x99\xca\x3a\x98\xf4\x40\xa7\x41\x3c\x85\xc5\x44\x19\xc4\x45\x88\x08\xfe\xbd\x2c\xca\x1a\xf7\xd8\xdd\xf5\x1e\x5d\x07\x1e\xb3\x76\x22\x89\x03\x9d\xe8\xad\x8c\x78\x3a\xbd\xbd\x9f\xea\xcb\x79\x5e\x3f\xd7\xf3\xc7\x86\x87\x3b\x1a\xff\xb6\x75\x81\x16\x47\x56\x34\x13\x07\x46\x47\x5d\xfe\x83\x5b\xdb\x89\x84\xc1\x69\xf0\x70\x18\x76\xec\x8f\x51\x80\x90\x12\x62\xd1\xc9\x2d\x64\xf5\xdb\xc0\x7c\x9d\xeb\x32\x3f\xbf\xae\x5f\x02\xf4\xc5\x98\x8f\x8a\x7b\x3e\xa2\xd9\xdd\x5b\x18\x7b\x1a\x35\x72\xba\x7a\xb6\xa6\x2e\x2d\x8a\xe2\xb7\x57\x0c\x53\x0c\x89\x48\x97\xa8\x5d\x7e\xea\x44\x62\x19\xc7\xec\x9d\x7c\x0f\x00\x0e\x1c\x02\x89\x04\x01\x00\x00")
+
+func _1528395660_keep_events_of_deleted_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395660_keep_events_of_deleted_campaignsDownSql,
+		"1528395660_keep_events_of_deleted_campaigns.down.sql",
+	)
+}
+
+func _1528395660_keep_events_of_deleted_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395660_keep_events_of_deleted_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395660_keep_events_of_deleted_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd, 0xf2, 0x39, 0x41, 0x48, 0x50, 0x25, 0x38, 0x2b, 0xc8, 0x6a, 0x85, 0x80, 0xb2, 0x98, 0x6e, 0x12, 0xbe, 0x4e, 0x91, 0xe2, 0xe, 0x0, 0x2d, 0x3e, 0x75, 0xa9, 0xfa, 0xd7, 0x8c, 0x13, 0x6e}}
+	return a, nil
+}
+
+var __1528395660_keep_events_of_deleted_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xcd\x41\x4e\xc3\x30\x10\x85\xe1\xbd\x4f\xf1\x0e\xd0\x72\x81\xac\xd2\x12\x90\x45\x9b\xa0\xc4\x0b\x76\x91\x49\x9e\xdb\x11\xae\x53\xc5\x43\xa5\xde\x1e\x35\x88\x2c\x58\xce\xe8\xd3\xfb\x77\xd5\xab\xad\x0b\x63\xb6\x5b\xbc\x91\x57\xe8\x99\xf0\x83\xca\x4d\xf4\x0e\x95\x0b\xa3\x24\x62\x0a\xf0\x18\xfc\xe5\xea\xe5\x94\x36\x90\x34\xc4\xef\x51\xd2\x69\xe1\xbc\x31\x29\x66\x0e\xd3\xfc\xf8\x3d\xa6\x44\x33\x46\x46\xaa\x4c\x69\x03\x1f\x94\xf3\x42\xff\x26\x20\x9a\x19\x03\xce\x3e\xe3\x93\x4c\xbf\x98\xe3\x93\x29\x0f\xae\x6a\xe1\xca\xdd\xa1\x5a\x83\xfd\x52\xc8\x78\x6e\x9b\x77\xec\x9b\xba\x73\x6d\x69\x6b\x07\xfb\x82\xea\xc3\x76\xae\xfb\x2f\xfb\xf5\x96\xb1\x0f\x5f\xbc\x17\xc6\xec\x9b\xe3\xd1\xba\xc2\xfc\x0c\x00\x26\xb5\xc3\xed\xf1\x00\x00\x00")
+
+func _1528395660_keep_events_of_deleted_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395660_keep_events_of_deleted_campaignsUpSql,
+		"1528395660_keep_events_of_deleted_campaigns.up.sql",
+	)
+}
+
+func _1528395660_keep_events_of_deleted_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395660_keep_events_of_deleted_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395660_keep_events_of_deleted_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x62, 0x7c, 0xa4, 0x7e, 0x53, 0x73, 0x42, 0x96, 0xa4, 0xf1, 0xaf, 0x91, 0xe7, 0x5c, 0xc2, 0xbf, 0x8d, 0xca, 0xdc, 0x33, 0x47, 0x7c, 0x81, 0xc0, 0x8f, 0x10, 0x93, 0xf7, 0x26, 0xe0, 0xf5, 0x75}}
+	return a, nil
+}
+
+var __1528395661_add_campaign_viewsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x36\x00\xc9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x76\x69\x65\x77\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xf8\x7d\x08\xe4\x36\x00\x00\x00")
+
+func _1528395661_add_campaign_viewsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395661_add_campaign_viewsDownSql,
+		"1528395661_add_campaign_views.down.sql",
+	)
+}
+
+func _1528395661_add_campaign_viewsDownSql() (*asset, error) {
+	bytes, err := _1528395661_add_campaign_viewsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395661_add_campaign_views.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0xb9, 0x7b, 0x43, 0x20, 0x36, 0x19, 0xcd, 0xd8, 0x54, 0x8a, 0xe6, 0x9a, 0xa3, 0x87, 0x8, 0x90, 0x90, 0xfe, 0x7d, 0x77, 0x14, 0xdd, 0x10, 0x51, 0x8b, 0xe4, 0x2b, 0x95, 0xd0, 0x8, 0x75}}
+	return a, nil
+}
+
+var __1528395661_add_campaign_viewsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\xc3\x30\x0c\x86\xef\x7e\x0a\x1d\x13\xc8\x1b\xf4\xe4\xda\xca\x30\xa4\x0a\x4b\x1c\xe8\xcd\x64\x8b\x09\x3a\x24\xeb\x6a\x67\x85\x3d\xfd\x70\xe9\xea\x32\xc6\xd8\xd1\xe6\xd3\xa7\xff\xd7\x1e\x9f\x0c\xed\x84\x50\x1d\x4a\x8b\x60\xe5\xbe\x41\x30\x35\x50\x6b\x01\x8f\xa6\xb7\x3d\xbc\x8e\xcb\x69\xe4\x79\x75\x1f\xec\x2f\x01\x0a\x01\xf9\x8b\x27\x78\xe1\x99\xd7\x78\x1d\xa0\xa1\x69\xa0\xc3\x1a\x3b\x24\x85\x79\x32\x14\x3c\x95\xd0\x12\x68\x6c\xd0\x22\x28\xd9\x2b\xa9\x11\x74\x42\xbb\xb4\xb2\x12\x00\x5b\xf0\x67\xc7\x13\xf0\x1a\xfd\xec\xcf\xbf\x1a\x13\xf3\x3f\x5b\x0a\xeb\x27\x37\x46\x88\xbc\xf8\x10\xc7\xe5\x14\x3f\xb3\x53\x63\x2d\x87\xc6\xc2\xfa\x76\x29\xca\xc4\xab\x96\x7a\xdb\x49\x43\x36\xb7\x4b\x8e\xe0\xee\x4f\x9e\xdc\x2d\xa3\xdb\x56\x7e\xdf\x3c\x0c\x64\x9e\x07\x84\xe2\x01\xa9\xbe\x7b\x94\xa2\xcc\x77\x35\xa4\xf1\xf8\xe7\x5d\xef\xea\x1c\xbc\xa5\x1f\x4c\x71\x63\xaa\x87\x76\x1a\x7b\x75\x5d\xd4\x1e\x0e\xc6\xee\xc4\xd7\x00\xdf\x38\x32\x0c\xd1\x01\x00\x00")
+
+func _1528395661_add_campaign_viewsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395661_add_campaign_viewsUpSql,
+		"1528395661_add_campaign_views.up.sql",
+	)
+}
+
+func _1528395661_add_campaign_viewsUpSql() (*asset, error) {
+	bytes, err := _1528395661_add_campaign_viewsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395661_add_campaign_views.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x87, 0x33, 0xcd, 0x76, 0xf, 0x83, 0x6d, 0x7f, 0xb9, 0xae, 0x40, 0xe9, 0xa5, 0xce, 0xec, 0x80, 0xde, 0x0, 0xe4, 0x6c, 0x7b, 0xfc, 0x77, 0x8a, 0xa6, 0xcc, 0x67, 0x18, 0x4e, 0x70, 0x68, 0xbd}}
+	return a, nil
+}
+
+var __1528395662_add_campaigns_trgm_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x6e\x61\x6d\x65\x5f\x74\x72\x67\x6d\x3b\x0a\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x5f\x64\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x5f\x74\x72\x67\x6d\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb1\xa0\x0f\x24\x6c\x00\x00\x00")
+
+func _1528395662_add_campaigns_trgm_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395662_add_campaigns_trgm_indexesDownSql,
+		"1528395662_add_campaigns_trgm_indexes.down.sql",
+	)
+}
+
+func _1528395662_add_campaigns_trgm_indexesDownSql() (*asset, error) {
+	bytes, err := _1528395662_add_campaigns_trgm_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395662_add_campaigns_trgm_indexes.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x85, 0xad, 0xce, 0xc3, 0x80, 0xa, 0xd2, 0xf8, 0xb1, 0x5e, 0x8c, 0x81, 0x9c, 0xc4, 0xb2, 0x9b, 0x2f, 0x24, 0x3c, 0xff, 0xa5, 0xfa, 0x6d, 0x4, 0xa1, 0xc5, 0xe1, 0x4c, 0xeb, 0xb0, 0x97, 0x53}}
+	return a, nil
+}
+
+var __1528395662_add_campaigns_trgm_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xc1\x4a\xf4\x40\x10\x84\xef\x79\x8a\x3a\xfe\xff\x61\x7d\x81\x9c\x74\x1d\x97\x41\x37\x01\x13\x61\x6f\x61\x76\xd2\xce\x36\x64\x3a\x71\x7a\x02\xfa\xf6\x32\xeb\x21\xe2\x41\xbc\x35\xc5\xd7\x5f\x51\x77\xe6\x60\x9b\xba\xaa\x76\x3b\xf4\x89\x43\x72\x11\x2c\x23\xbd\x93\x62\xa2\x8c\x7c\x21\xe8\x7a\xd6\x9c\x58\x02\xec\x93\x7d\x34\xf0\xb3\x8c\x9c\x79\x16\xc5\xfc\x7a\x25\xbc\x8b\x8b\xe3\x20\x0a\x25\x97\xfc\xa5\xd8\xde\x56\x4a\x1f\x58\x95\x70\xe6\x1c\xdd\xf2\xa5\x85\x7a\x27\x0a\x16\xcd\xe4\xc6\xf2\x5f\x02\x29\x72\x37\x4d\x9b\xe8\xa6\xda\x3f\x9b\xdb\xde\xc0\x36\xf7\xe6\x04\xfb\x80\xa6\xed\x61\x4e\xb6\xeb\xbb\x8d\x1a\xc4\x45\x1a\x72\x0a\x11\x6d\xb3\xc5\x78\xe9\x6c\x73\x40\x60\xc1\xbf\x42\x94\xeb\x4a\x0d\xf3\xa2\xff\xeb\xbf\xa9\x47\x52\x9f\x78\x29\x3b\x7f\x6f\xf8\x06\xfe\x2c\xaa\xf6\xed\xf1\x68\xfb\xba\xfa\x1c\x00\x55\xe4\xa1\x19\x66\x01\x00\x00")
+
+func _1528395662_add_campaigns_trgm_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395662_add_campaigns_trgm_indexesUpSql,
+		"1528395662_add_campaigns_trgm_indexes.up.sql",
+	)
+}
+
+func _1528395662_add_campaigns_trgm_indexesUpSql() (*asset, error) {
+	bytes, err := _1528395662_add_campaigns_trgm_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395662_add_campaigns_trgm_indexes.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1, 0x8a, 0x20, 0xd4, 0xc4, 0x56, 0x49, 0xc7, 0x15, 0x2d, 0x88, 0x22, 0x18, 0x6b, 0xf5, 0xad, 0x1f, 0x51, 0xc0, 0x33, 0xab, 0xe, 0x21, 0x58, 0x57, 0xab, 0x37, 0x8b, 0xa3, 0x3a, 0x83, 0xc}}
+	return a, nil
+}
+
+var __1528395663_add_campaign_remindersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x72\x65\x6d\x69\x6e\x64\x65\x72\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5f\x01\xfb\xbf\x3a\x00\x00\x00")
+
+func _1528395663_add_campaign_remindersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395663_add_campaign_remindersDownSql,
+		"1528395663_add_campaign_reminders.down.sql",
+	)
+}
+
+func _1528395663_add_campaign_remindersDownSql() (*asset, error) {
+	bytes, err := _1528395663_add_campaign_remindersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395663_add_campaign_reminders.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x65, 0x3b, 0x4e, 0xed, 0x4e, 0x27, 0x92, 0xf4, 0x3e, 0xe9, 0xd4, 0xb8, 0xf2, 0x4, 0x2c, 0x70, 0xbf, 0x91, 0x66, 0x4c, 0x88, 0xfc, 0x42, 0xe8, 0xd1, 0xef, 0x17, 0x92, 0x71, 0x92, 0xf6, 0x61}}
+	return a, nil
+}
+
+var __1528395663_add_campaign_remindersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xd1\x4a\xc3\x30\x14\x86\xef\xf3\x14\xff\x65\x0b\xbe\xc1\xae\xb2\xf6\x4c\x83\x6d\x2a\x69\x86\xdb\x55\x89\x6b\x28\x01\x1b\x47\x12\x11\x7c\x7a\xc9\x26\x2d\xc8\x44\xef\x42\xfe\xff\x7c\x7c\xe7\x6c\xe9\x5e\xc8\x0d\x63\x95\x22\xae\x09\x9a\x6f\x1b\x82\xd8\x41\x76\x1a\x74\x10\xbd\xee\x71\x32\xf3\xd9\xb8\xc9\x0f\xc1\xce\xce\x8f\x36\x44\x14\x0c\x70\x23\x5e\xdc\x14\x6d\x70\xe6\x15\x4f\x4a\xb4\x5c\x1d\xf1\x48\xc7\x3b\x86\x75\xe4\x5a\x72\x3e\x5d\x80\x72\xdf\x34\x50\xb4\x23\x45\xb2\xa2\x95\x1c\x0b\x37\x96\xe8\x24\x6a\x6a\x48\x13\x2a\xde\x57\xbc\x26\xd4\xb9\xaa\xb2\x52\x86\xbe\x47\x1b\x06\x37\xc2\xf9\x64\x27\x1b\x6e\x12\x73\xe7\x7f\xb4\xeb\x32\x83\x49\x48\x6e\xb6\x31\x99\xf9\x9c\x3e\x17\x66\x6e\x44\xeb\xd3\x8f\x3c\x7f\x9f\x82\x35\xc9\xfe\x3a\x99\xa5\xf9\xbe\xd1\xf0\x6f\x1f\x45\xc9\xca\xf5\xb6\x42\xd6\x74\xf8\xf3\xb6\xdf\xaf\x8c\xef\xe4\x8d\xbc\x58\xf2\x12\xcf\x0f\xa4\x68\xf1\x14\xfd\x45\x60\xc3\x58\xd5\xb5\xad\xd0\x1b\xf6\x35\x00\xdd\xfd\xdf\x8a\xdd\x01\x00\x00")
+
+func _1528395663_add_campaign_remindersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395663_add_campaign_remindersUpSql,
+		"1528395663_add_campaign_reminders.up.sql",
+	)
+}
+
+func _1528395663_add_campaign_remindersUpSql() (*asset, error) {
+	bytes, err := _1528395663_add_campaign_remindersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395663_add_campaign_reminders.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0x97, 0xa8, 0x4a, 0x88, 0x26, 0xcd, 0x95, 0x57, 0x6a, 0x3, 0xa4, 0xc4, 0x32, 0xa2, 0x25, 0xb1, 0x32, 0x5d, 0x4f, 0x9b, 0x4d, 0xdf, 0x6f, 0x2f, 0x7c, 0x3f, 0x1a, 0xdd, 0xf4, 0xe4, 0x4e}}
+	return a, nil
+}
+
+var __1528395664_add_campaign_collaboratorsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3e\x00\xc1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x63\x6f\x6c\x6c\x61\x62\x6f\x72\x61\x74\x6f\x72\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6f\x4a\x97\x7c\x3e\x00\x00\x00")
+
+func _1528395664_add_campaign_collaboratorsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395664_add_campaign_collaboratorsDownSql,
+		"1528395664_add_campaign_collaborators.down.sql",
+	)
+}
+
+func _1528395664_add_campaign_collaboratorsDownSql() (*asset, error) {
+	bytes, err := _1528395664_add_campaign_collaboratorsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395664_add_campaign_collaborators.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x85, 0xc9, 0xc8, 0x9e, 0x94, 0xe6, 0xd0, 0x20, 0x2c, 0x76, 0x23, 0x8a, 0xb2, 0xad, 0xe3, 0x1, 0x2e, 0x47, 0xac, 0x36, 0x3, 0xc9, 0x66, 0xe5, 0xfb, 0xb1, 0x8f, 0x81, 0xad, 0x8, 0xe5, 0x18}}
+	return a, nil
+}
+
+var __1528395664_add_campaign_collaboratorsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\xb4\x30\x14\x85\xf7\x79\x8a\xb3\x54\x98\x37\x70\x95\x89\xd7\x9f\x80\x13\xf9\x35\xc2\xec\x42\x46\x83\x04\x46\x9d\x6a\xa4\xd0\xa7\x2f\x29\xb5\x76\x31\x85\xd9\x26\xdf\xfd\xce\xbd\xe7\x4c\xff\xa4\xca\x18\x13\x35\x71\x4d\xd0\xfc\x5c\x12\x64\x01\x55\x69\xd0\x55\x36\xba\x41\x67\xc7\x87\xf5\xc3\x64\xba\xf9\x7e\xb7\xb7\x79\xb1\x61\x5e\x56\x24\x0c\xc7\x97\xef\x71\xf3\x83\x9f\xc2\xd7\xa0\x6a\xcb\x12\x35\x15\x54\x93\x12\x74\x18\xd6\xc4\xf7\x29\x2a\x85\x9c\x4a\xd2\x04\xc1\x1b\xc1\x73\x42\x1e\xd1\x3a\x46\x9f\x18\xb0\xad\x6e\x31\xbe\x87\x9f\x82\x1b\xdc\xf2\xd4\x18\x99\xd7\x6c\xdd\xe2\x6c\x70\xbd\xb1\x01\xc1\x8f\x6e\x0d\x76\x7c\x84\x8f\x43\x9a\x53\xc1\xdb\x52\x63\x9a\xdf\x93\x34\x0e\x88\x4a\x35\xba\xe6\x52\xe9\x3f\x2e\x37\x3f\xcf\xbe\x37\xdf\xcb\x9a\x6d\xf2\x6f\x9b\x43\xab\xe4\xff\x96\x90\xfc\x42\x4e\xfb\x41\x29\x4b\x8f\xa2\xa5\xca\xe9\xfa\x52\xd1\x7b\x44\xec\xed\x39\x91\xec\x01\x19\x63\xa2\xba\x5c\xa4\xce\xd8\xe7\x00\x57\xf2\xd9\x56\xd8\x01\x00\x00")
+
+func _1528395664_add_campaign_collaboratorsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395664_add_campaign_collaboratorsUpSql,
+		"1528395664_add_campaign_collaborators.up.sql",
+	)
+}
+
+func _1528395664_add_campaign_collaboratorsUpSql() (*asset, error) {
+	bytes, err := _1528395664_add_campaign_collaboratorsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395664_add_campaign_collaborators.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9f, 0xc3, 0x58, 0xe4, 0x5, 0xe5, 0xc8, 0x7c, 0x20, 0x5, 0x7e, 0x72, 0x69, 0x95, 0xe9, 0x26, 0x7d, 0xa2, 0x31, 0x7f, 0x53, 0x68, 0xe1, 0x7b, 0x97, 0x4f, 0x62, 0x58, 0x9a, 0x7e, 0x19, 0x87}}
+	return a, nil
+}
+
+var __1528395665_add_is_locked_to_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x48\x00\xb7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5f\x6c\x6f\x63\x6b\x65\x64\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xca\xbe\x1d\x16\x48\x00\x00\x00")
+
+func _1528395665_add_is_locked_to_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395665_add_is_locked_to_campaignsDownSql,
+		"1528395665_add_is_locked_to_campaigns.down.sql",
+	)
+}
+
+func _1528395665_add_is_locked_to_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395665_add_is_locked_to_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395665_add_is_locked_to_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4c, 0xd3, 0x9f, 0xd2, 0x30, 0x44, 0xe0, 0x88, 0x60, 0xae, 0x6e, 0xe2, 0xc7, 0x62, 0xb8, 0x8e, 0xbf, 0x35, 0xbc, 0x85, 0x34, 0x9, 0x8c, 0x94, 0x36, 0x77, 0xb1, 0xc7, 0xe3, 0x13, 0x13, 0x7d}}
+	return a, nil
+}
+
+var __1528395665_add_is_locked_to_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6a\x00\x95\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5f\x6c\x6f\x63\x6b\x65\x64\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x69\x5c\x4f\x80\x6a\x00\x00\x00")
+
+func _1528395665_add_is_locked_to_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395665_add_is_locked_to_campaignsUpSql,
+		"1528395665_add_is_locked_to_campaigns.up.sql",
+	)
+}
+
+func _1528395665_add_is_locked_to_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395665_add_is_locked_to_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395665_add_is_locked_to_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x76, 0x73, 0x52, 0xe1, 0xf2, 0xef, 0x40, 0x6c, 0xa7, 0x3f, 0xfb, 0x52, 0x41, 0x5f, 0xbd, 0xa, 0x95, 0xcf, 0x37, 0xf2, 0xbb, 0x54, 0x5a, 0x3c, 0xe9, 0xf9, 0x23, 0x2f, 0x4f, 0xa3, 0xa5, 0xd2}}
+	return a, nil
+}
+
+var __1528395666_add_campaign_dependenciesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3d\x00\xc2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x64\x65\x70\x65\x6e\x64\x65\x6e\x63\x69\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x81\x31\xef\xb8\x3d\x00\x00\x00")
+
+func _1528395666_add_campaign_dependenciesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395666_add_campaign_dependenciesDownSql,
+		"1528395666_add_campaign_dependencies.down.sql",
+	)
+}
+
+func _1528395666_add_campaign_dependenciesDownSql() (*asset, error) {
+	bytes, err := _1528395666_add_campaign_dependenciesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395666_add_campaign_dependencies.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x79, 0x58, 0x4, 0x0, 0xbb, 0x72, 0xaf, 0x84, 0x1f, 0xb6, 0xbc, 0x5, 0xd1, 0xe2, 0x2f, 0xf5, 0xfb, 0x96, 0xd8, 0x28, 0x1, 0x38, 0x95, 0xe2, 0xe6, 0x17, 0x61, 0x68, 0x69, 0x67, 0xa7, 0x83}}
+	return a, nil
+}
+
+var __1528395666_add_campaign_dependenciesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x91\xc1\x4a\xc4\x30\x14\x45\xf7\xf9\x8a\xbb\x6c\x61\xfe\xa0\x22\x64\xd2\x57\x0d\x76\x52\x6c\x53\x98\x5d\xa8\x4d\x1c\x02\x36\xad\x36\x83\xe0\xd7\x4b\x15\xac\x95\x59\xcc\xc2\x6d\x72\xdf\x39\xc9\xbb\x7b\xba\x93\x2a\x63\x4c\xd4\xc4\x35\x41\xf3\x7d\x49\x90\x05\x54\xa5\x41\x47\xd9\xe8\x06\x7d\x37\x4c\x9d\x3f\x05\x63\xdd\xe4\x82\x75\xa1\xf7\x6e\x46\xc2\xb0\xde\x78\x8b\x27\x7f\xf2\x21\x7e\xcd\xa9\xb6\x2c\x51\x53\x41\x35\x29\x41\x2b\x60\x4e\xbc\x4d\x51\x29\xe4\x54\x92\x26\x08\xde\x08\x9e\x13\xf2\x25\x5a\x2f\xe6\x1d\x03\xbe\x2d\xb3\x19\xff\x17\xdb\xbf\xb9\x2e\x3a\x6b\xba\x88\xe8\x07\x37\xc7\x6e\x98\xe2\xc7\x0a\xce\xa9\xe0\x6d\xa9\x11\xc6\xf7\x24\x5d\x06\x44\xa5\x1a\x5d\x73\xa9\xf4\xe5\x0d\x98\x9f\x53\x6f\xcd\xe6\xd1\xe6\x1c\xfc\xeb\xd9\xa1\x55\xf2\xb1\x25\x24\xbf\x82\xbb\xed\xf7\xae\x13\x85\x31\x9a\xd9\xbd\x3c\x43\xdc\x93\x78\xd8\xf0\x70\x73\xfb\x87\xc8\xd2\xb5\x4d\xa9\x72\x3a\x5e\xd3\xa6\xd9\x30\x96\x8a\x2e\xc6\x92\xad\x2a\x63\x4c\x54\x87\x83\xd4\x19\xfb\x1c\x00\x62\x0f\x5f\xa3\x47\x02\x00\x00")
+
+func _1528395666_add_campaign_dependenciesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395666_add_campaign_dependenciesUpSql,
+		"1528395666_add_campaign_dependencies.up.sql",
+	)
+}
+
+func _1528395666_add_campaign_dependenciesUpSql() (*asset, error) {
+	bytes, err := _1528395666_add_campaign_dependenciesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395666_add_campaign_dependencies.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x48, 0xaf, 0x21, 0x7d, 0xcf, 0x56, 0xfd, 0x56, 0x8, 0x8f, 0x2b, 0x59, 0x3c, 0x4, 0x31, 0xac, 0x27, 0x3a, 0x7, 0x27, 0x18, 0x42, 0x3e, 0x70, 0x28, 0x87, 0x99, 0xa8, 0xa8, 0xc9, 0xbe, 0xfc}}
+	return a, nil
+}
+
+var __1528395667_add_sort_key_to_campaignsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x2b\x8e\x2f\xce\x2f\x2a\x89\xcf\x4e\xad\xb4\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x45\xc8\x2b\x80\xb5\x3b\xfb\xfb\x84\xfa\xfa\x21\xe9\x47\xe8\x02\xcb\x07\xbb\x06\x86\xba\xfa\x39\xbb\xe2\xb5\x21\xbe\x38\xb5\xd0\x9a\x8b\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x30\x00\x7f\x1b\x76\x63\xa0\x00\x00\x00")
+
+func _1528395667_add_sort_key_to_campaignsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395667_add_sort_key_to_campaignsDownSql,
+		"1528395667_add_sort_key_to_campaigns.down.sql",
+	)
+}
+
+func _1528395667_add_sort_key_to_campaignsDownSql() (*asset, error) {
+	bytes, err := _1528395667_add_sort_key_to_campaignsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395667_add_sort_key_to_campaigns.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0xd1, 0x34, 0xda, 0xca, 0xab, 0x72, 0x3f, 0xde, 0x67, 0x8c, 0x6b, 0x32, 0x7b, 0x4, 0x7, 0x95, 0xfe, 0xec, 0x0, 0x27, 0xe9, 0x77, 0xe8, 0x22, 0x61, 0x3b, 0xd0, 0xda, 0x90, 0x23, 0x9c}}
+	return a, nil
+}
+
+var __1528395667_add_sort_key_to_campaignsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\x41\x6f\x9b\x40\x10\x85\xef\xfb\x2b\xde\x2d\xd0\x12\xab\x55\x95\x5c\x50\x0f\x18\xc6\x15\x12\x2c\xad\x59\x54\xf7\x64\xad\xcd\xd4\x46\xb1\xc1\xdd\xdd\x84\xe6\xdf\x57\xc8\xc6\x54\x55\x9a\x5c\x67\xe7\xbd\xfd\xde\xbc\x39\x7d\x49\x65\x28\xc4\xed\x2d\x24\xf7\xd8\xea\xe3\x49\x37\xbb\xd6\x42\x1b\x46\x67\x6a\x36\x5c\xe3\xa0\xad\x9b\x41\xed\xb9\x31\xb0\x9d\x71\x78\xe0\xe7\xf3\x86\x3d\xe9\x2d\xd7\xb8\xbf\xbb\xfb\x74\x0f\x7d\xd2\xc6\x05\xb0\xdd\xe0\xe6\xf6\xda\x41\x5f\x0d\xb1\xd5\x2d\x36\x8c\x63\xf7\xc4\x35\x36\xec\x7a\xe6\x16\xae\xef\xd0\xb9\x3d\x1b\x8b\xbe\x71\xfb\xee\xd1\xc1\x70\xfb\x78\xdc\xb0\x69\xda\xdd\x4c\xc4\x4b\x8a\x14\xa1\xa4\x6f\x15\xc9\x98\x90\x2e\x20\x0b\x05\x5a\xa5\xa5\x2a\x27\xd8\xf5\x00\xb5\x7e\xe0\xe7\xb5\xe5\x5f\xa1\x10\x51\xa6\x68\x09\x15\xcd\x33\x9a\x96\x10\x25\x09\xe2\x22\xab\x72\xf9\x8f\xcf\xa8\xc6\xa6\xd9\x35\xad\x0b\x45\xf5\x35\x89\xd4\xdf\xd2\x92\xd4\xb4\xf5\x19\x4d\x8d\x77\xe7\xcc\xa1\x28\x29\xa3\x58\xc1\xb2\x7b\xd2\x07\xef\xe6\x65\xa6\x9b\x00\x71\x11\x65\x54\xc6\xe4\x79\x17\x45\x1e\xad\xbc\xa6\xf6\xb1\x58\x16\xf9\xf4\x95\x1f\xe0\x83\x8f\xf7\xf8\x18\xe0\xa7\x3e\x58\xf6\xff\x17\x47\x00\xe7\x98\x97\x48\x57\xbc\x81\x35\xa1\x45\x54\x65\x0a\x2d\xff\x7e\x15\xcb\x1f\x73\x04\xaf\xda\x0d\xb7\x92\x55\x96\x85\x17\x94\x6b\x1f\x2f\xdb\xa2\xf8\x2e\x29\xc1\xfc\xc7\x44\x3b\x1b\xdf\x43\x31\x96\x9a\xca\x84\x56\x6f\x36\x8a\x42\x4e\x53\x6f\x9c\x0e\x57\x89\x8b\x3c\x4f\x55\x28\xfe\x0c\x00\xb0\x2a\xd6\x6c\xc2\x02\x00\x00")
+
+func _1528395667_add_sort_key_to_campaignsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395667_add_sort_key_to_campaignsUpSql,
+		"1528395667_add_sort_key_to_campaigns.up.sql",
+	)
+}
+
+func _1528395667_add_sort_key_to_campaignsUpSql() (*asset, error) {
+	bytes, err := _1528395667_add_sort_key_to_campaignsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395667_add_sort_key_to_campaigns.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x29, 0xbf, 0x54, 0x8a, 0xd6, 0x67, 0x63, 0xb1, 0xc, 0xb1, 0x84, 0x4b, 0x33, 0x7e, 0xd0, 0xc5, 0x39, 0xf3, 0xff, 0x38, 0xd4, 0x6f, 0x4b, 0xa9, 0x3f, 0xed, 0xaf, 0xe3, 0x3c, 0xe3, 0x70, 0xb3}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1787,6 +2375,34 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395652_add_lsif_indexer.up.sql":                               _1528395652_add_lsif_indexerUpSql,
 	"1528395653_repo_normalize_visibility_metadata.down.sql":           _1528395653_repo_normalize_visibility_metadataDownSql,
 	"1528395653_repo_normalize_visibility_metadata.up.sql":             _1528395653_repo_normalize_visibility_metadataUpSql,
+	"1528395654_add_campaign_subscribers.down.sql":                     _1528395654_add_campaign_subscribersDownSql,
+	"1528395654_add_campaign_subscribers.up.sql":                       _1528395654_add_campaign_subscribersUpSql,
+	"1528395655_add_last_updated_by_to_campaigns.down.sql":             _1528395655_add_last_updated_by_to_campaignsDownSql,
+	"1528395655_add_last_updated_by_to_campaigns.up.sql":               _1528395655_add_last_updated_by_to_campaignsUpSql,
+	"1528395656_add_campaign_events.down.sql":                          _1528395656_add_campaign_eventsDownSql,
+	"1528395656_add_campaign_events.up.sql":                            _1528395656_add_campaign_eventsUpSql,
+	"1528395657_add_campaign_pins.down.sql":                            _1528395657_add_campaign_pinsDownSql,
+	"1528395657_add_campaign_pins.up.sql":                              _1528395657_add_campaign_pinsUpSql,
+	"1528395658_add_idempotency_key_to_campaigns.down.sql":             _1528395658_add_idempotency_key_to_campaignsDownSql,
+	"1528395658_add_idempotency_key_to_campaigns.up.sql":               _1528395658_add_idempotency_key_to_campaignsUpSql,
+	"1528395659_add_is_template_to_campaigns.down.sql":                 _1528395659_add_is_template_to_campaignsDownSql,
+	"1528395659_add_is_template_to_campaigns.up.sql":                   _1528395659_add_is_template_to_campaignsUpSql,
+	"1528395660_keep_events_of_deleted_campaigns.down.sql":             _1528395660_keep_events_of_deleted_campaignsDownSql,
+	"1528395660_keep_events_of_deleted_campaigns.up.sql":               _1528395660_keep_events_of_deleted_campaignsUpSql,
+	"1528395661_add_campaign_views.down.sql":                           _1528395661_add_campaign_viewsDownSql,
+	"1528395661_add_campaign_views.up.sql":                             _1528395661_add_campaign_viewsUpSql,
+	"1528395662_add_campaigns_trgm_indexes.down.sql":                   _1528395662_add_campaigns_trgm_indexesDownSql,
+	"1528395662_add_campaigns_trgm_indexes.up.sql":                     _1528395662_add_campaigns_trgm_indexesUpSql,
+	"1528395663_add_campaign_reminders.down.sql":                       _1528395663_add_campaign_remindersDownSql,
+	"1528395663_add_campaign_reminders.up.sql":                         _1528395663_add_campaign_remindersUpSql,
+	"1528395664_add_campaign_collaborators.down.sql":                   _1528395664_add_campaign_collaboratorsDownSql,
+	"1528395664_add_campaign_collaborators.up.sql":                     _1528395664_add_campaign_collaboratorsUpSql,
+	"1528395665_add_is_locked_to_campaigns.down.sql":                   _1528395665_add_is_locked_to_campaignsDownSql,
+	"1528395665_add_is_locked_to_campaigns.up.sql":                     _1528395665_add_is_locked_to_campaignsUpSql,
+	"1528395666_add_campaign_dependencies.down.sql":                    _1528395666_add_campaign_dependenciesDownSql,
+	"1528395666_add_campaign_dependencies.up.sql":                      _1528395666_add_campaign_dependenciesUpSql,
+	"1528395667_add_sort_key_to_campaigns.down.sql":                    _1528395667_add_sort_key_to_campaignsDownSql,
+	"1528395667_add_sort_key_to_campaigns.up.sql":                      _1528395667_add_sort_key_to_campaignsUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -1904,6 +2520,34 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395652_add_lsif_indexer.up.sql":                               {_1528395652_add_lsif_indexerUpSql, map[string]*bintree{}},
 	"1528395653_repo_normalize_visibility_metadata.down.sql":           {_1528395653_repo_normalize_visibility_metadataDownSql, map[string]*bintree{}},
 	"1528395653_repo_normalize_visibility_metadata.up.sql":             {_1528395653_repo_normalize_visibility_metadataUpSql, map[string]*bintree{}},
+	"1528395654_add_campaign_subscribers.down.sql":                     {_1528395654_add_campaign_subscribersDownSql, map[string]*bintree{}},
+	"1528395654_add_campaign_subscribers.up.sql":                       {_1528395654_add_campaign_subscribersUpSql, map[string]*bintree{}},
+	"1528395655_add_last_updated_by_to_campaigns.down.sql":             {_1528395655_add_last_updated_by_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395655_add_last_updated_by_to_campaigns.up.sql":               {_1528395655_add_last_updated_by_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395656_add_campaign_events.down.sql":                          {_1528395656_add_campaign_eventsDownSql, map[string]*bintree{}},
+	"1528395656_add_campaign_events.up.sql":                            {_1528395656_add_campaign_eventsUpSql, map[string]*bintree{}},
+	"1528395657_add_campaign_pins.down.sql":                            {_1528395657_add_campaign_pinsDownSql, map[string]*bintree{}},
+	"1528395657_add_campaign_pins.up.sql":                              {_1528395657_add_campaign_pinsUpSql, map[string]*bintree{}},
+	"1528395658_add_idempotency_key_to_campaigns.down.sql":             {_1528395658_add_idempotency_key_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395658_add_idempotency_key_to_campaigns.up.sql":               {_1528395658_add_idempotency_key_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395659_add_is_template_to_campaigns.down.sql":                 {_1528395659_add_is_template_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395659_add_is_template_to_campaigns.up.sql":                   {_1528395659_add_is_template_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395660_keep_events_of_deleted_campaigns.down.sql":             {_1528395660_keep_events_of_deleted_campaignsDownSql, map[string]*bintree{}},
+	"1528395660_keep_events_of_deleted_campaigns.up.sql":               {_1528395660_keep_events_of_deleted_campaignsUpSql, map[string]*bintree{}},
+	"1528395661_add_campaign_views.down.sql":                           {_1528395661_add_campaign_viewsDownSql, map[string]*bintree{}},
+	"1528395661_add_campaign_views.up.sql":                             {_1528395661_add_campaign_viewsUpSql, map[string]*bintree{}},
+	"1528395662_add_campaigns_trgm_indexes.down.sql":                   {_1528395662_add_campaigns_trgm_indexesDownSql, map[string]*bintree{}},
+	"1528395662_add_campaigns_trgm_indexes.up.sql":                     {_1528395662_add_campaigns_trgm_indexesUpSql, map[string]*bintree{}},
+	"1528395663_add_campaign_reminders.down.sql":                       {_1528395663_add_campaign_remindersDownSql, map[string]*bintree{}},
+	"1528395663_add_campaign_reminders.up.sql":                         {_1528395663_add_campaign_remindersUpSql, map[string]*bintree{}},
+	"1528395664_add_campaign_collaborators.down.sql":                   {_1528395664_add_campaign_collaboratorsDownSql, map[string]*bintree{}},
+	"1528395664_add_campaign_collaborators.up.sql":                     {_1528395664_add_campaign_collaboratorsUpSql, map[string]*bintree{}},
+	"1528395665_add_is_locked_to_campaigns.down.sql":                   {_1528395665_add_is_locked_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395665_add_is_locked_to_campaigns.up.sql":                     {_1528395665_add_is_locked_to_campaignsUpSql, map[string]*bintree{}},
+	"1528395666_add_campaign_dependencies.down.sql":                    {_1528395666_add_campaign_dependenciesDownSql, map[string]*bintree{}},
+	"1528395666_add_campaign_dependencies.up.sql":                      {_1528395666_add_campaign_dependenciesUpSql, map[string]*bintree{}},
+	"1528395667_add_sort_key_to_campaigns.down.sql":                    {_1528395667_add_sort_key_to_campaignsDownSql, map[string]*bintree{}},
+	"1528395667_add_sort_key_to_campaigns.up.sql":                      {_1528395667_add_sort_key_to_campaignsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
@@ -289,6 +289,22 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// CampaignsCreationRateLimit description: Limits how quickly campaigns can be created in a single namespace (a user or an organization). Campaign creations beyond the limit are rejected. This is a setting for the experimental campaigns feature.
+type CampaignsCreationRateLimit struct {
+	// Burst description: The number of campaigns that can be created in a namespace in quick succession.
+	Burst int `json:"burst,omitempty"`
+	// PerHour description: The number of campaigns that can be created per hour in a namespace, once the burst has been used up.
+	PerHour float64 `json:"perHour,omitempty"`
+}
+
+// CampaignsDescriptionLength description: Limits the length of campaign descriptions, measured in characters. This is a setting for the experimental campaigns feature.
+type CampaignsDescriptionLength struct {
+	// Max description: Descriptions longer than this are rejected.
+	Max int `json:"max,omitempty"`
+	// Warn description: Descriptions longer than this are accepted but logged.
+	Warn int `json:"warn,omitempty"`
+}
+
 // CloneURLToRepositoryName description: Describes a mapping from clone URL to repository name. The `from` field contains a regular expression with named capturing groups. The `to` field contains a template string that references capturing group names. For instance, if `from` is "^../(?P<name>\w+)$" and `to` is "github.com/user/{name}", the clone URL "../myRepository" would be mapped to the repository name "github.com/user/myRepository".
 type CloneURLToRepositoryName struct {
 	// From description: A regular expression that matches a set of clone URLs. The regular expression should use the Go regular expression syntax (https://golang.org/pkg/regexp/) and contain at least one named capturing group. The regular expression matches partially by default, so use "^...$" if whole-string matching is desired.
@@ -899,6 +915,12 @@ type SiteConfiguration struct {
 	//
 	// Only available in Sourcegraph Enterprise.
 	Branding *Branding `json:"branding,omitempty"`
+	// CampaignsCreationRateLimit description: Limits how quickly campaigns can be created in a single namespace (a user or an organization). Campaign creations beyond the limit are rejected. This is a setting for the experimental campaigns feature.
+	CampaignsCreationRateLimit *CampaignsCreationRateLimit `json:"campaigns.creationRateLimit,omitempty"`
+	// CampaignsDescriptionLength description: Limits the length of campaign descriptions, measured in characters. This is a setting for the experimental campaigns feature.
+	CampaignsDescriptionLength *CampaignsDescriptionLength `json:"campaigns.descriptionLength,omitempty"`
+	// CampaignsMaxListLimit description: The maximum number of campaigns returned by a single list request. Requests for more are reduced to this number and paginated. Defaults to 1000. This is a setting for the experimental campaigns feature.
+	CampaignsMaxListLimit int `json:"campaigns.maxListLimit,omitempty"`
 	// CampaignsReadAccessEnabled description: Enables read-only access to campaigns for non-site-admin users. This is a setting for the experimental campaigns feature. These will only have an effect when campaigns is enabled with `{"experimentalFeatures": {"automation": "enabled"}}`.
 	CampaignsReadAccessEnabled *bool `json:"campaigns.readAccess.enabled,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
@@ -933,6 +955,8 @@ type SiteConfiguration struct {
 	GitCloneURLToRepositoryName []*CloneURLToRepositoryName `json:"git.cloneURLToRepositoryName,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently to update repositories.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitMaxRawDiffBytes description: Maximum size in bytes of the raw diff text of a commit returned by the GraphQL API. Larger diffs are truncated. Defaults to 1048576 (1 MiB).
+	GitMaxRawDiffBytes int `json:"gitMaxRawDiffBytes,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
 	GithubClientID string `json:"githubClientID,omitempty"`
 	// GithubClientSecret description: Client secret for GitHub. (DEPRECATED)
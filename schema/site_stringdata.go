@@ -173,6 +173,51 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Campaigns"
     },
+    "campaigns.creationRateLimit": {
+      "description": "Limits how quickly campaigns can be created in a single namespace (a user or an organization). Campaign creations beyond the limit are rejected. This is a setting for the experimental campaigns feature.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "perHour": {
+          "description": "The number of campaigns that can be created per hour in a namespace, once the burst has been used up.",
+          "type": "number",
+          "exclusiveMinimum": 0
+        },
+        "burst": {
+          "description": "The number of campaigns that can be created in a namespace in quick succession.",
+          "type": "integer",
+          "minimum": 1
+        }
+      },
+      "default": { "perHour": 60, "burst": 10 },
+      "group": "Campaigns"
+    },
+    "campaigns.descriptionLength": {
+      "description": "Limits the length of campaign descriptions, measured in characters. This is a setting for the experimental campaigns feature.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "warn": {
+          "description": "Descriptions longer than this are accepted but logged.",
+          "type": "integer",
+          "minimum": 1
+        },
+        "max": {
+          "description": "Descriptions longer than this are rejected.",
+          "type": "integer",
+          "minimum": 1
+        }
+      },
+      "default": { "warn": 65536, "max": 1048576 },
+      "group": "Campaigns"
+    },
+    "campaigns.maxListLimit": {
+      "description": "The maximum number of campaigns returned by a single list request. Requests for more are reduced to this number and paginated. Defaults to 1000. This is a setting for the experimental campaigns feature.",
+      "type": "integer",
+      "minimum": 1,
+      "default": 1000,
+      "group": "Campaigns"
+    },
     "corsOrigin": {
       "description": "Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.",
       "type": "string",
@@ -243,6 +288,13 @@ const SiteSchemaJSON = `{
       "default": 5,
       "group": "External services"
     },
+    "gitMaxRawDiffBytes": {
+      "description": "Maximum size in bytes of the raw diff text of a commit returned by the GraphQL API. Larger diffs are truncated. Defaults to 1048576 (1 MiB).",
+      "type": "integer",
+      "minimum": 1,
+      "default": 1048576,
+      "group": "Misc."
+    },
     "repoListUpdateInterval": {
       "description": "Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.",
       "type": "integer",